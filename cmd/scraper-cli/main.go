@@ -5,14 +5,23 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"job-scraper-go/internal/api"
 	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
 	"job-scraper-go/internal/models"
 	"job-scraper-go/internal/scraper"
 	"job-scraper-go/internal/scraper/sources"
 	"job-scraper-go/internal/storage"
 	"job-scraper-go/pkg/httpclient"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -20,13 +29,25 @@ import (
 
 func main() {
 	var (
-		configFile = flag.String("config", "config.json", "Configuration file path")
-		command    = flag.String("cmd", "scrape", "Command to run: scrape, metrics, test, config, sources")
-		source     = flag.String("source", "", "Specific source to scrape (remoteok, remotive)")
-		category   = flag.String("category", "", "Filter by category (software-dev, devops, data, etc.)")
-		output     = flag.String("output", "console", "Output format: console, json")
-		verbose    = flag.Bool("verbose", false, "Verbose output")
-		help       = flag.Bool("help", false, "Show help message")
+		configFile  = flag.String("config", "config.json", "Configuration file path")
+		command     = flag.String("cmd", "scrape", "Command to run: scrape, export, metrics, test, health, config, sources, serve, init")
+		source      = flag.String("source", "", "Source to scrape, or comma-separated sources (remoteok,remotive)")
+		category    = flag.String("category", "", "Filter by category (software-dev, devops, data, etc.)")
+		jobType     = flag.String("job-type", "", "With -cmd export, only export jobs of this type (full-time, contract, etc.)")
+		output      = flag.String("output", "console", "Output format: console, json, csv, jsonl")
+		outFile     = flag.String("out-file", "", "Write output to this file instead of stdout")
+		fieldList   = flag.String("fields", "", "Comma-separated job fields for json, csv and jsonl job output (title,company,url, etc.)")
+		limit       = flag.Int("limit", 0, "Maximum number of jobs to keep per source (0 = unlimited)")
+		since       = flag.String("since", "", "Only keep jobs posted within a duration (72h, 7d) or after a date (2006-01-02)")
+		dropUndated = flag.Bool("drop-undated", false, "With -since, also drop jobs without a posted date")
+		dryRun      = flag.Bool("dry-run", false, "With -cmd scrape, fetch and report jobs without saving them")
+		timeout     = flag.Duration("timeout", 0, "With -cmd scrape, deadline for the whole scrape, e.g. 10m; 0 for none (default: scraper.scrape_timeout)")
+		similarity  = flag.Float64("similarity", 0, "Similarity between 0 and 1 above which jobs from different sources are near-duplicates (default: scraper.similarity_threshold)")
+		check       = flag.Bool("check", false, "With -cmd sources, health check each source")
+		force       = flag.Bool("force", false, "With -cmd init, overwrite an existing configuration file")
+		verbose     = flag.Bool("verbose", false, "Verbose output, including debug logs")
+		quiet       = flag.Bool("quiet", false, "Only print errors and final results")
+		help        = flag.Bool("help", false, "Show help message")
 	)
 	flag.Parse()
 
@@ -36,29 +57,99 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *verbose && *quiet {
+		log.Fatalf("-verbose and -quiet cannot be combined")
+	}
+	if *quiet {
+		statusOut = io.Discard
+	}
+
 	// Load environment variables
-	if err := godotenv.Load(); err != nil {
+	if err := godotenv.Load(); err != nil && !*quiet {
 		log.Printf("Warning: Could not load .env file: %v", err)
 	}
 
+	// Writing a new configuration must not depend on loading the existing one
+	if *command == "init" {
+		runInitCommand(*configFile, *force)
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig(*configFile)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	logger := newLogger(os.Stderr, cfg, *verbose, *quiet)
+
+	// Parse the posting date filter
+	var postedAfter time.Time
+	if *since != "" {
+		postedAfter, err = parseSince(*since, time.Now())
+		if err != nil {
+			log.Fatalf("Invalid -since value: %v", err)
+		}
+	}
+
+	// A -similarity flag overrides the configured near-duplicate threshold
+	similarityThreshold := cfg.Scraper.SimilarityThreshold
+	if flagSet("similarity") {
+		if *similarity <= 0 || *similarity >= 1 {
+			log.Fatalf("Invalid -similarity value: must be between 0 and 1, got %v", *similarity)
+		}
+		similarityThreshold = *similarity
+		// Near-duplicates are only suppressed across sources
+		if names := parseSourceNames(*source); len(names) == 1 && *category == "" {
+			logger.Warnf("-similarity has no effect when scraping the single source %s", names[0])
+		}
+	}
+
+	// A -timeout flag overrides the configured scrape deadline
+	if flagSet("timeout") {
+		if *timeout < 0 {
+			log.Fatalf("Invalid -timeout value: cannot be negative, got %v", *timeout)
+		}
+		cfg.Scraper.ScrapeTimeout.Duration = *timeout
+	}
+
+	// Parse the output field selection
+	fields, err := parseFields(*fieldList)
+	if err != nil {
+		log.Fatalf("Invalid -fields value: %v", err)
+	}
+
 	// Execute command
 	switch *command {
 	case "scrape":
-		runScrapeCommand(cfg, *source, *category, *output, *verbose)
+		options := scraper.Options{
+			SimilarityThreshold: similarityThreshold,
+			MaxJobs:             *limit,
+			PostedAfter:         postedAfter,
+			DropUndated:         *dropUndated,
+			DryRun:              *dryRun,
+		}
+		runScrapeCommand(cfg, *source, *category, *output, *outFile, fields, options, logger)
+	case "export":
+		filter := storage.JobFilter{
+			Source:   *source,
+			Category: *category,
+			JobType:  *jobType,
+			Limit:    *limit,
+		}
+		runExportCommand(cfg, filter, postedAfter, *output, *outFile, fields)
 	case "metrics":
 		runMetricsCommand(cfg, *output)
 	case "test":
-		runTestCommand(cfg, *source, *verbose)
+		runTestCommand(cfg, *source, logger)
+	case "health":
+		runHealthCommand(cfg)
 	case "config":
 		runConfigCommand(cfg, *output)
 	case "sources":
-		runSourcesCommand(cfg, *output)
+		runSourcesCommand(cfg, *output, *check, logger)
+	case "serve":
+		runServeCommand(cfg, logger)
 	default:
 		fmt.Printf("Unknown command: %s\n", *command)
 		printUsage()
@@ -66,88 +157,238 @@ func main() {
 	}
 }
 
-func runScrapeCommand(cfg *config.Config, source, category, output string, verbose bool) {
-	fmt.Println("Starting job scraping...")
+// parseSourceNames splits the comma-separated -source flag into source names
+func parseSourceNames(source string) []string {
+	var names []string
+	for _, name := range strings.Split(source, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func runScrapeCommand(cfg *config.Config, source, category, output, outFile string, fields []string, options scraper.Options, logger *logging.Logger) {
+	if isJobOutput(output) && outFile == "" && statusOut == os.Stdout {
+		statusOut = os.Stderr
+	}
+	statusf("Starting job scraping...\n")
+
+	sourceNames := parseSourceNames(source)
+	// Category runs and runs of several sources scrape the listed sources
+	if len(sourceNames) > 1 || category != "" {
+		options.Sources = sourceNames
+	}
 
 	// Initialize components
-	httpClient := httpclient.NewHttpClient(cfg.Scraper.RequestTimeout)
-	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey)
+	httpClient, err := scraper.NewHTTPClient(cfg.Scraper)
 	if err != nil {
-		log.Fatalf("Failed to initialize storage: %v", err)
+		log.Fatalf("Failed to create HTTP client: %v", err)
 	}
-
-	logger := log.New(os.Stdout, "", log.LstdFlags)
-	if !verbose {
-		logger.SetOutput(log.Writer())
+	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey, cfg.Database.Table)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := scraper.WithScrapeTimeout(context.Background(), cfg.Scraper.ScrapeTimeout.Duration)
 	defer cancel()
 
-	var metrics *scraper.ScraperMetrics
+	powerScraper := scraper.NewPowerScraper(store, httpClient, logger)
+	powerScraper.SetRetryConfig(scraper.NewRetryConfig(cfg.Scraper))
+	powerScraper.SetSaveRetryConfig(scraper.NewSaveRetryConfig(cfg.Scraper))
+	powerScraper.SetSaveConcurrency(cfg.Scraper.ConcurrentSaves)
+	powerScraper.SetMergeDuplicates(cfg.Scraper.MergeDuplicates)
+	powerScraper.SetRefetchMalformedJSON(cfg.Scraper.RefetchMalformedJSON)
+	powerScraper.SetSourcePriority(cfg.Scraper.SourcePriority)
+	powerScraper.SetValidationLevel(cfg.Scraper.Validation)
+	powerScraper.SetOptions(options)
+	powerScraper.InitializeSources(cfg.Sources)
+	if cfg.Monitoring.MetricsFile != "" {
+		powerScraper.SetMetricsStore(scraper.NewMetricsStore(cfg.Monitoring.MetricsFile))
+	}
 
-	if source != "" {
-		// Scrape specific source
-		fmt.Printf("Scraping specific source: %s\n", source)
-		if category != "" {
-			fmt.Printf("Filtering by category: %s\n", category)
+	if cfg.Scraper.SeedDedup {
+		if err := powerScraper.SeedDeduplicator(ctx); err != nil {
+			log.Fatalf("Failed to seed deduplicator: %v", err)
 		}
-		metrics = scrapeSingleSource(httpClient, store, source, category, logger, ctx)
-	} else {
-		// Scrape all sources
-		powerScraper := scraper.NewPowerScraper(store, httpClient, logger)
-		powerScraper.InitializeSources()
+	}
 
-		if err := powerScraper.ScrapeAllSources(ctx); err != nil {
+	var jobs []models.Job
+	if len(sourceNames) == 1 && category == "" {
+		// Scrape specific source
+		statusf("Scraping specific source: %s\n", sourceNames[0])
+		result, err := powerScraper.ScrapeSource(ctx, sourceNames[0])
+		if err != nil {
 			log.Fatalf("Scraping failed: %v", err)
 		}
-
-		metricsValue := powerScraper.GetMetrics()
-		metrics = &metricsValue
+		if options.DryRun {
+			statusf("Would save %d jobs from %s\n", len(result.Jobs), result.Source)
+		} else {
+			statusf("Saved %d of %d jobs from %s\n", result.SavedCount, len(result.Jobs), result.Source)
+		}
+		jobs = result.Jobs
+	} else if category != "" {
+		// Scrape the categories from every source, or the listed ones
+		statusf("Filtering %s by categories: %s\n", describeSources(sourceNames), category)
+		report, err := powerScraper.ScrapeByCategory(ctx, strings.Split(category, ","))
+		if err != nil {
+			log.Fatalf("Scraping failed: %v (%s)", err, report.Summary())
+		}
+		statusf("Run report: %s\n", report.Summary())
+		jobs = report.Jobs
+	} else {
+		// Scrape all sources, or the listed ones
+		if len(sourceNames) > 1 {
+			statusf("Scraping sources: %s\n", strings.Join(sourceNames, ", "))
+		}
+		report, err := powerScraper.ScrapeAllSources(ctx)
+		if err != nil {
+			log.Fatalf("Scraping failed: %v (%s)", err, report.Summary())
+		}
+		statusf("Run report: %s\n", report.Summary())
+		jobs = report.Jobs
 	}
 
+	metrics := powerScraper.GetMetrics()
+
 	// Output results
-	if output == "json" {
-		outputJSON(metrics)
-	} else {
-		outputConsole(metrics)
+	out, err := openOutput(outFile)
+	if err != nil {
+		log.Fatalf("Failed to open output: %v", err)
+	}
+	defer out.Close()
+
+	switch output {
+	case "json":
+		outputJSON(out, &metrics)
+	case "csv":
+		if err := writeJobsCSV(out, jobs, fields); err != nil {
+			log.Fatalf("Failed to write CSV: %v", err)
+		}
+	case "jsonl":
+		if err := writeJobsJSONL(out, jobs, fields); err != nil {
+			log.Fatalf("Failed to write JSON Lines: %v", err)
+		}
+	default:
+		outputConsole(out, &metrics)
+		if options.DryRun {
+			fmt.Fprintln(out)
+			outputJobsConsole(out, "Would Save", jobs)
+		}
+	}
+
+	if outFile != "" {
+		statusf("Wrote %s output to %s\n", output, outFile)
 	}
 }
 
 func runMetricsCommand(cfg *config.Config, output string) {
-	// For now, we'll just show configuration as we don't have persistent metrics
-	fmt.Println("Configuration-based metrics:")
-	if output == "json" {
-		outputJSON(cfg)
-	} else {
-		fmt.Printf("Concurrent Sources: %d\n", cfg.Scraper.ConcurrentSources)
-		fmt.Printf("Batch Size: %d\n", cfg.Scraper.BatchSize)
-		fmt.Printf("Scraping Interval: %v\n", cfg.Scraper.ScrapingInterval)
-		fmt.Printf("Enabled Sources: ")
+	snapshot, snapshotErr := latestMetricsSnapshot(cfg)
+	storedJobs, err := countStoredJobs(cfg)
 
-		var enabled []string
-		if cfg.Sources.RemoteOK.Enabled {
-			enabled = append(enabled, "RemoteOK")
+	if output == "json" {
+		result := map[string]interface{}{}
+		if snapshot != nil {
+			result["last_run"] = snapshot
+		}
+		if err == nil {
+			result["stored_jobs"] = storedJobs
 		}
-		if cfg.Sources.Remotive.Enabled {
-			enabled = append(enabled, "Remotive")
+		outputJSON(os.Stdout, result)
+		return
+	}
+
+	switch {
+	case snapshotErr != nil:
+		fmt.Printf("Last run metrics: unavailable (%v)\n", snapshotErr)
+	case snapshot == nil:
+		fmt.Println("Last run metrics: none saved yet")
+	default:
+		fmt.Printf("Last run metrics (saved %s):\n", snapshot.Time.Local().Format(time.RFC1123))
+		outputConsole(os.Stdout, &snapshot.Metrics)
+	}
+	fmt.Println()
+
+	if err != nil {
+		fmt.Printf("Stored Jobs: unavailable (%v)\n", err)
+		return
+	}
+
+	fmt.Printf("Stored Jobs: %d\n", storedJobs["total"])
+	var names []string
+	for name := range storedJobs {
+		if name != "total" {
+			names = append(names, name)
 		}
-		if cfg.Sources.WeWorkRemotely.Enabled {
-			enabled = append(enabled, "WeWorkRemotely")
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %s: %d\n", name, storedJobs[name])
+	}
+}
+
+// latestMetricsSnapshot reads the metrics saved after the last scraping run
+func latestMetricsSnapshot(cfg *config.Config) (*scraper.MetricsSnapshot, error) {
+	if cfg.Monitoring.MetricsFile == "" {
+		return nil, fmt.Errorf("monitoring.metrics_file is not set")
+	}
+	return scraper.NewMetricsStore(cfg.Monitoring.MetricsFile).Latest()
+}
+
+// describeSources names the sources of a run for status messages
+func describeSources(names []string) string {
+	if len(names) == 0 {
+		return "all sources"
+	}
+	return strings.Join(names, ", ")
+}
+
+// countStoredJobs counts stored jobs in total and for each registered source and feed
+func countStoredJobs(cfg *config.Config) (map[string]int64, error) {
+	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey, cfg.Database.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jobs are stored under the source's display name, e.g. "RemoteOK"
+	var sourceNames []string
+	for _, name := range sources.FactoryNames() {
+		if source, err := sources.BuildSource(name, nil); err == nil {
+			sourceNames = append(sourceNames, source.GetName())
 		}
+	}
+	for _, feed := range cfg.Sources.Feeds {
+		sourceNames = append(sourceNames, feed.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-		fmt.Printf("%v\n", enabled)
+	counts := make(map[string]int64)
+	if counts["total"], err = store.Count(ctx, storage.JobFilter{}); err != nil {
+		return nil, err
 	}
+	for _, name := range sourceNames {
+		if counts[name], err = store.Count(ctx, storage.JobFilter{Source: name}); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
 }
 
-func runTestCommand(cfg *config.Config, source string, verbose bool) {
+func runTestCommand(cfg *config.Config, source string, logger *logging.Logger) {
 	fmt.Println("Testing job sources...")
 
-	httpClient := httpclient.NewHttpClient(cfg.Scraper.RequestTimeout)
-	logger := log.New(os.Stdout, "", log.LstdFlags)
-	if !verbose {
-		logger = log.New(log.Writer(), "", 0)
+	httpClient, err := scraper.NewHTTPClient(cfg.Scraper)
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
 	}
+	// The test command fetches sources directly, so retry transient failures here
+	httpClient.SetRetryPolicy(httpclient.RetryPolicy{
+		MaxAttempts:  cfg.Scraper.RetryAttempts + 1,
+		InitialDelay: cfg.Scraper.RetryDelay.Duration,
+		MaxDelay:     cfg.Scraper.MaxRetryDelay.Duration,
+	})
 
 	// Test specific source or all sources
 	if source != "" {
@@ -157,163 +398,379 @@ func runTestCommand(cfg *config.Config, source string, verbose bool) {
 	}
 }
 
+func runHealthCommand(cfg *config.Config) {
+	fmt.Println("Checking job source health...")
+
+	httpClient, err := scraper.NewHTTPClient(cfg.Scraper)
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
+	}
+
+	var enabled []sources.JobSource
+	sourceConfigs := cfg.Sources.ByName()
+	for _, name := range sources.FactoryNames() {
+		if !sourceConfigs[name].Enabled {
+			continue
+		}
+		source, err := sources.BuildSource(name, httpClient)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		enabled = append(enabled, source)
+	}
+	for _, feed := range cfg.Sources.Feeds {
+		if feed.Enabled {
+			enabled = append(enabled, scraper.NewFeedSource(httpClient, feed))
+		}
+	}
+
+	failed := 0
+	for _, source := range enabled {
+		start := time.Now()
+		if err := source.HealthCheck(context.Background()); err != nil {
+			fmt.Printf("❌ %s: %v\n", source.GetName(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ %s: healthy (%v)\n", source.GetName(), time.Since(start).Round(time.Millisecond))
+	}
+
+	if failed > 0 {
+		fmt.Printf("%d of %d sources unhealthy\n", failed, len(enabled))
+		os.Exit(1)
+	}
+}
+
 func runConfigCommand(cfg *config.Config, output string) {
 	if output == "json" {
-		outputJSON(cfg)
+		outputJSON(os.Stdout, cfg)
 	} else {
 		fmt.Println("Current Configuration:")
-		fmt.Printf("Database URL: %s\n", maskString(cfg.Database.SupabaseURL))
-		fmt.Printf("Database Key: %s\n", maskString(cfg.Database.SupabaseKey))
-		fmt.Printf("Scraping Interval: %v\n", cfg.Scraper.ScrapingInterval)
+		fmt.Printf("Database URL: %s\n", config.MaskSecret(cfg.Database.SupabaseURL))
+		fmt.Printf("Database Key: %s\n", config.MaskSecret(cfg.Database.SupabaseKey))
+		fmt.Printf("Scraping Interval: %v\n", cfg.Scraper.ScrapingInterval.Duration)
 		fmt.Printf("Concurrent Sources: %d\n", cfg.Scraper.ConcurrentSources)
 		fmt.Printf("Monitoring Enabled: %t\n", cfg.Monitoring.Enabled)
 	}
 }
 
-func runSourcesCommand(cfg *config.Config, output string) {
-	sources := map[string]config.SourceConfig{
-		"RemoteOK":       cfg.Sources.RemoteOK,
-		"Remotive":       cfg.Sources.Remotive,
-		"WeWorkRemotely": cfg.Sources.WeWorkRemotely,
+// runSourcesCommand lists the sources registered from the configuration,
+// health checking them when check is set
+func runSourcesCommand(cfg *config.Config, output string, check bool, logger *logging.Logger) {
+	httpClient, err := scraper.NewHTTPClient(cfg.Scraper)
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
 	}
 
+	powerScraper := scraper.NewPowerScraper(nil, httpClient, logger)
+	powerScraper.InitializeSources(cfg.Sources)
+
+	statuses := powerScraper.SourceStatuses(context.Background(), check)
 	if output == "json" {
-		outputJSON(sources)
+		outputJSON(os.Stdout, statuses)
 	} else {
-		fmt.Println("Available Job Sources:")
-		for name, sourceConfig := range sources {
-			status := "disabled"
-			if sourceConfig.Enabled {
-				status = "enabled"
-			}
-			fmt.Printf("- %s: %s (rate limit: %d/min)\n", name, status, sourceConfig.RateLimit)
-		}
+		outputSourcesConsole(os.Stdout, statuses)
 	}
 }
 
-func testSingleSource(client *httpclient.HttpClient, sourceName string, logger *log.Logger) {
-	fmt.Printf("Testing source: %s\n", sourceName)
-
-	start := time.Now()
+// outputSourcesConsole prints one line per source with its rate limit and,
+// if checked, its health
+func outputSourcesConsole(w io.Writer, statuses []scraper.SourceStatus) {
+	fmt.Fprintln(w, "Available Job Sources:")
+	for _, status := range statuses {
+		state := "disabled"
+		if status.Enabled {
+			state = "enabled"
+		}
 
-	switch sourceName {
-	case "remoteok":
-		source := sources.NewRemoteOKSource(client)
-		jobs, err := source.FetchJobs()
-		if err != nil {
-			fmt.Printf("❌ RemoteOK test failed: %v\n", err)
-			return
+		window := "min"
+		if status.RateWindow != time.Minute {
+			window = status.RateWindow.String()
 		}
-		fmt.Printf("✅ RemoteOK test passed: fetched %d jobs in %v\n", len(jobs), time.Since(start))
 
-	case "remotive":
-		source := sources.NewRemotiveSource(client)
-		jobs, err := source.FetchJobs()
-		if err != nil {
-			fmt.Printf("❌ Remotive test failed: %v\n", err)
-			return
+		health := ""
+		switch {
+		case status.Checked && status.Healthy:
+			health = fmt.Sprintf(", healthy (%v)", status.ResponseTime.Round(time.Millisecond))
+		case status.Checked:
+			health = fmt.Sprintf(", unreachable: %s", status.Error)
+		case status.ResponseTime > 0:
+			health = fmt.Sprintf(", last response time: %v", status.ResponseTime.Round(time.Millisecond))
 		}
-		fmt.Printf("✅ Remotive test passed: fetched %d jobs in %v\n", len(jobs), time.Since(start))
 
-	default:
-		fmt.Printf("❌ Unknown source: %s\n", sourceName)
+		fmt.Fprintf(w, "- %s: %s (rate limit: %d/%s, %s)%s\n", status.Name, state, status.RateLimit, window, status.BaseURL, health)
+	}
+}
+
+func runInitCommand(configFile string, force bool) {
+	if err := writeDefaultConfig(configFile, force); err != nil {
+		log.Fatal(err)
 	}
+	fmt.Printf("Wrote default configuration to %s\n", configFile)
 }
 
-func testAllSources(client *httpclient.HttpClient, cfg *config.Config, logger *log.Logger) {
-	if cfg.Sources.RemoteOK.Enabled {
-		testSingleSource(client, "remoteok", logger)
+// writeDefaultConfig writes the default configuration to configFile, refusing
+// to replace an existing file unless force is set
+func writeDefaultConfig(configFile string, force bool) error {
+	if _, err := os.Stat(configFile); err == nil && !force {
+		return fmt.Errorf("configuration file %s already exists, use -force to overwrite it", configFile)
 	}
 
-	if cfg.Sources.Remotive.Enabled {
-		testSingleSource(client, "remotive", logger)
+	// Keep credentials out of the file; they are read from SUPABASE_URL / SUPABASE_KEY
+	cfg := config.DefaultConfig()
+	cfg.Database = config.DatabaseConfig{}
+
+	if err := cfg.SaveConfig(configFile); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
 	}
+	return nil
 }
 
-// scrapeSingleSource scrapes a specific source and returns metrics
-func scrapeSingleSource(client *httpclient.HttpClient, store storage.Store, sourceName, category string, logger *log.Logger, ctx context.Context) *scraper.ScraperMetrics {
-	// Initialize sources
-	remoteOKSource := sources.NewRemoteOKSource(client)
-	remotiveSource := sources.NewRemotiveSource(client)
+func runExportCommand(cfg *config.Config, filter storage.JobFilter, postedAfter time.Time, output, outFile string, fields []string) {
+	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey, cfg.Database.Table)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
 
-	var jobs []models.Job
-	var err error
-
-	switch sourceName {
-	case "remoteok":
-		jobs, err = remoteOKSource.FetchJobs()
-	case "remotive":
-		// Check if category filtering is requested
-		if category != "" {
-			fmt.Printf("Fetching jobs from Remotive with category: %s\n", category)
-			jobs, err = remotiveSource.FetchJobsByCategory(category)
-		} else {
-			jobs, err = remotiveSource.FetchJobs()
+	out, err := openOutput(outFile)
+	if err != nil {
+		log.Fatalf("Failed to open output: %v", err)
+	}
+	defer out.Close()
+
+	count, err := exportJobs(context.Background(), store, filter, postedAfter, output, fields, out)
+	if err != nil {
+		log.Fatalf("Failed to export jobs: %v", err)
+	}
+
+	if outFile != "" {
+		fmt.Printf("Exported %d jobs as %s to %s\n", count, output, outFile)
+	}
+}
+
+// exportJobs writes the stored jobs matching the filter and posted after
+// postedAfter, if set, and returns how many were written. The -source flag
+// takes registry names such as "remoteok" while jobs store the source's
+// display name, so known names are resolved first. Structured output only
+// includes the given fields, if any are selected.
+func exportJobs(ctx context.Context, store storage.Store, filter storage.JobFilter, postedAfter time.Time, output string, fields []string, out io.Writer) (int, error) {
+	if filter.Source != "" {
+		if source, err := sources.BuildSource(filter.Source, nil); err == nil {
+			filter.Source = source.GetName()
+		}
+	}
+
+	// The posting date is filtered here, so the limit applies afterwards
+	limit := filter.Limit
+	if !postedAfter.IsZero() {
+		filter.Limit = 0
+	}
+
+	jobs, err := store.GetJobsFiltered(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if !postedAfter.IsZero() {
+		jobs = scraper.FilterPostedAfter(jobs, postedAfter, false)
+		if limit > 0 && limit < len(jobs) {
+			jobs = jobs[:limit]
+		}
+	}
+
+	switch output {
+	case "json":
+		if len(fields) > 0 {
+			outputJSON(out, selectFields(jobs, fields))
+			break
+		}
+		if jobs == nil {
+			jobs = []models.Job{}
 		}
+		outputJSON(out, jobs)
+	case "csv":
+		err = writeJobsCSV(out, jobs, fields)
+	case "jsonl":
+		err = writeJobsJSONL(out, jobs, fields)
 	default:
-		log.Fatalf("Unknown source: %s. Available sources: remoteok, remotive", sourceName)
+		outputJobsConsole(out, "Stored Jobs", jobs)
+	}
+	if err != nil {
+		return 0, err
 	}
+	return len(jobs), nil
+}
 
+func runServeCommand(cfg *config.Config, logger *logging.Logger) {
+	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey, cfg.Database.Table)
 	if err != nil {
-		log.Fatalf("Failed to fetch jobs from %s: %v", sourceName, err)
+		log.Fatalf("Failed to initialize storage: %v", err)
 	}
 
-	fmt.Printf("Fetched %d jobs from %s\n", len(jobs), sourceName)
+	server := api.NewServer(store, logger).NewHTTPServer(cfg.Server)
 
-	// Save jobs to storage
-	if len(jobs) > 0 {
-		if err := store.SaveJobs(jobs); err != nil {
-			log.Printf("Error saving jobs to storage: %v", err)
-		} else {
-			fmt.Printf("Successfully saved %d jobs\n", len(jobs))
+	go func() {
+		fmt.Printf("Serving job API on %s\n", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("API server failed: %v", err)
 		}
+	}()
+
+	// Wait for shutdown signal
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("API server shutdown failed: %v", err)
 	}
+	fmt.Println("API server stopped")
+}
+
+func testSingleSource(client *httpclient.HttpClient, sourceName string, logger *logging.Logger) {
+	fmt.Printf("Testing source: %s\n", sourceName)
 
-	// Return simplified metrics
-	metrics := &scraper.ScraperMetrics{
-		TotalJobsScraped: int64(len(jobs)),
-		TotalJobsSaved:   int64(len(jobs)), // Assuming all are saved for now
-		TotalDuplicates:  0,                // Would need actual duplicate tracking
-		TotalErrors:      0,
-		ScrapingDuration: time.Minute, // Approximate
+	start := time.Now()
+
+	source, err := sources.BuildSource(sourceName, client)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		fmt.Printf("❌ %s test failed: %v\n", source.GetName(), err)
+		return
 	}
+	fmt.Printf("✅ %s test passed: fetched %d jobs in %v\n", source.GetName(), len(jobs), time.Since(start))
+}
 
-	return metrics
+func testAllSources(client *httpclient.HttpClient, cfg *config.Config, logger *logging.Logger) {
+	sourceConfigs := cfg.Sources.ByName()
+	for _, name := range sources.FactoryNames() {
+		if sourceConfigs[name].Enabled {
+			testSingleSource(client, name, logger)
+		}
+	}
 }
 
-func outputJSON(data interface{}) {
-	encoder := json.NewEncoder(os.Stdout)
+func outputJSON(w io.Writer, data interface{}) {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(data); err != nil {
 		log.Printf("Failed to encode JSON: %v", err)
 	}
 }
 
-func outputConsole(metrics *scraper.ScraperMetrics) {
-	fmt.Println("=== Scraping Results ===")
-	fmt.Printf("Total Jobs Scraped: %d\n", metrics.TotalJobsScraped)
-	fmt.Printf("Total Jobs Saved: %d\n", metrics.TotalJobsSaved)
-	fmt.Printf("Total Duplicates: %d\n", metrics.TotalDuplicates)
-	fmt.Printf("Total Errors: %d\n", metrics.TotalErrors)
-	fmt.Printf("Scraping Duration: %v\n", metrics.ScrapingDuration)
+func outputConsole(w io.Writer, metrics *scraper.ScraperMetrics) {
+	fmt.Fprintln(w, "=== Scraping Results ===")
+	fmt.Fprintf(w, "Total Jobs Scraped: %d\n", metrics.TotalJobsScraped)
+	fmt.Fprintf(w, "Total Jobs Saved: %d\n", metrics.TotalJobsSaved)
+	fmt.Fprintf(w, "Total Duplicates: %d (%d within sources, %d across sources)\n",
+		metrics.TotalDuplicates, metrics.WithinSourceDuplicates, metrics.CrossSourceDuplicates)
+	fmt.Fprintf(w, "Total Errors: %d\n", metrics.TotalErrors)
+	if metrics.SuspiciousRuns > 0 {
+		fmt.Fprintf(w, "Suspicious Runs: %d\n", metrics.SuspiciousRuns)
+	}
+	fmt.Fprintf(w, "Scraping Duration: %v\n", metrics.ScrapingDuration)
 
 	if len(metrics.SourcePerformance) > 0 {
-		fmt.Println("\n=== Source Performance ===")
+		fmt.Fprintln(w, "\n=== Source Performance ===")
 		for source, perf := range metrics.SourcePerformance {
-			fmt.Printf("%s:\n", source)
-			fmt.Printf("  Jobs Scraped: %d\n", perf.JobsScraped)
-			fmt.Printf("  Duplicates: %d\n", perf.Duplicates)
-			fmt.Printf("  Errors: %d\n", perf.Errors)
-			fmt.Printf("  Response Time: %v\n", perf.ResponseTime)
+			fmt.Fprintf(w, "%s:\n", source)
+			fmt.Fprintf(w, "  Jobs Scraped: %d\n", perf.JobsScraped)
+			fmt.Fprintf(w, "  Duplicates: %d (%d from other sources)\n", perf.Duplicates, perf.CrossSourceDuplicates)
+			fmt.Fprintf(w, "  Errors: %d\n", perf.Errors)
+			if perf.SuspiciousRuns > 0 {
+				fmt.Fprintf(w, "  Suspicious Runs: %d\n", perf.SuspiciousRuns)
+			}
+			fmt.Fprintf(w, "  Response Time: %v (average %v)\n", perf.ResponseTime, perf.AvgResponseTime().Round(time.Millisecond))
+			fmt.Fprintf(w, "  Success Rate: %.0f%% of %d scrapes\n", perf.SuccessRate()*100, perf.Requests)
+			if perf.LastError != "" {
+				fmt.Fprintf(w, "  Last Error: %s (%s)\n", perf.LastError, perf.LastErrorTime.Format("2006-01-02 15:04:05"))
+			}
 		}
 	}
 }
 
-func maskString(s string) string {
-	if len(s) <= 8 {
-		return "***"
+// outputJobsConsole lists jobs under a heading, one per line
+func outputJobsConsole(w io.Writer, heading string, jobs []models.Job) {
+	fmt.Fprintf(w, "=== %s (%d) ===\n", heading, len(jobs))
+	for _, job := range jobs {
+		posted := "undated"
+		if job.PostedDate != nil {
+			posted = job.PostedDate.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%s  %s at %s [%s]\n", posted, job.Title, job.Company, job.Source)
+		fmt.Fprintf(w, "            %s\n", job.URL)
+	}
+}
+
+// parseSince parses a -since value: a duration before now ("72h", "7d") or a
+// date ("2006-01-02" or RFC 3339)
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", value)
+		}
+		return now.Add(-duration), nil
+	}
+
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is neither a duration (72h, 7d) nor a date (2006-01-02)", value)
+}
+
+// newLogger builds the logger shared by the commands. main passes stderr so
+// that results on stdout stay machine-readable. -verbose logs debug messages
+// and -quiet only errors; otherwise monitoring.log_level applies. Lines use
+// monitoring.log_format.
+func newLogger(out io.Writer, cfg *config.Config, verbose, quiet bool) *logging.Logger {
+	level := parseLogLevel(cfg)
+	switch {
+	case verbose:
+		level = logging.LevelDebug
+	case quiet:
+		level = logging.LevelError
+	}
+
+	format, err := logging.ParseFormat(cfg.Monitoring.LogFormat)
+	if err != nil {
+		log.Printf("Warning: %v, defaulting to text", err)
+	}
+	return logging.NewWithFormat(out, "", log.LstdFlags, level, format)
+}
+
+// flagSet reports whether the named flag was given on the command line
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseLogLevel returns the configured log level, defaulting to info when invalid
+func parseLogLevel(cfg *config.Config) logging.Level {
+	level, err := logging.ParseLevel(cfg.Monitoring.LogLevel)
+	if err != nil {
+		log.Printf("Warning: %v, defaulting to info", err)
 	}
-	return s[:4] + "***" + s[len(s)-4:]
+	return level
 }
 
 func printUsage() {
@@ -323,22 +780,46 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  -cmd scrape    - Run job scraping")
-	fmt.Println("  -cmd metrics   - Show metrics")
+	fmt.Println("  -cmd export    - Export stored jobs matching -source, -category, -job-type, -since and -limit")
+	fmt.Println("  -cmd metrics   - Show the metrics of the last run")
 	fmt.Println("  -cmd test      - Test job sources")
+	fmt.Println("  -cmd health    - Check that enabled sources are reachable")
 	fmt.Println("  -cmd config    - Show configuration")
 	fmt.Println("  -cmd sources   - List available sources")
+	fmt.Println("  -cmd serve     - Serve stored jobs over a REST API")
+	fmt.Println("  -cmd init      - Write a default configuration file to -config")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  -config string   - Configuration file (default: config.json)")
-	fmt.Println("  -source string   - Specific source to use (remoteok, remotive)")
+	fmt.Println("  -source string   - Specific source to use, or comma-separated sources to scrape (remoteok,remotive)")
 	fmt.Println("  -category string - Filter by category (software-dev, devops, data, etc.)")
-	fmt.Println("  -output string   - Output format: console, json (default: console)")
-	fmt.Println("  -verbose         - Verbose output")
+	fmt.Println("  -output string   - Output format: console, json, csv, jsonl (default: console)")
+	fmt.Println("  -out-file string - Write output to a file instead of stdout")
+	fmt.Println("  -fields string   - Comma-separated job fields for json, csv and jsonl job output (title,company,url, etc.)")
+	fmt.Println("  -job-type string - With -cmd export, only export jobs of this type (full-time, contract, etc.)")
+	fmt.Println("  -limit int       - Maximum jobs to keep per source (default: 0, unlimited)")
+	fmt.Println("  -since string    - Only keep jobs posted within a duration (72h, 7d) or after a date (2006-01-02)")
+	fmt.Println("  -drop-undated    - With -since, also drop jobs without a posted date")
+	fmt.Println("  -dry-run         - With -cmd scrape, fetch and report jobs without saving them")
+	fmt.Println("  -timeout duration - With -cmd scrape, deadline for the whole scrape (default: scraper.scrape_timeout)")
+	fmt.Println("  -similarity float - Near-duplicate similarity threshold, between 0 and 1 (default: scraper.similarity_threshold)")
+	fmt.Println("  -check           - With -cmd sources, health check each source")
+	fmt.Println("  -force           - With -cmd init, overwrite an existing configuration file")
+	fmt.Println("  -verbose         - Verbose output, including debug logs")
+	fmt.Println("  -quiet           - Only print errors and final results")
 	fmt.Println("  -help            - Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  scraper-cli -cmd scrape                              # Scrape all sources")
 	fmt.Println("  scraper-cli -cmd scrape -source remotive             # Scrape only Remotive")
+	fmt.Println("  scraper-cli -cmd scrape -source remoteok,remotive    # Scrape RemoteOK and Remotive")
 	fmt.Println("  scraper-cli -cmd scrape -source remotive -category software-dev  # Scrape software dev jobs from Remotive")
+	fmt.Println("  scraper-cli -cmd scrape -source wework -category programming     # Scrape programming jobs from WeWorkRemotely")
+	fmt.Println("  scraper-cli -cmd scrape -category devops,data                    # Scrape devops and data jobs from every source")
+	fmt.Println("  scraper-cli -cmd scrape -source remotive -dry-run       # Scrape Remotive without saving")
+	fmt.Println("  scraper-cli -cmd scrape -output csv -out-file jobs.csv  # Export scraped jobs to CSV")
+	fmt.Println("  scraper-cli -cmd scrape -output jsonl | jq .title       # Stream scraped jobs as JSON Lines")
+	fmt.Println("  scraper-cli -cmd export -source remoteok -output csv -out-file jobs.csv  # Export stored RemoteOK jobs to CSV")
+	fmt.Println("  scraper-cli -cmd export -output jsonl -fields title,company,url           # Export only some fields")
 	fmt.Println("  scraper-cli -help                                    # Show help")
 }
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/scraper"
+	"job-scraper-go/internal/storage"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "72h", want: now.Add(-72 * time.Hour)},
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: "7d", want: time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)},
+		{value: "0d", want: now},
+		{value: "2024-05-01", want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2024-05-01T08:30:00Z", want: time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "-72h", wantErr: true},
+		{value: "-3d", wantErr: true},
+		{value: "last week", wantErr: true},
+		{value: "2024-13-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSourceNames(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{"", nil},
+		{"RemoteOK", []string{"RemoteOK"}},
+		{" remotive ,", []string{"remotive"}},
+		{"RemoteOK, Remotive", []string{"RemoteOK", "Remotive"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseSourceNames(tt.source); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSourceNames(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestWriteDefaultConfig(t *testing.T) {
+	for _, name := range []string{"config.json", "config.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+
+			if err := writeDefaultConfig(path, false); err != nil {
+				t.Fatalf("writeDefaultConfig: %v", err)
+			}
+
+			cfg, err := config.LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig of the written file: %v", err)
+			}
+			want := config.DefaultConfig()
+			sections := []struct {
+				name      string
+				got, want interface{}
+			}{
+				{"server", cfg.Server, want.Server},
+				{"scraper", cfg.Scraper, want.Scraper},
+				{"remoteok", cfg.Sources.RemoteOK, want.Sources.RemoteOK},
+				{"remotive", cfg.Sources.Remotive, want.Sources.Remotive},
+				{"monitoring", cfg.Monitoring, want.Monitoring},
+				{"notifications", cfg.Notifications, want.Notifications},
+			}
+			for _, section := range sections {
+				if !reflect.DeepEqual(section.got, section.want) {
+					t.Errorf("loaded %s %+v, want the default %+v", section.name, section.got, section.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteDefaultConfigKeepsExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"server":{"port":9090}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeDefaultConfig(path, false); err == nil || !strings.Contains(err.Error(), "-force") {
+		t.Errorf("writeDefaultConfig over an existing file error = %v, want a hint to use -force", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != `{"server":{"port":9090}}` {
+		t.Errorf("existing file changed to %s", data)
+	}
+
+	if err := writeDefaultConfig(path, true); err != nil {
+		t.Fatalf("writeDefaultConfig with force: %v", err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Server.Port != config.DefaultConfig().Server.Port {
+		t.Errorf("port = %d after forced write, want the default %d", cfg.Server.Port, config.DefaultConfig().Server.Port)
+	}
+}
+
+// newExportStore returns a memory store holding jobs of two sources, posted
+// a day apart from May 10th, 2024 backwards
+func newExportStore(t *testing.T) *storage.MemoryStore {
+	t.Helper()
+
+	jobs := []models.Job{
+		{Title: "Go Developer", Company: "Acme", URL: "https://example.com/1", Source: "RemoteOK", JobCategory: "Backend Development"},
+		{Title: "Designer", Company: "Globex", URL: "https://example.com/2", Source: "Remotive", JobCategory: "Design"},
+		{Title: "Rust Developer", Company: "Initech", URL: "https://example.com/3", Source: "RemoteOK", JobCategory: "Backend Development"},
+		{Title: "SRE", Company: "Hooli", URL: "https://example.com/4", Source: "RemoteOK", JobCategory: "DevOps"},
+	}
+	posted := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	for i := range jobs {
+		date := posted.AddDate(0, 0, -i)
+		jobs[i].PostedDate = &date
+	}
+
+	store := storage.NewMemoryStore()
+	if err := store.SaveJobs(context.Background(), jobs); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestExportJobsWritesFilteredSubset(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      storage.JobFilter
+		postedAfter time.Time
+		want        []string
+	}{
+		{"all", storage.JobFilter{}, time.Time{}, []string{"Go Developer", "Designer", "Rust Developer", "SRE"}},
+		// Registry names resolve to the stored display name
+		{"source", storage.JobFilter{Source: "remoteok"}, time.Time{}, []string{"Go Developer", "Rust Developer", "SRE"}},
+		{"source and category", storage.JobFilter{Source: "remoteok", Category: "Backend Development"}, time.Time{}, []string{"Go Developer", "Rust Developer"}},
+		{"limit", storage.JobFilter{Source: "RemoteOK", Limit: 2}, time.Time{}, []string{"Go Developer", "Rust Developer"}},
+		// The limit applies after the posting date filter
+		{"since and limit", storage.JobFilter{Limit: 2}, time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC), []string{"Go Developer", "Designer"}},
+		{"since", storage.JobFilter{Source: "remoteok"}, time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC), []string{"Go Developer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			count, err := exportJobs(context.Background(), newExportStore(t), tt.filter, tt.postedAfter, "json", nil, &out)
+			if err != nil {
+				t.Fatalf("exportJobs: %v", err)
+			}
+
+			var jobs []models.Job
+			if err := json.Unmarshal(out.Bytes(), &jobs); err != nil {
+				t.Fatalf("decoding %s: %v", out.String(), err)
+			}
+			var got []string
+			for _, job := range jobs {
+				got = append(got, job.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) || count != len(tt.want) {
+				t.Errorf("exported %q (count %d), want %q", got, count, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportJobsWithoutMatchesWritesEmptyArray(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := exportJobs(context.Background(), newExportStore(t), storage.JobFilter{Source: "Indeed"}, time.Time{}, "json", nil, &out); err != nil {
+		t.Fatalf("exportJobs: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "[]" {
+		t.Errorf("exported %s, want []", got)
+	}
+}
+
+func TestExportJobsAsCSV(t *testing.T) {
+	var out bytes.Buffer
+	filter := storage.JobFilter{Category: "DevOps"}
+	if _, err := exportJobs(context.Background(), newExportStore(t), filter, time.Time{}, "csv", []string{"title", "company"}, &out); err != nil {
+		t.Fatalf("exportJobs: %v", err)
+	}
+
+	header, rows := readCSV(t, out.Bytes())
+	if want := []string{"title", "company"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("header = %q, want %q", header, want)
+	}
+	if want := [][]string{{"SRE", "Hooli"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestNewLoggerLevels(t *testing.T) {
+	tests := []struct {
+		name           string
+		verbose, quiet bool
+		want           []string
+	}{
+		{name: "default", want: []string{"info message", "error message"}},
+		{name: "verbose", verbose: true, want: []string{"debug message", "info message", "error message"}},
+		{name: "quiet", quiet: true, want: []string{"error message"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := newLogger(&buf, config.DefaultConfig(), tt.verbose, tt.quiet)
+			logger.Debugf("debug message")
+			logger.Infof("info message")
+			logger.Errorf("error message")
+
+			var got []string
+			for _, msg := range []string{"debug message", "info message", "error message"} {
+				if strings.Contains(buf.String(), msg) {
+					got = append(got, msg)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("logged %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHidesInfoLogsButKeepsResults(t *testing.T) {
+	var logs bytes.Buffer
+	logger := newLogger(&logs, config.DefaultConfig(), false, true)
+	logger.Infof("Scraping 3 sources")
+
+	var results bytes.Buffer
+	if _, err := exportJobs(context.Background(), newExportStore(t), storage.JobFilter{}, time.Time{}, "json", nil, &results); err != nil {
+		t.Fatalf("exportJobs: %v", err)
+	}
+
+	if logs.Len() != 0 {
+		t.Errorf("quiet mode logged %q, want nothing", logs.String())
+	}
+	if results.Len() == 0 {
+		t.Error("quiet mode wrote no results")
+	}
+}
+
+func TestOutputSourcesConsole(t *testing.T) {
+	statuses := []scraper.SourceStatus{
+		{Name: "Indeed", RateLimit: 10, RateWindow: time.Minute, BaseURL: "https://indeed.com"},
+		{Name: "RemoteOK", Enabled: true, RateLimit: 30, RateWindow: time.Hour, BaseURL: "https://remoteok.com", Checked: true, Error: "connection refused"},
+		{Name: "Remotive", Enabled: true, RateLimit: 60, RateWindow: time.Minute, BaseURL: "https://remotive.com", Checked: true, Healthy: true, ResponseTime: 120 * time.Millisecond},
+		{Name: "WeWorkRemotely", Enabled: true, RateLimit: 20, RateWindow: time.Minute, BaseURL: "https://weworkremotely.com", ResponseTime: 2 * time.Second},
+	}
+
+	var out bytes.Buffer
+	outputSourcesConsole(&out, statuses)
+
+	want := `Available Job Sources:
+- Indeed: disabled (rate limit: 10/min, https://indeed.com)
+- RemoteOK: enabled (rate limit: 30/1h0m0s, https://remoteok.com), unreachable: connection refused
+- Remotive: enabled (rate limit: 60/min, https://remotive.com), healthy (120ms)
+- WeWorkRemotely: enabled (rate limit: 20/min, https://weworkremotely.com), last response time: 2s
+`
+	if got := out.String(); got != want {
+		t.Errorf("output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMetricsCommandReadsLatestSnapshot(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Monitoring.MetricsFile = filepath.Join(t.TempDir(), "metrics.json")
+
+	if snapshot, err := latestMetricsSnapshot(cfg); err != nil || snapshot != nil {
+		t.Fatalf("latestMetricsSnapshot before any run = %v, %v, want none", snapshot, err)
+	}
+
+	metrics := scraper.ScraperMetrics{
+		TotalJobsScraped: 42,
+		TotalJobsSaved:   40,
+		TotalErrors:      1,
+		SourcePerformance: map[string]scraper.SourceMetrics{
+			"Remotive": {JobsScraped: 42, Errors: 1, LastError: "service unavailable"},
+		},
+	}
+	if err := scraper.NewMetricsStore(cfg.Monitoring.MetricsFile).Save(time.Now(), &metrics); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	snapshot, err := latestMetricsSnapshot(cfg)
+	if err != nil || snapshot == nil {
+		t.Fatalf("latestMetricsSnapshot = %v, %v, want the saved snapshot", snapshot, err)
+	}
+
+	var out bytes.Buffer
+	outputConsole(&out, &snapshot.Metrics)
+	for _, want := range []string{"Total Jobs Scraped: 42", "Total Jobs Saved: 40", "Total Errors: 1", "Last Error: service unavailable"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestMetricsCommandWithoutMetricsFile(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Monitoring.MetricsFile = ""
+
+	if _, err := latestMetricsSnapshot(cfg); err == nil {
+		t.Error("latestMetricsSnapshot without a metrics file succeeded, want an error")
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"job-scraper-go/internal/models"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statusOut receives progress messages; it is switched to stderr when job data
+// is written to stdout so the data stays machine-readable
+var statusOut io.Writer = os.Stdout
+
+// statusf prints a progress message
+func statusf(format string, args ...interface{}) {
+	fmt.Fprintf(statusOut, format, args...)
+}
+
+// isJobOutput reports whether an output format writes jobs rather than metrics
+func isJobOutput(output string) bool {
+	return output == "csv" || output == "jsonl"
+}
+
+// openOutput opens the output destination, using stdout when path is empty
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return file, nil
+}
+
+// nopCloser wraps a writer that must not be closed, such as stdout
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// csvHeader lists the columns written by writeJobsCSV when no fields are selected
+var csvHeader = []string{"title", "company", "location", "url", "salary", "posted_date", "source", "job_category", "job_type", "work_mode", "experience_level"}
+
+// jobFieldNames returns the JSON names of the models.Job fields in declaration order
+func jobFieldNames() []string {
+	jobType := reflect.TypeOf(models.Job{})
+	names := make([]string, 0, jobType.NumField())
+	for i := 0; i < jobType.NumField(); i++ {
+		names = append(names, jsonFieldName(jobType.Field(i)))
+	}
+	return names
+}
+
+// jsonFieldName returns the name of a struct field in JSON
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+// parseFields parses the comma-separated job field names of -fields, such as
+// "title,company,url". An empty list selects every field.
+func parseFields(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	for _, name := range jobFieldNames() {
+		known[name] = true
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("unknown field %q (available fields: %s)", name, strings.Join(jobFieldNames(), ", "))
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// jobFieldValues returns the field values of a job keyed by their JSON names
+func jobFieldValues(job models.Job) map[string]interface{} {
+	value := reflect.ValueOf(job)
+	values := make(map[string]interface{}, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		values[jsonFieldName(value.Type().Field(i))] = value.Field(i).Interface()
+	}
+	return values
+}
+
+// fieldObject is a job restricted to some of its fields. It encodes as a JSON
+// object with the fields in the order they were selected.
+type fieldObject struct {
+	fields []string
+	values map[string]interface{}
+}
+
+func (o fieldObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		value, err := json.Marshal(o.values[field])
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(strconv.Quote(field))
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// selectFields restricts jobs to the given fields for JSON output
+func selectFields(jobs []models.Job, fields []string) []fieldObject {
+	objects := make([]fieldObject, 0, len(jobs))
+	for _, job := range jobs {
+		objects = append(objects, fieldObject{fields: fields, values: jobFieldValues(job)})
+	}
+	return objects
+}
+
+// csvValue formats a job field value for a CSV cell
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// writeJobsCSV writes jobs as CSV rows with a header line, using the given
+// fields as columns or csvHeader when none are selected
+func writeJobsCSV(w io.Writer, jobs []models.Job, fields []string) error {
+	if len(fields) == 0 {
+		fields = csvHeader
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(fields); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, job := range jobs {
+		values := jobFieldValues(job)
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = csvValue(values[field])
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJobsJSONL writes each job as a compact JSON object on its own line,
+// restricted to the given fields if any are selected. Jobs are written one at
+// a time so output streams as it is produced.
+func writeJobsJSONL(w io.Writer, jobs []models.Job, fields []string) error {
+	encoder := json.NewEncoder(w)
+	for _, job := range jobs {
+		var value interface{} = job
+		if len(fields) > 0 {
+			value = fieldObject{fields: fields, values: jobFieldValues(job)}
+		}
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Errorf("failed to write JSON line: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/scraper"
+	"job-scraper-go/internal/storage"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// fixtureFeed is an RSS feed whose descriptions contain commas, quotes and newlines
+const fixtureFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<item>
+  <title>Go Developer, Backend</title>
+  <link>https://example.com/jobs/1</link>
+  <company>Acme, Inc.</company>
+  <location>Remote</location>
+  <salary>$100,000 - $120,000</salary>
+  <pubDate>Mon, 06 May 2024 10:00:00 +0000</pubDate>
+  <description><![CDATA[<p>Build APIs, services and tools.</p>
+<p>Say "hello" in Go.</p>]]></description>
+</item>
+<item>
+  <title>Designer</title>
+  <link>https://example.com/jobs/2</link>
+  <company>Globex</company>
+  <location>Berlin</location>
+  <description>Figma</description>
+</item>
+</channel></rss>`
+
+// scrapeFixtureFeed scrapes fixtureFeed into an in-memory store and returns
+// the jobs of the run
+func scrapeFixtureFeed(t *testing.T) []models.Job {
+	t.Helper()
+
+	feed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		io.WriteString(w, fixtureFeed)
+	}))
+	defer feed.Close()
+
+	ps := scraper.NewPowerScraper(storage.NewMemoryStore(), httpclient.NewHttpClient(5*time.Second), logging.New(io.Discard, "", 0, logging.LevelError))
+	ps.InitializeSources(config.SourcesConfig{Feeds: []config.FeedConfig{{
+		Name:    "Fixture",
+		URL:     feed.URL,
+		Enabled: true,
+		Fields:  config.FeedFieldMapping{Company: "company", Location: "location", Salary: "salary"},
+	}}})
+
+	report, err := ps.ScrapeAllSources(context.Background())
+	if err != nil {
+		t.Fatalf("ScrapeAllSources: %v (%s)", err, report.Summary())
+	}
+	if len(report.Jobs) != 2 {
+		t.Fatalf("scraped %d jobs, want 2 (%s)", len(report.Jobs), report.Summary())
+	}
+	return report.Jobs
+}
+
+// readCSV parses CSV output into its header and rows
+func readCSV(t *testing.T, data []byte) ([]string, [][]string) {
+	t.Helper()
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV %q: %v", data, err)
+	}
+	if len(records) == 0 {
+		t.Fatal("CSV output is empty, want a header line")
+	}
+	return records[0], records[1:]
+}
+
+func TestWriteJobsCSVRoundTripsScrapedJobs(t *testing.T) {
+	jobs := scrapeFixtureFeed(t)
+
+	var buf bytes.Buffer
+	if err := writeJobsCSV(&buf, jobs, nil); err != nil {
+		t.Fatalf("writeJobsCSV: %v", err)
+	}
+
+	header, rows := readCSV(t, buf.Bytes())
+	if strings.Join(header, ",") != strings.Join(csvHeader, ",") {
+		t.Errorf("header = %q, want %q", header, csvHeader)
+	}
+	if len(rows) != len(jobs) {
+		t.Fatalf("parsed %d rows, want %d", len(rows), len(jobs))
+	}
+
+	for i, row := range rows {
+		job := jobs[i]
+		want := map[string]string{
+			"title":       job.Title,
+			"company":     job.Company,
+			"location":    job.Location,
+			"url":         job.URL,
+			"salary":      job.Salary,
+			"posted_date": csvValue(job.PostedDate),
+			"source":      job.Source,
+			"job_type":    job.JobType,
+		}
+		for column, name := range header {
+			if wantValue, ok := want[name]; ok && row[column] != wantValue {
+				t.Errorf("row %d %s = %q, want %q", i, name, row[column], wantValue)
+			}
+		}
+	}
+
+	first := rows[0]
+	if first[0] != "Go Developer, Backend" || first[1] != "Acme, Inc." || first[4] != "$100,000 - $120,000" {
+		t.Errorf("first row = %q, want the fields containing commas intact", first)
+	}
+	if first[5] != "2024-05-06T10:00:00Z" {
+		t.Errorf("posted_date = %q, want RFC 3339", first[5])
+	}
+}
+
+func TestWriteJobsCSVQuotesDescriptions(t *testing.T) {
+	jobs := scrapeFixtureFeed(t)
+	if !strings.ContainsAny(jobs[0].Description, ",\n\"") {
+		t.Fatalf("fixture description %q has nothing to quote", jobs[0].Description)
+	}
+
+	var buf bytes.Buffer
+	if err := writeJobsCSV(&buf, jobs, []string{"title", "description"}); err != nil {
+		t.Fatalf("writeJobsCSV: %v", err)
+	}
+
+	header, rows := readCSV(t, buf.Bytes())
+	if len(header) != 2 || header[0] != "title" || header[1] != "description" {
+		t.Errorf("header = %q, want the selected fields", header)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("parsed %d rows, want 2", len(rows))
+	}
+	for i, row := range rows {
+		if row[0] != jobs[i].Title || row[1] != jobs[i].Description {
+			t.Errorf("row %d = %q, want %q and %q", i, row, jobs[i].Title, jobs[i].Description)
+		}
+	}
+}
+
+func TestWriteJobsCSVWithoutJobsWritesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJobsCSV(&buf, nil, nil); err != nil {
+		t.Fatalf("writeJobsCSV: %v", err)
+	}
+
+	header, rows := readCSV(t, buf.Bytes())
+	if len(header) != len(csvHeader) || len(rows) != 0 {
+		t.Errorf("got header %q and %d rows, want only the header", header, len(rows))
+	}
+}
+
+func TestWriteJobsJSONLWritesOneJobPerLine(t *testing.T) {
+	jobs := scrapeFixtureFeed(t)
+
+	path := filepath.Join(t.TempDir(), "jobs.jsonl")
+	out, err := openOutput(path)
+	if err != nil {
+		t.Fatalf("openOutput: %v", err)
+	}
+	if err := writeJobsJSONL(out, jobs, nil); err != nil {
+		t.Fatalf("writeJobsJSONL: %v", err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != len(jobs) {
+		t.Fatalf("wrote %d lines, want one per job (%d)", len(lines), len(jobs))
+	}
+	for i, line := range lines {
+		var job models.Job
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			t.Fatalf("line %d %q is not valid JSON: %v", i, line, err)
+		}
+		if job.URL != jobs[i].URL || job.Description != jobs[i].Description {
+			t.Errorf("line %d decoded to %q (%s), want %q", i, job.Title, job.URL, jobs[i].Title)
+		}
+	}
+}
+
+func TestWriteJobsJSONLSelectsFields(t *testing.T) {
+	jobs := []models.Job{{Title: "Go Developer", Company: "Acme", URL: "https://example.com/1"}}
+
+	var buf bytes.Buffer
+	if err := writeJobsJSONL(&buf, jobs, []string{"url", "title"}); err != nil {
+		t.Fatalf("writeJobsJSONL: %v", err)
+	}
+
+	want := `{"url":"https://example.com/1","title":"Go Developer"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{list: "", want: nil},
+		{list: "title,company,url", want: []string{"title", "company", "url"}},
+		{list: " Title , URL,title,", want: []string{"title", "url"}},
+		{list: "salary_min,posted_date", want: []string{"salary_min", "posted_date"}},
+		{list: "title,employer", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			got, err := parseFields(tt.list)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "employer") {
+					t.Errorf("parseFields(%q) error = %v, want one naming the unknown field", tt.list, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFields(%q): %v", tt.list, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFields(%q) = %q, want %q", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectFieldsJSON(t *testing.T) {
+	jobs := []models.Job{{Title: "Go Developer", Company: "Acme", URL: "https://example.com/1", Salary: "$100k"}}
+
+	data, err := json.Marshal(selectFields(jobs, []string{"company", "title"}))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := string(data), `[{"company":"Acme","title":"Go Developer"}]`; got != want {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+}
@@ -2,15 +2,19 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
+	"io"
 	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
+	"job-scraper-go/internal/monitoring"
+	"job-scraper-go/internal/notify"
 	"job-scraper-go/internal/scraper"
 	"job-scraper-go/internal/storage"
-	"job-scraper-go/pkg/httpclient"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,13 +22,16 @@ import (
 )
 
 func main() {
+	configFile := flag.String("config", "config.json", "Configuration file path (.json, .yaml or .yml)")
+	flag.Parse()
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Printf("Warning: Could not load .env file: %v", err)
 	}
 
 	// Load configuration
-	cfg, err := config.LoadConfig("config.json")
+	cfg, err := config.LoadConfig(*configFile)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
@@ -35,7 +42,7 @@ func main() {
 	}
 
 	// Setup logging
-	logger, logFile, err := setupLogging(cfg.Monitoring.LogFile, cfg.Monitoring.LogLevel)
+	logger, logFile, err := setupLogging(cfg.Monitoring)
 	if err != nil {
 		log.Fatalf("Failed to setup logging: %v", err)
 	}
@@ -46,17 +53,58 @@ func main() {
 	logger.Printf("Starting Job Scraper with %d concurrent sources", cfg.Scraper.ConcurrentSources)
 
 	// Initialize HTTP client
-	httpClient := httpclient.NewHttpClient(cfg.Scraper.RequestTimeout)
+	httpClient, err := scraper.NewHTTPClient(cfg.Scraper)
+	if err != nil {
+		logger.Fatalf("Failed to create HTTP client: %v", err)
+	}
 
 	// Initialize storage
-	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey)
+	store, err := storage.NewSupabaseStore(cfg.Database.SupabaseURL, cfg.Database.SupabaseKey, cfg.Database.Table)
 	if err != nil {
 		logger.Fatalf("Failed to initialize storage: %v", err)
 	}
 
 	// Initialize power scraper
 	powerScraper := scraper.NewPowerScraper(store, httpClient, logger)
-	powerScraper.InitializeSources()
+	powerScraper.SetRetryConfig(scraper.NewRetryConfig(cfg.Scraper))
+	powerScraper.SetSaveRetryConfig(scraper.NewSaveRetryConfig(cfg.Scraper))
+	powerScraper.SetSaveConcurrency(cfg.Scraper.ConcurrentSaves)
+	powerScraper.SetMergeDuplicates(cfg.Scraper.MergeDuplicates)
+	powerScraper.SetRefetchMalformedJSON(cfg.Scraper.RefetchMalformedJSON)
+	powerScraper.SetSourcePriority(cfg.Scraper.SourcePriority)
+	powerScraper.SetValidationLevel(cfg.Scraper.Validation)
+	powerScraper.SetOptions(scraper.Options{SimilarityThreshold: cfg.Scraper.SimilarityThreshold})
+	powerScraper.InitializeSources(cfg.Sources)
+
+	// Only process jobs posted since each source's last scrape
+	if cfg.Scraper.StateFile != "" {
+		state, err := scraper.LoadScrapeState(cfg.Scraper.StateFile)
+		if err != nil {
+			logger.Fatalf("Failed to load scrape state: %v", err)
+		}
+		powerScraper.SetScrapeState(state)
+	}
+
+	// Keep the metrics of each run across restarts
+	if cfg.Monitoring.MetricsFile != "" {
+		powerScraper.SetMetricsStore(scraper.NewMetricsStore(cfg.Monitoring.MetricsFile))
+	}
+
+	// Treat jobs saved by earlier runs as duplicates
+	if cfg.Scraper.SeedDedup {
+		if err := powerScraper.SeedDeduplicator(context.Background()); err != nil {
+			logger.Fatalf("Failed to seed deduplicator: %v", err)
+		}
+	}
+
+	// Initialize new-job notifications
+	notifier, err := notify.NewFromConfig(cfg.Notifications, httpClient)
+	if err != nil {
+		logger.Fatalf("Failed to initialize notifications: %v", err)
+	}
+	if notifier != nil {
+		powerScraper.SetNotifier(notifier)
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -66,28 +114,36 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start background scraping if interval is configured
-	var scraperDone chan struct{}
-	if cfg.Scraper.ScrapingInterval > 0 {
-		scraperDone = make(chan struct{})
-		go runPeriodicScraping(ctx, powerScraper, cfg.Scraper.ScrapingInterval, logger, scraperDone)
-	}
+	// Background goroutines, waited for on shutdown
+	var tasks []backgroundTask
 
-	// Start metrics reporting if monitoring is enabled
-	var metricsDone chan struct{}
-	if cfg.Monitoring.Enabled {
-		metricsDone = make(chan struct{})
-		go runMetricsReporting(ctx, powerScraper, cfg.Monitoring.MetricsInterval, logger, metricsDone)
-	}
+	// Start scraping, once now and then every interval if one is configured
+	scraperDone := make(chan struct{})
+	tasks = append(tasks, backgroundTask{"Periodic scraping", scraperDone})
+	go runPeriodicScraping(ctx, powerScraper, cfg.Scraper.ScrapingInterval.Duration, cfg.Scraper.ScrapeTimeout.Duration, logger, scraperDone)
 
-	// Run initial scraping
-	logger.Println("Running initial scraping...")
-	if err := powerScraper.ScrapeAllSources(ctx); err != nil {
-		logger.Printf("Initial scraping failed: %v", err)
+	// Start pruning stale jobs if a retention period is configured
+	if cfg.Database.RetentionPeriod.Duration > 0 {
+		pruneDone := make(chan struct{})
+		tasks = append(tasks, backgroundTask{"Job pruning", pruneDone})
+		go runPeriodicPruning(ctx, store, cfg.Database.RetentionPeriod.Duration, logger, pruneDone)
 	}
 
-	// Print initial metrics
-	printMetrics(powerScraper, logger)
+	// Start metrics reporting and the metrics endpoint if monitoring is enabled
+	var metricsServer *http.Server
+	if cfg.Monitoring.Enabled {
+		metricsDone := make(chan struct{})
+		tasks = append(tasks, backgroundTask{"Metrics reporting", metricsDone})
+		go runMetricsReporting(ctx, powerScraper, cfg.Monitoring.MetricsInterval.Duration, cfg.Monitoring.ResetMetrics, logger, metricsDone)
+
+		metricsServer = monitoring.NewServer(cfg.Server, powerScraper, powerScraper)
+		go func() {
+			logger.Printf("Serving Prometheus metrics on %s/metrics and source controls on %s/sources", metricsServer.Addr, metricsServer.Addr)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Errorf("Metrics server failed: %v", err)
+			}
+		}()
+	}
 
 	// Wait for shutdown signal
 	select {
@@ -100,74 +156,190 @@ func main() {
 	// Cancel context to stop all background operations
 	cancel()
 
-	// Wait for background operations to complete
-	if scraperDone != nil {
-		<-scraperDone
-		logger.Println("Periodic scraping stopped")
+	// Give the metrics endpoint and background operations until the shutdown
+	// timeout to stop, so that a hung request cannot block the exit
+	shutdownTimeout := cfg.Server.ShutdownTimeout.Duration
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("Metrics server shutdown failed: %v", err)
+		}
 	}
-	if metricsDone != nil {
-		<-metricsDone
-		logger.Println("Metrics reporting stopped")
+
+	if pending := waitForTasks(shutdownCtx, tasks, logger); len(pending) > 0 {
+		logger.Errorf("Shutdown timed out after %v, still running: %s", shutdownTimeout, strings.Join(pending, ", "))
+		os.Exit(1)
 	}
 
 	logger.Println("Job Scraper shutdown complete")
 }
 
-// setupLogging configures logging based on the configuration
-func setupLogging(logFile, logLevel string) (*log.Logger, *os.File, error) {
-	var logOutput *os.File
-	var err error
+// backgroundTask is a goroutine of the daemon that closes done when it returns
+type backgroundTask struct {
+	name string
+	done chan struct{}
+}
 
-	if logFile != "" {
-		// Ensure log directory exists
-		if err := os.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
-			return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+// waitForTasks waits for the tasks to stop until ctx is done and returns the
+// names of the tasks still running
+func waitForTasks(ctx context.Context, tasks []backgroundTask, logger *logging.Logger) []string {
+	var pending []string
+	for _, task := range tasks {
+		select {
+		case <-task.done:
+		case <-ctx.Done():
+			// A task may have stopped just as the deadline passed
+			select {
+			case <-task.done:
+			default:
+				pending = append(pending, task.name)
+				continue
+			}
 		}
+		logger.Printf("%s stopped", task.name)
+	}
+	return pending
+}
 
-		logOutput, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
-		}
-	} else {
-		logOutput = os.Stdout
+// pruneInterval is how often stale jobs are deleted when a retention period is set
+const pruneInterval = time.Hour
+
+// setupLogging configures logging based on the configuration. The log file,
+// if any, is rotated by size and must be closed by the caller.
+func setupLogging(cfg config.MonitoringConfig) (*logging.Logger, io.Closer, error) {
+	level, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	format, err := logging.ParseFormat(cfg.LogFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.LogFile == "" {
+		return logging.NewWithFormat(os.Stdout, "[SCRAPER] ", log.LstdFlags|log.Lshortfile, level, format), nil, nil
+	}
+
+	logFile, err := logging.NewRotatingFile(cfg.LogFile, cfg.MaxLogSizeMB, cfg.MaxLogBackups, cfg.MaxLogAgeDays)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	logger := log.New(logOutput, "[SCRAPER] ", log.LstdFlags|log.Lshortfile)
-	return logger, logOutput, nil
+	logger := logging.NewWithFormat(logFile, "[SCRAPER] ", log.LstdFlags|log.Lshortfile, level, format)
+	return logger, logFile, nil
 }
 
-// runPeriodicScraping runs the scraper at regular intervals
-func runPeriodicScraping(ctx context.Context, powerScraper *scraper.PowerScraper, interval time.Duration, logger *log.Logger, done chan struct{}) {
+// runPeriodicScraping runs the scraper once and then at regular intervals,
+// or only once if interval is zero. Each run is given up to timeout, if set.
+func runPeriodicScraping(ctx context.Context, powerScraper *scraper.PowerScraper, interval, timeout time.Duration, logger *logging.Logger, done chan struct{}) {
 	defer close(done)
 
+	// Run initial scraping
+	logger.Println("Running initial scraping...")
+	runCtx, cancel := scraper.WithScrapeTimeout(ctx, timeout)
+	report, err := powerScraper.ScrapeAllSources(runCtx)
+	cancel()
+	if err != nil {
+		logger.Printf("Initial scraping failed: %v", err)
+	}
+	logger.Printf("Initial scraping report: %s", report.Summary())
+
+	// Print initial metrics
+	printMetrics(powerScraper, logger)
+
+	if interval <= 0 {
+		return
+	}
+
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	logger.Printf("Starting periodic scraping every %v", interval)
 
+	// scraping is closed when the scheduled scrape in progress finishes and is
+	// nil while none runs, so that a scrape slower than the interval is never
+	// overlapped by the next one
+	var scraping chan struct{}
+	var scrapeStart time.Time
+
 	for {
 		select {
 		case <-ctx.Done():
+			if scraping != nil {
+				<-scraping
+			}
 			logger.Println("Periodic scraping cancelled")
 			return
+		case <-scraping:
+			scraping = nil
 		case <-ticker.C:
-			logger.Println("Starting scheduled scraping...")
-			start := time.Now()
-
-			if err := powerScraper.ScrapeAllSources(ctx); err != nil {
-				logger.Printf("Scheduled scraping failed: %v", err)
-			} else {
-				logger.Printf("Scheduled scraping completed in %v", time.Since(start))
+			if scraping != nil {
+				logger.Warnf("Skipping scheduled scraping: the previous run is still in progress after %v", time.Since(scrapeStart).Round(time.Second))
+				continue
 			}
 
-			// Print metrics after each scraping
-			printMetrics(powerScraper, logger)
+			scraping = make(chan struct{})
+			scrapeStart = time.Now()
+			go runScheduledScraping(ctx, powerScraper, timeout, logger, scraping)
 		}
 	}
 }
 
-// runMetricsReporting periodically reports scraper metrics
-func runMetricsReporting(ctx context.Context, powerScraper *scraper.PowerScraper, interval time.Duration, logger *log.Logger, done chan struct{}) {
+// runScheduledScraping runs a scheduled scrape, giving up after timeout if
+// set, and closes done when it finishes
+func runScheduledScraping(ctx context.Context, powerScraper *scraper.PowerScraper, timeout time.Duration, logger *logging.Logger, done chan struct{}) {
+	defer close(done)
+
+	logger.Println("Starting scheduled scraping...")
+	start := time.Now()
+
+	ctx, cancel := scraper.WithScrapeTimeout(ctx, timeout)
+	defer cancel()
+	report, err := powerScraper.ScrapeAllSources(ctx)
+	if err != nil {
+		logger.Printf("Scheduled scraping failed: %v", err)
+	} else {
+		logger.Printf("Scheduled scraping completed in %v", time.Since(start))
+	}
+	logger.Printf("Scheduled scraping report: %s", report.Summary())
+
+	// Print metrics after each scraping
+	printMetrics(powerScraper, logger)
+}
+
+// runPeriodicPruning deletes jobs older than the retention period, once at
+// startup and then every pruneInterval
+func runPeriodicPruning(ctx context.Context, store storage.Store, retention time.Duration, logger *logging.Logger, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	logger.Printf("Pruning jobs older than %v every %v", retention, pruneInterval)
+
+	for {
+		deleted, err := store.DeleteJobsOlderThan(ctx, time.Now().Add(-retention))
+		if err != nil {
+			logger.Errorf("Failed to prune stale jobs: %v", err)
+		} else if deleted > 0 {
+			logger.Printf("Pruned %d jobs older than %v", deleted, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Println("Job pruning cancelled")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runMetricsReporting periodically reports scraper metrics, resetting them
+// after each report when reset is set
+func runMetricsReporting(ctx context.Context, powerScraper *scraper.PowerScraper, interval time.Duration, reset bool, logger *logging.Logger, done chan struct{}) {
 	defer close(done)
 
 	ticker := time.NewTicker(interval)
@@ -182,27 +354,36 @@ func runMetricsReporting(ctx context.Context, powerScraper *scraper.PowerScraper
 			return
 		case <-ticker.C:
 			printMetrics(powerScraper, logger)
+			if reset {
+				powerScraper.ResetMetrics()
+			}
 		}
 	}
 }
 
 // printMetrics prints current scraper metrics
-func printMetrics(powerScraper *scraper.PowerScraper, logger *log.Logger) {
+func printMetrics(powerScraper *scraper.PowerScraper, logger *logging.Logger) {
 	metrics := powerScraper.GetMetrics()
 
 	logger.Printf("=== Scraper Metrics ===")
 	logger.Printf("Total Jobs Scraped: %d", metrics.TotalJobsScraped)
 	logger.Printf("Total Jobs Saved: %d", metrics.TotalJobsSaved)
-	logger.Printf("Total Duplicates: %d", metrics.TotalDuplicates)
+	logger.Printf("Total Duplicates: %d (%d within sources, %d across sources)",
+		metrics.TotalDuplicates, metrics.WithinSourceDuplicates, metrics.CrossSourceDuplicates)
 	logger.Printf("Total Errors: %d", metrics.TotalErrors)
+	logger.Printf("Suspicious Runs: %d", metrics.SuspiciousRuns)
 	logger.Printf("Last Scraping Duration: %v", metrics.ScrapingDuration)
 
 	if len(metrics.SourcePerformance) > 0 {
 		logger.Printf("=== Source Performance ===")
 		for source, perf := range metrics.SourcePerformance {
-			logger.Printf("%s: scraped=%d, saved=%d, duplicates=%d, errors=%d, response_time=%v, last_scraped=%v",
-				source, perf.JobsScraped, perf.JobsSaved, perf.Duplicates, perf.Errors,
-				perf.ResponseTime, perf.LastScraped.Format("2006-01-02 15:04:05"))
+			logger.Printf("%s: scraped=%d, saved=%d, duplicates=%d, cross_source_duplicates=%d, errors=%d, suspicious_runs=%d, response_time=%v, avg_response_time=%v, success_rate=%.2f, last_scraped=%v",
+				source, perf.JobsScraped, perf.JobsSaved, perf.Duplicates, perf.CrossSourceDuplicates, perf.Errors, perf.SuspiciousRuns,
+				perf.ResponseTime, perf.AvgResponseTime().Round(time.Millisecond), perf.SuccessRate(),
+				perf.LastScraped.Format("2006-01-02 15:04:05"))
+			if perf.LastError != "" {
+				logger.Printf("%s: last_error=%q at %v", source, perf.LastError, perf.LastErrorTime.Format("2006-01-02 15:04:05"))
+			}
 		}
 	}
 
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/scraper"
+	"job-scraper-go/internal/scraper/sources"
+	"job-scraper-go/internal/storage"
+	"job-scraper-go/pkg/httpclient"
+)
+
+func TestRunPeriodicPruningPrunesAtStartup(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	store := storage.NewMemoryStore()
+	jobs := []models.Job{
+		{Title: "Stale", PostedDate: &old},
+		{Title: "Fresh", PostedDate: &now},
+	}
+	if err := store.SaveJobs(context.Background(), jobs); err != nil {
+		t.Fatal(err)
+	}
+
+	// A cancelled context stops the loop after the startup prune
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan struct{})
+	runPeriodicPruning(ctx, store, 24*time.Hour, logging.New(io.Discard, "", 0, logging.LevelError), done)
+
+	select {
+	case <-done:
+	default:
+		t.Error("done was not closed")
+	}
+	remaining, err := store.GetJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].Title != "Fresh" {
+		t.Errorf("kept %+v, want only the Fresh job", remaining)
+	}
+}
+
+func TestWaitForTasksReturnsAtDeadline(t *testing.T) {
+	stopped := make(chan struct{})
+	close(stopped)
+	stalled := make(chan struct{})
+	defer close(stalled)
+	tasks := []backgroundTask{
+		{name: "Job pruning", done: stopped},
+		{name: "Periodic scraping", done: stalled},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	pending := waitForTasks(ctx, tasks, logging.New(io.Discard, "", 0, logging.LevelError))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitForTasks returned after %v, want about 50ms", elapsed)
+	}
+	if want := []string{"Periodic scraping"}; !reflect.DeepEqual(pending, want) {
+		t.Errorf("pending = %q, want %q", pending, want)
+	}
+}
+
+func TestWaitForTasksAllStopped(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pending := waitForTasks(ctx, []backgroundTask{{name: "Metrics reporting", done: done}}, logging.New(io.Discard, "", 0, logging.LevelError))
+	if len(pending) != 0 {
+		t.Errorf("pending = %q, want none", pending)
+	}
+}
+
+// slowBoard is the source built by the slow_board factory
+var slowBoard *slowSource
+
+// slowSource is a JobSource whose fetches take delay, recording how many run
+// at once
+type slowSource struct {
+	delay time.Duration
+
+	mu         sync.Mutex
+	running    int
+	maxRunning int
+	fetches    int
+}
+
+func (s *slowSource) GetName() string                       { return "SlowBoard" }
+func (s *slowSource) GetRateLimit() int                     { return 0 }
+func (s *slowSource) SupportsSearch() bool                  { return false }
+func (s *slowSource) GetBaseURL() string                    { return "https://example.com" }
+func (s *slowSource) HealthCheck(ctx context.Context) error { return nil }
+
+func (s *slowSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	s.mu.Lock()
+	s.running++
+	s.fetches++
+	if s.running > s.maxRunning {
+		s.maxRunning = s.running
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.running--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(s.delay):
+		return nil, nil
+	}
+}
+
+var registerSlowBoard sync.Once
+
+func TestRunPeriodicScrapingSkipsOverlappingTicks(t *testing.T) {
+	slow := &slowSource{delay: 100 * time.Millisecond}
+	slowBoard = slow
+	registerSlowBoard.Do(func() {
+		sources.RegisterFactory("slow_board", func(client *httpclient.HttpClient) sources.JobSource {
+			return slowBoard
+		})
+	})
+
+	logger := logging.New(io.Discard, "", 0, logging.LevelError)
+	powerScraper := scraper.NewPowerScraper(storage.NewMemoryStore(), httpclient.NewHttpClient(time.Second), logger)
+	powerScraper.InitializeSources(config.SourcesConfig{})
+	if err := powerScraper.SetSourceEnabled("slow_board", true); err != nil {
+		t.Fatalf("SetSourceEnabled: %v", err)
+	}
+
+	// Ticks every 10ms while each scrape takes 100ms
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	runPeriodicScraping(ctx, powerScraper, 10*time.Millisecond, 0, logger, done)
+
+	slow.mu.Lock()
+	defer slow.mu.Unlock()
+	if slow.maxRunning != 1 {
+		t.Errorf("%d scrapes ran at once, want 1", slow.maxRunning)
+	}
+	if slow.fetches < 2 || slow.fetches > 5 {
+		t.Errorf("fetched %d times, want the initial scrape and a few scheduled ones rather than one per tick", slow.fetches)
+	}
+}
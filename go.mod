@@ -7,6 +7,7 @@ require golang.org/x/net v0.0.0-20190724013045-ca1201d0de80
 require (
 	github.com/joho/godotenv v1.5.1
 	github.com/nedpals/supabase-go v0.5.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
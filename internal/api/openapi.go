@@ -0,0 +1,222 @@
+package api
+
+import (
+	"job-scraper-go/internal/models"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// openAPIVersion is the version of the API described by the OpenAPI document
+const openAPIVersion = "1.0.0"
+
+// handleOpenAPI serves the OpenAPI 3 document describing the API
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, openAPISpec())
+}
+
+// openAPISpec builds the OpenAPI 3 document for the API routes. The Job
+// schema is generated from models.Job, so it follows the stored fields.
+func openAPISpec() map[string]interface{} {
+	jsonResponse := func(description string, schema map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schema},
+			},
+		}
+	}
+	errorResponse := func(description string) map[string]interface{} {
+		return jsonResponse(description, schemaRef("Error"))
+	}
+	queryParam := func(name, description string, schema map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        name,
+			"in":          "query",
+			"description": description,
+			"required":    false,
+			"schema":      schema,
+		}
+	}
+
+	jobSchema := objectSchema(reflect.TypeOf(models.Job{}))
+	properties := jobSchema["properties"].(map[string]interface{})
+	properties["job_type"].(map[string]interface{})["enum"] = []string{
+		"", models.JobTypeFullTime, models.JobTypePartTime, models.JobTypeContract, models.JobTypeFreelance, models.JobTypeInternship,
+	}
+	properties["work_mode"].(map[string]interface{})["enum"] = []string{
+		"", models.WorkModeRemote, models.WorkModeHybrid, models.WorkModeOnsite,
+	}
+	properties["experience_level"].(map[string]interface{})["enum"] = []string{
+		"", models.ExperienceIntern, models.ExperienceJunior, models.ExperienceMid, models.ExperienceSenior, models.ExperienceLead,
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Job Scraper API",
+			"description": "Read access to the jobs stored by the job scraper",
+			"version":     openAPIVersion,
+		},
+		"paths": map[string]interface{}{
+			"/jobs": map[string]interface{}{
+				"get": map[string]interface{}{
+					"summary":     "List stored jobs",
+					"description": "Lists stored jobs, newest first, or searches them when q is given, best match first",
+					"operationId": "listJobs",
+					"parameters": []interface{}{
+						queryParam("source", "Only jobs from this source, e.g. Remotive", stringSchema()),
+						queryParam("category", "Only jobs of this category", stringSchema()),
+						queryParam("job_type", "Only jobs of this job type, e.g. full-time", stringSchema()),
+						queryParam("q", "Full-text search of titles and descriptions", stringSchema()),
+						queryParam("limit", "Maximum number of jobs returned, 0 for the largest page", map[string]interface{}{"type": "integer", "minimum": 0, "maximum": maxLimit, "default": defaultLimit}),
+						queryParam("offset", "Number of jobs skipped", map[string]interface{}{"type": "integer", "minimum": 0, "default": 0}),
+					},
+					"responses": map[string]interface{}{
+						"200": pagedResponse(jsonResponse("Matching jobs", map[string]interface{}{"type": "array", "items": schemaRef("Job")})),
+						"304": map[string]interface{}{"description": "The jobs are unchanged since the ETag in If-None-Match"},
+						"400": errorResponse("Invalid query parameter"),
+						"500": errorResponse("Jobs could not be queried"),
+						"501": errorResponse("q was given but the jobs table cannot be searched"),
+					},
+				},
+			},
+			"/jobs/{id}": map[string]interface{}{
+				"get": map[string]interface{}{
+					"summary":     "Get a stored job",
+					"operationId": "getJob",
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"name":        "id",
+							"in":          "path",
+							"description": "Storage ID of the job",
+							"required":    true,
+							"schema":      map[string]interface{}{"type": "integer", "minimum": 1},
+						},
+					},
+					"responses": map[string]interface{}{
+						"200": jsonResponse("The job", schemaRef("Job")),
+						"304": map[string]interface{}{"description": "The job is unchanged since the ETag in If-None-Match"},
+						"400": errorResponse("Invalid job ID"),
+						"404": errorResponse("No job has this ID"),
+						"500": errorResponse("The job could not be queried"),
+					},
+				},
+			},
+			"/healthz": map[string]interface{}{
+				"get": map[string]interface{}{
+					"summary":     "Health check",
+					"operationId": "health",
+					"responses": map[string]interface{}{
+						"200": jsonResponse("The API is up", map[string]interface{}{
+							"type":       "object",
+							"properties": map[string]interface{}{"status": map[string]interface{}{"type": "string", "example": "ok"}},
+							"required":   []string{"status"},
+						}),
+					},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"Job": jobSchema,
+				"Error": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"error": stringSchema()},
+					"required":   []string{"error"},
+				},
+			},
+		},
+	}
+}
+
+// pagedResponse adds the pagination headers of /jobs to a response
+func pagedResponse(response map[string]interface{}) map[string]interface{} {
+	response["headers"] = map[string]interface{}{
+		"X-Total-Count": map[string]interface{}{
+			"description": "Number of jobs matching the query across all pages",
+			"schema":      map[string]interface{}{"type": "integer"},
+		},
+		"ETag": map[string]interface{}{
+			"description": "Tag of the response, sent back in If-None-Match to get 304 Not Modified while it is unchanged",
+			"schema":      stringSchema(),
+		},
+		"Link": map[string]interface{}{
+			"description": `Links to the next and previous pages, e.g. </jobs?limit=50&offset=50>; rel="next"`,
+			"schema":      stringSchema(),
+		},
+	}
+	return response
+}
+
+// schemaRef references a schema in the document's components
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func stringSchema() map[string]interface{} {
+	return map[string]interface{}{"type": "string"}
+}
+
+// objectSchema generates the schema of a struct from its JSON encoding. Fields
+// without omitempty are always encoded and so are required.
+func objectSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// typeSchema maps a Go type onto its JSON schema
+func typeSchema(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Pointer {
+		schema := typeSchema(t.Elem())
+		schema["nullable"] = true
+		return schema
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return stringSchema()
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return objectSchema(t)
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// openAPIDocument is the part of an OpenAPI document checked by the tests
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Type       string                            `json:"type"`
+			Properties map[string]map[string]interface{} `json:"properties"`
+			Required   []string                          `json:"required"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	recorder := get(t, server, "/openapi.json", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	var doc openAPIDocument
+	if err := json.Unmarshal(recorder.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding %s: %v", recorder.Body, err)
+	}
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("openapi = %q, want 3.0.3", doc.OpenAPI)
+	}
+
+	var paths []string
+	for path, operations := range doc.Paths {
+		if _, ok := operations["get"]; !ok {
+			t.Errorf("%s has no GET operation", path)
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if want := []string{"/healthz", "/jobs", "/jobs/{id}"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+}
+
+func TestOpenAPIJobSchemaFollowsModel(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	var doc openAPIDocument
+	if err := json.Unmarshal(get(t, server, "/openapi.json", nil).Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	job, ok := doc.Components.Schemas["Job"]
+	if !ok {
+		t.Fatal("no Job schema")
+	}
+
+	tests := []struct {
+		property string
+		want     map[string]interface{}
+	}{
+		{"title", map[string]interface{}{"type": "string"}},
+		{"salary_min", map[string]interface{}{"type": "integer"}},
+		{"posted_date", map[string]interface{}{"type": "string", "format": "date-time", "nullable": true}},
+		{"scraped_at", map[string]interface{}{"type": "string", "format": "date-time"}},
+		{"tags", map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}},
+	}
+	for _, tt := range tests {
+		if got := job.Properties[tt.property]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s schema = %v, want %v", tt.property, got, tt.want)
+		}
+	}
+	if enum := job.Properties["job_type"]["enum"]; enum == nil {
+		t.Error("job_type has no enum of the job types")
+	}
+
+	// Every field but the omitempty id is always encoded
+	required := make(map[string]bool)
+	for _, name := range job.Required {
+		required[name] = true
+	}
+	if required["id"] || !required["title"] || !required["url"] {
+		t.Errorf("required = %q, want every field but id", job.Required)
+	}
+}
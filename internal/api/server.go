@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/storage"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultLimit is the page size used when no limit is requested
+const defaultLimit = 50
+
+// maxLimit caps the page size. Larger limits, and a limit of 0, return pages
+// of maxLimit jobs.
+const maxLimit = 200
+
+// Server exposes stored jobs over a REST API
+type Server struct {
+	store  storage.Store
+	logger *logging.Logger
+}
+
+// NewServer creates a new API server backed by the given store
+func NewServer(store storage.Store, logger *logging.Logger) *Server {
+	return &Server{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Handler returns the HTTP handler serving all API routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /jobs", s.handleJobs)
+	mux.HandleFunc("GET /jobs/{id}", s.handleJob)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	return mux
+}
+
+// NewHTTPServer creates an HTTP server for the API using the configured port and timeouts
+func (s *Server) NewHTTPServer(cfg config.ServerConfig) *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		Handler:      s.Handler(),
+		ReadTimeout:  cfg.ReadTimeout.Duration,
+		WriteTimeout: cfg.WriteTimeout.Duration,
+		IdleTimeout:  cfg.IdleTimeout.Duration,
+	}
+}
+
+// handleJobs lists stored jobs filtered by source, category, and job_type,
+// or searches them when q is given
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := parseNonNegative(query.Get("limit"), defaultLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit: %v", err))
+		return
+	}
+	offset, err := parseNonNegative(query.Get("offset"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid offset: %v", err))
+		return
+	}
+	if limit == 0 || limit > maxLimit {
+		limit = maxLimit
+	}
+
+	filter := storage.JobFilter{
+		Source:   query.Get("source"),
+		Category: query.Get("category"),
+		JobType:  query.Get("job_type"),
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	var jobs []models.Job
+	var total int64
+	if q := query.Get("q"); q != "" {
+		jobs, total, err = s.searchJobs(r.Context(), q, filter)
+	} else {
+		jobs, total, err = s.listJobs(r.Context(), filter)
+	}
+	if errors.Is(err, storage.ErrSearchUnsupported) {
+		writeError(w, http.StatusNotImplemented, err.Error())
+		return
+	}
+	if err != nil {
+		s.logger.Errorf("Failed to query jobs: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to query jobs")
+		return
+	}
+
+	if jobs == nil {
+		jobs = []models.Job{}
+	}
+	setPaginationHeaders(w, r, filter, total)
+	writeJSONWithETag(w, r, jobs, strconv.FormatInt(total, 10))
+}
+
+// handleJob returns a single job by its storage ID
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid job id %q", r.PathValue("id")))
+		return
+	}
+
+	job, err := s.store.GetJobByID(r.Context(), id)
+	if err != nil {
+		s.logger.Errorf("Failed to get job %d: %v", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to get job")
+		return
+	}
+	if job == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("job %d not found", id))
+		return
+	}
+
+	writeJSONWithETag(w, r, job, "")
+}
+
+// listJobs returns a page of stored jobs along with the number of jobs
+// matching the filter
+func (s *Server) listJobs(ctx context.Context, filter storage.JobFilter) ([]models.Job, int64, error) {
+	total, err := s.store.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if int64(filter.Offset) >= total {
+		return nil, total, nil
+	}
+
+	jobs, err := s.store.GetJobsFiltered(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+// searchJobs runs a full-text search, best match first, returning a page of
+// the results along with their number. Without field filters only the results
+// up to the end of the page are fetched. The field filters apply to the ranked
+// results before paging, so a filtered search fetches every match.
+func (s *Server) searchJobs(ctx context.Context, q string, filter storage.JobFilter) ([]models.Job, int64, error) {
+	if filter.Source == "" && filter.Category == "" && filter.JobType == "" {
+		total, err := s.store.CountSearch(ctx, q)
+		if err != nil {
+			return nil, 0, err
+		}
+		if int64(filter.Offset) >= total {
+			return nil, total, nil
+		}
+
+		results, err := s.store.SearchJobs(ctx, q, filter.Offset+filter.Limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page(results, filter), total, nil
+	}
+
+	results, err := s.store.SearchJobs(ctx, q, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []models.Job
+	for _, job := range results {
+		if filter.Matches(job) {
+			jobs = append(jobs, job)
+		}
+	}
+	return page(jobs, filter), int64(len(jobs)), nil
+}
+
+// page returns the jobs in the page of the filter's offset and limit
+func page(jobs []models.Job, filter storage.JobFilter) []models.Job {
+	if filter.Offset >= len(jobs) {
+		return nil
+	}
+	jobs = jobs[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(jobs) {
+		jobs = jobs[:filter.Limit]
+	}
+	return jobs
+}
+
+// setPaginationHeaders sets X-Total-Count to the number of matching jobs and
+// a Link header with the next and previous pages, where there are any
+func setPaginationHeaders(w http.ResponseWriter, r *http.Request, filter storage.JobFilter, total int64) {
+	w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+
+	var links []string
+	if next := filter.Offset + filter.Limit; int64(next) < total {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(r, next, filter.Limit)))
+	}
+	if filter.Offset > 0 {
+		prev := filter.Offset - filter.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(r, prev, filter.Limit)))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageURL returns the request's URL with another page's offset and limit
+func pageURL(r *http.Request, offset, limit int) string {
+	query := r.URL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+
+	page := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return page.String()
+}
+
+// handleHealth reports that the API is up
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// parseNonNegative parses an optional non-negative integer query parameter
+func parseNonNegative(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%d must not be negative", n)
+	}
+	return n, nil
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeJSONWithETag writes a 200 JSON response with an ETag hashed from the
+// body and extra, which covers response state outside the body such as the
+// total count. When the request's If-None-Match holds that ETag it writes
+// 304 Not Modified instead, without the body.
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, data interface{}, extra string) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+	body = append(body, '\n')
+
+	hash := sha256.New()
+	hash.Write(body)
+	hash.Write([]byte(extra))
+	etag := fmt.Sprintf(`"%x"`, hash.Sum(nil)[:16])
+
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// etagMatches reports whether an If-None-Match header lists the ETag,
+// comparing weakly as RFC 9110 requires for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
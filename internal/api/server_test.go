@@ -0,0 +1,390 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/logging"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/storage"
+)
+
+// newTestServer returns an API server over an in-memory store holding jobs.
+// The jobs are posted a day apart, the first one most recently.
+func newTestServer(t *testing.T, jobs ...models.Job) (*Server, *storage.MemoryStore) {
+	t.Helper()
+
+	store := storage.NewMemoryStore()
+	posted := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := range jobs {
+		date := posted.AddDate(0, 0, -i)
+		jobs[i].PostedDate = &date
+	}
+	if err := store.SaveJobs(context.Background(), jobs); err != nil {
+		t.Fatal(err)
+	}
+	return NewServer(store, logging.New(io.Discard, "", 0, logging.LevelError)), store
+}
+
+// get serves a GET request for target
+func get(t *testing.T, server *Server, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+// decodeJobs decodes a JSON array of jobs, failing the test unless the
+// response is a 200
+func decodeJobs(t *testing.T, recorder *httptest.ResponseRecorder) []models.Job {
+	t.Helper()
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+	var jobs []models.Job
+	if err := json.Unmarshal(recorder.Body.Bytes(), &jobs); err != nil {
+		t.Fatalf("decoding %s: %v", recorder.Body, err)
+	}
+	return jobs
+}
+
+// sampleJobs returns jobs of several sources, categories and job types
+func sampleJobs() []models.Job {
+	return []models.Job{
+		{Title: "Go Developer", Company: "Acme", URL: "https://example.com/1", Source: "RemoteOK", JobCategory: "Backend Development", JobType: models.JobTypeFullTime, Description: "Golang APIs"},
+		{Title: "Designer", Company: "Globex", URL: "https://example.com/2", Source: "Remotive", JobCategory: "Design", JobType: models.JobTypeContract, Description: "Figma"},
+		{Title: "Rust Developer", Company: "Initech", URL: "https://example.com/3", Source: "RemoteOK", JobCategory: "Backend Development", JobType: models.JobTypeContract, Description: "Systems"},
+		{Title: "Data Intern", Company: "Umbrella", URL: "https://example.com/4", Source: "Remotive", JobCategory: "Data Science", JobType: models.JobTypeInternship, Description: "Python"},
+	}
+}
+
+func TestListJobsFilters(t *testing.T) {
+	server, _ := newTestServer(t, sampleJobs()...)
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"/jobs", []string{"Go Developer", "Designer", "Rust Developer", "Data Intern"}},
+		{"/jobs?source=RemoteOK", []string{"Go Developer", "Rust Developer"}},
+		{"/jobs?category=Design", []string{"Designer"}},
+		{"/jobs?job_type=contract", []string{"Designer", "Rust Developer"}},
+		{"/jobs?source=RemoteOK&job_type=contract", []string{"Rust Developer"}},
+		{"/jobs?limit=2", []string{"Go Developer", "Designer"}},
+		{"/jobs?limit=2&offset=1", []string{"Designer", "Rust Developer"}},
+		{"/jobs?source=Indeed", nil},
+		{"/jobs?offset=10", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			jobs := decodeJobs(t, get(t, server, tt.target, nil))
+
+			var got []string
+			for _, job := range jobs {
+				got = append(got, job.Title)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GET %s = %q, want %q", tt.target, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GET %s = %q, want %q", tt.target, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestListJobsEmptyResultIsEmptyArray(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	recorder := get(t, server, "/jobs", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	if got := recorder.Body.String(); got != "[]\n" {
+		t.Errorf("body = %q, want an empty JSON array", got)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+}
+
+func TestListJobsRejectsInvalidPaging(t *testing.T) {
+	server, _ := newTestServer(t, sampleJobs()...)
+
+	for _, target := range []string{"/jobs?limit=ten", "/jobs?limit=-1", "/jobs?offset=x", "/jobs?offset=-5"} {
+		if recorder := get(t, server, target, nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", target, recorder.Code)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	recorder := get(t, server, "/healthz", nil)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "{\"status\":\"ok\"}\n" {
+		t.Errorf("GET /healthz = %d %q, want 200 with status ok", recorder.Code, recorder.Body)
+	}
+}
+
+func TestListJobsSearch(t *testing.T) {
+	server, _ := newTestServer(t,
+		models.Job{Title: "Platform Engineer", URL: "https://example.com/1", Source: "RemoteOK", Description: "Kubernetes clusters"},
+		models.Job{Title: "Kubernetes Engineer", URL: "https://example.com/2", Source: "Remotive", Description: "Clusters on AWS"},
+		models.Job{Title: "Designer", URL: "https://example.com/3", Source: "RemoteOK", Description: "Figma"},
+	)
+
+	tests := []struct {
+		target    string
+		want      []string
+		wantTotal string
+	}{
+		// The title match ranks above the newer description match
+		{"/jobs?q=kubernetes", []string{"Kubernetes Engineer", "Platform Engineer"}, "2"},
+		{"/jobs?q=kubernetes+aws", []string{"Kubernetes Engineer"}, "1"},
+		{"/jobs?q=kubernetes&source=RemoteOK", []string{"Platform Engineer"}, "1"},
+		{"/jobs?q=rust", nil, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			recorder := get(t, server, tt.target, nil)
+			var got []string
+			for _, job := range decodeJobs(t, recorder) {
+				got = append(got, job.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GET %s = %q, want %q", tt.target, got, tt.want)
+			}
+			if total := recorder.Header().Get("X-Total-Count"); total != tt.wantTotal {
+				t.Errorf("X-Total-Count = %s, want %s", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+// unsearchableStore is a memory store whose table cannot be searched
+type unsearchableStore struct {
+	*storage.MemoryStore
+}
+
+func (unsearchableStore) SearchJobs(ctx context.Context, query string, limit int) ([]models.Job, error) {
+	return nil, storage.ErrSearchUnsupported
+}
+
+func (unsearchableStore) CountSearch(ctx context.Context, query string) (int64, error) {
+	return 0, storage.ErrSearchUnsupported
+}
+
+func TestListJobsSearchUnsupported(t *testing.T) {
+	server := NewServer(unsearchableStore{storage.NewMemoryStore()}, logging.New(io.Discard, "", 0, logging.LevelError))
+
+	if code := get(t, server, "/jobs?q=golang", nil).Code; code != http.StatusNotImplemented {
+		t.Errorf("search status = %d, want 501", code)
+	}
+	// Listing without a query still works
+	if code := get(t, server, "/jobs", nil).Code; code != http.StatusOK {
+		t.Errorf("list status = %d, want 200", code)
+	}
+}
+
+// numberedJobs returns n jobs titled "Go Developer 1" to "Go Developer n"
+func numberedJobs(n int) []models.Job {
+	jobs := make([]models.Job, n)
+	for i := range jobs {
+		jobs[i] = models.Job{
+			Title:       fmt.Sprintf("Go Developer %d", i+1),
+			URL:         fmt.Sprintf("https://example.com/%d", i+1),
+			Source:      "RemoteOK",
+			Description: "Golang services",
+		}
+	}
+	return jobs
+}
+
+func TestListJobsPagination(t *testing.T) {
+	server, _ := newTestServer(t, numberedJobs(5)...)
+
+	tests := []struct {
+		target    string
+		wantFirst string
+		wantCount int
+		wantLink  string
+	}{
+		{"/jobs?limit=2", "Go Developer 1", 2, `</jobs?limit=2&offset=2>; rel="next"`},
+		{"/jobs?limit=2&offset=2", "Go Developer 3", 2, `</jobs?limit=2&offset=4>; rel="next", </jobs?limit=2&offset=0>; rel="prev"`},
+		{"/jobs?limit=2&offset=4", "Go Developer 5", 1, `</jobs?limit=2&offset=2>; rel="prev"`},
+		{"/jobs?limit=2&offset=1", "Go Developer 2", 2, `</jobs?limit=2&offset=3>; rel="next", </jobs?limit=2&offset=0>; rel="prev"`},
+		{"/jobs?source=RemoteOK&limit=3", "Go Developer 1", 3, `</jobs?limit=3&offset=3&source=RemoteOK>; rel="next"`},
+		{"/jobs?q=golang&limit=2&offset=2", "Go Developer 3", 2, `</jobs?limit=2&offset=4&q=golang>; rel="next", </jobs?limit=2&offset=0&q=golang>; rel="prev"`},
+		{"/jobs", "Go Developer 1", 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			recorder := get(t, server, tt.target, nil)
+			jobs := decodeJobs(t, recorder)
+
+			if len(jobs) != tt.wantCount || jobs[0].Title != tt.wantFirst {
+				t.Errorf("GET %s returned %d jobs from %q, want %d from %q", tt.target, len(jobs), jobs[0].Title, tt.wantCount, tt.wantFirst)
+			}
+			if total := recorder.Header().Get("X-Total-Count"); total != "5" {
+				t.Errorf("X-Total-Count = %s, want 5", total)
+			}
+			if link := recorder.Header().Get("Link"); link != tt.wantLink {
+				t.Errorf("Link = %s, want %s", link, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestListJobsCapsPageSize(t *testing.T) {
+	server, _ := newTestServer(t, numberedJobs(maxLimit+50)...)
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/jobs", defaultLimit},
+		{"/jobs?limit=10", 10},
+		{fmt.Sprintf("/jobs?limit=%d", maxLimit), maxLimit},
+		{"/jobs?limit=1000", maxLimit},
+		{"/jobs?limit=0", maxLimit},
+		{"/jobs?q=golang&limit=1000", maxLimit},
+		{"/jobs?q=golang&source=RemoteOK&limit=1000", maxLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			recorder := get(t, server, tt.target, nil)
+			if got := len(decodeJobs(t, recorder)); got != tt.want {
+				t.Errorf("GET %s returned %d jobs, want %d", tt.target, got, tt.want)
+			}
+			if total, want := recorder.Header().Get("X-Total-Count"), fmt.Sprint(maxLimit+50); total != want {
+				t.Errorf("X-Total-Count = %s, want %s", total, want)
+			}
+		})
+	}
+}
+
+// brokenStore is a memory store whose jobs cannot be read by ID
+type brokenStore struct {
+	*storage.MemoryStore
+}
+
+func (brokenStore) GetJobByID(ctx context.Context, id int) (*models.Job, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestGetJob(t *testing.T) {
+	server, _ := newTestServer(t, sampleJobs()...)
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/jobs/99", http.StatusNotFound},
+		{"/jobs/abc", http.StatusBadRequest},
+		{"/jobs/0", http.StatusBadRequest},
+		{"/jobs/-1", http.StatusBadRequest},
+		{"/jobs/1.5", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if code := get(t, server, tt.target, nil).Code; code != tt.want {
+				t.Errorf("GET %s status = %d, want %d", tt.target, code, tt.want)
+			}
+		})
+	}
+
+	recorder := get(t, server, "/jobs/2", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+	var job models.Job
+	if err := json.Unmarshal(recorder.Body.Bytes(), &job); err != nil {
+		t.Fatalf("decoding %s: %v", recorder.Body, err)
+	}
+	if job.ID != 2 || job.Title != "Designer" {
+		t.Errorf("GET /jobs/2 = job %d %q, want job 2 %q", job.ID, job.Title, "Designer")
+	}
+}
+
+func TestGetJobStoreError(t *testing.T) {
+	server := NewServer(brokenStore{storage.NewMemoryStore()}, logging.New(io.Discard, "", 0, logging.LevelError))
+
+	if code := get(t, server, "/jobs/1", nil).Code; code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+}
+
+func TestETag(t *testing.T) {
+	server, store := newTestServer(t, sampleJobs()...)
+
+	for _, target := range []string{"/jobs?limit=1", "/jobs?source=Remotive", "/jobs/1"} {
+		t.Run(target, func(t *testing.T) {
+			first := get(t, server, target, nil)
+			etag := first.Header().Get("ETag")
+			if first.Code != http.StatusOK || etag == "" {
+				t.Fatalf("status = %d, ETag = %q; want 200 with an ETag", first.Code, etag)
+			}
+			if again := get(t, server, target, nil).Header().Get("ETag"); again != etag {
+				t.Errorf("repeated ETag = %s, want %s", again, etag)
+			}
+
+			for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+				recorder := get(t, server, target, http.Header{"If-None-Match": {ifNoneMatch}})
+				if recorder.Code != http.StatusNotModified {
+					t.Errorf("If-None-Match %s: status = %d, want 304", ifNoneMatch, recorder.Code)
+				}
+				if recorder.Body.Len() != 0 {
+					t.Errorf("If-None-Match %s: body = %q, want empty", ifNoneMatch, recorder.Body)
+				}
+				if got := recorder.Header().Get("ETag"); got != etag {
+					t.Errorf("If-None-Match %s: ETag = %s, want %s", ifNoneMatch, got, etag)
+				}
+			}
+
+			recorder := get(t, server, target, http.Header{"If-None-Match": {`"other"`}})
+			if recorder.Code != http.StatusOK {
+				t.Errorf("stale If-None-Match: status = %d, want 200", recorder.Code)
+			}
+		})
+	}
+
+	// A job posted before the others leaves the first page unchanged but
+	// raises the total, so the page's ETag changes too
+	before := get(t, server, "/jobs?limit=1", nil).Header().Get("ETag")
+	posted := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	older := models.Job{Title: "Old Job", URL: "https://example.com/old", Source: "Remotive", PostedDate: &posted}
+	if err := store.SaveJobs(context.Background(), []models.Job{older}); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := get(t, server, "/jobs?limit=1", http.Header{"If-None-Match": {before}})
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status after a change = %d, want 200", recorder.Code)
+	}
+	if after := recorder.Header().Get("ETag"); after == "" || after == before {
+		t.Errorf("ETag after a change = %q, want a new ETag other than %s", after, before)
+	}
+}
@@ -3,89 +3,228 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"job-scraper-go/internal/models"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Server     ServerConfig     `json:"server"`
-	Database   DatabaseConfig   `json:"database"`
-	Scraper    ScraperConfig    `json:"scraper"`
-	Sources    SourcesConfig    `json:"sources"`
-	Monitoring MonitoringConfig `json:"monitoring"`
+	Server        ServerConfig        `json:"server" yaml:"server"`
+	Database      DatabaseConfig      `json:"database" yaml:"database"`
+	Scraper       ScraperConfig       `json:"scraper" yaml:"scraper"`
+	Sources       SourcesConfig       `json:"sources" yaml:"sources"`
+	Monitoring    MonitoringConfig    `json:"monitoring" yaml:"monitoring"`
+	Notifications NotificationsConfig `json:"notifications" yaml:"notifications"`
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	Port         int           `json:"port"`
-	ReadTimeout  time.Duration `json:"read_timeout"`
-	WriteTimeout time.Duration `json:"write_timeout"`
-	IdleTimeout  time.Duration `json:"idle_timeout"`
+	Port            int      `json:"port" yaml:"port"`
+	ReadTimeout     Duration `json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout    Duration `json:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout     Duration `json:"idle_timeout" yaml:"idle_timeout"`
+	ShutdownTimeout Duration `json:"shutdown_timeout" yaml:"shutdown_timeout"` // how long the daemon waits for background work before exiting anyway
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	SupabaseURL string `json:"supabase_url"`
-	SupabaseKey string `json:"supabase_key"`
+	SupabaseURL     string   `json:"supabase_url,omitempty" yaml:"supabase_url,omitempty"`         // defaults to SUPABASE_URL
+	SupabaseKey     string   `json:"supabase_key,omitempty" yaml:"supabase_key,omitempty"`         // defaults to SUPABASE_KEY
+	Table           string   `json:"table,omitempty" yaml:"table,omitempty"`                       // defaults to "jobs"
+	RetentionPeriod Duration `json:"retention_period,omitempty" yaml:"retention_period,omitempty"` // delete jobs older than this, 0 keeps all jobs
 }
 
 // ScraperConfig holds scraper configuration
 type ScraperConfig struct {
-	ConcurrentSources int           `json:"concurrent_sources"`
-	BatchSize         int           `json:"batch_size"`
-	RetryAttempts     int           `json:"retry_attempts"`
-	RetryDelay        time.Duration `json:"retry_delay"`
-	ScrapingInterval  time.Duration `json:"scraping_interval"`
-	RequestTimeout    time.Duration `json:"request_timeout"`
-	EnableDedup       bool          `json:"enable_dedup"`
+	ConcurrentSources    int             `json:"concurrent_sources" yaml:"concurrent_sources"`
+	BatchSize            int             `json:"batch_size" yaml:"batch_size"`
+	ConcurrentSaves      int             `json:"concurrent_saves" yaml:"concurrent_saves"` // batch saves run at once, 1 saves sequentially
+	RetryAttempts        int             `json:"retry_attempts" yaml:"retry_attempts"`
+	SaveRetryAttempts    int             `json:"save_retry_attempts" yaml:"save_retry_attempts"` // retries of failed storage saves, using the same delays
+	RetryDelay           Duration        `json:"retry_delay" yaml:"retry_delay"`
+	MaxRetryDelay        Duration        `json:"max_retry_delay" yaml:"max_retry_delay"`
+	BackoffFactor        float64         `json:"backoff_factor" yaml:"backoff_factor"`
+	RetryJitter          bool            `json:"retry_jitter" yaml:"retry_jitter"` // randomize retry delays so failing sources do not retry in lockstep
+	ScrapingInterval     Duration        `json:"scraping_interval" yaml:"scraping_interval"`
+	RequestTimeout       Duration        `json:"request_timeout" yaml:"request_timeout"`
+	ScrapeTimeout        Duration        `json:"scrape_timeout" yaml:"scrape_timeout"`                 // deadline for a whole scraping run, 0 for none
+	MaxResponseBytes     int             `json:"max_response_bytes" yaml:"max_response_bytes"`         // response body size limit, 0 for unlimited
+	ProxyURL             string          `json:"proxy_url,omitempty" yaml:"proxy_url,omitempty"`       // defaults to HTTP_PROXY/HTTPS_PROXY
+	ConditionalFetch     bool            `json:"conditional_fetch" yaml:"conditional_fetch"`           // revalidate with ETag/Last-Modified and skip unchanged feeds
+	RefetchMalformedJSON bool            `json:"refetch_malformed_json" yaml:"refetch_malformed_json"` // fetch a source again at once when its JSON response cannot be parsed
+	Transport            TransportConfig `json:"transport" yaml:"transport"`
+	EnableDedup          bool            `json:"enable_dedup" yaml:"enable_dedup"`
+	MergeDuplicates      bool            `json:"merge_duplicates" yaml:"merge_duplicates"`                   // fill empty fields of a job from its duplicates instead of dropping them
+	SimilarityThreshold  float64         `json:"similarity_threshold" yaml:"similarity_threshold"`           // jobs at least this similar across sources are near-duplicates (0 to 1, 0 disables)
+	SourcePriority       []string        `json:"source_priority,omitempty" yaml:"source_priority,omitempty"` // sources whose copy of a duplicate is kept, highest priority first
+	StateFile            string          `json:"state_file,omitempty" yaml:"state_file,omitempty"`           // enables incremental scraping, tracking each source's last scrape time
+	Validation           string          `json:"validation" yaml:"validation"`                               // "off", "basic" (default) or "strict" checks before saving jobs
+	SeedDedup            bool            `json:"seed_dedup" yaml:"seed_dedup"`                               // treat jobs already in storage as duplicates; loads the whole table at startup
+}
+
+// TransportConfig holds HTTP connection pool settings
+type TransportConfig struct {
+	MaxIdleConns        int      `json:"max_idle_conns" yaml:"max_idle_conns"`
+	MaxIdleConnsPerHost int      `json:"max_idle_conns_per_host" yaml:"max_idle_conns_per_host"`
+	IdleConnTimeout     Duration `json:"idle_conn_timeout" yaml:"idle_conn_timeout"`
+	DisableKeepAlives   bool     `json:"disable_keep_alives" yaml:"disable_keep_alives"`
 }
 
 // SourcesConfig holds configuration for all job sources
 type SourcesConfig struct {
-	RemoteOK       SourceConfig `json:"remoteok"`
-	Remotive       SourceConfig `json:"remotive"`
-	WeWorkRemotely SourceConfig `json:"wework_remotely"`
+	RemoteOK       SourceConfig `json:"remoteok" yaml:"remoteok"`
+	Remotive       SourceConfig `json:"remotive" yaml:"remotive"`
+	WeWorkRemotely SourceConfig `json:"wework_remotely" yaml:"wework_remotely"`
+	Indeed         SourceConfig `json:"indeed" yaml:"indeed"`
+	Feeds          []FeedConfig `json:"feeds,omitempty" yaml:"feeds,omitempty"` // generic RSS/Atom job feeds
 }
 
 // SourceConfig holds configuration for individual sources
 type SourceConfig struct {
-	Enabled     bool     `json:"enabled"`
-	RateLimit   int      `json:"rate_limit"`
-	SearchTerms []string `json:"search_terms"`
-	Locations   []string `json:"locations"`
-	JobTypes    []string `json:"job_types"`
+	Enabled          bool       `json:"enabled" yaml:"enabled"`
+	RateLimit        int        `json:"rate_limit" yaml:"rate_limit"`
+	RateWindow       Duration   `json:"rate_window,omitempty" yaml:"rate_window,omitempty"`                 // window for RateLimit, defaults to a minute
+	Burst            int        `json:"burst,omitempty" yaml:"burst,omitempty"`                             // requests allowed at once after idling, defaults to 1
+	Timeout          Duration   `json:"timeout,omitempty" yaml:"timeout,omitempty"`                         // limit for each fetch, defaults to request_timeout
+	RequestDelay     Duration   `json:"request_delay,omitempty" yaml:"request_delay,omitempty"`             // pause between the requests of a single fetch, e.g. one per location or category
+	MinExpectedJobs  int        `json:"min_expected_jobs,omitempty" yaml:"min_expected_jobs,omitempty"`     // fetches with fewer jobs are logged and counted as suspicious runs
+	FailBelowMinJobs bool       `json:"fail_below_min_jobs,omitempty" yaml:"fail_below_min_jobs,omitempty"` // treat suspicious runs as errors
+	SearchTerms      []string   `json:"search_terms" yaml:"search_terms"`
+	SearchMode       string     `json:"search_mode,omitempty" yaml:"search_mode,omitempty"` // "any" (default) or "all" search terms must match
+	Locations        []string   `json:"locations" yaml:"locations"`
+	JobTypes         []string   `json:"job_types" yaml:"job_types"`
+	PreserveHTML     bool       `json:"preserve_html,omitempty" yaml:"preserve_html,omitempty"` // keep raw HTML in job descriptions
+	Auth             AuthConfig `json:"auth,omitempty" yaml:"auth,omitempty"`                   // credentials for sources that require an API key or token
+}
+
+// AuthConfig holds the credentials sent with a source's requests, either a
+// header name and value such as "X-Api-Key", or a bearer token sent in the
+// Authorization header
+type AuthConfig struct {
+	Header      string `json:"header,omitempty" yaml:"header,omitempty"`
+	Value       string `json:"value,omitempty" yaml:"value,omitempty"`
+	BearerToken string `json:"bearer_token,omitempty" yaml:"bearer_token,omitempty"`
+}
+
+// HeaderValue returns the header the credentials are sent in, with an empty
+// name when none are configured
+func (a AuthConfig) HeaderValue() (name, value string) {
+	if a.BearerToken != "" {
+		return "Authorization", "Bearer " + a.BearerToken
+	}
+	return a.Header, a.Value
+}
+
+// MaskSecret hides all but the ends of a secret, such as an API key, for
+// display in logs and output
+func MaskSecret(s string) string {
+	if len(s) <= 8 {
+		return "***"
+	}
+	return s[:4] + "***" + s[len(s)-4:]
+}
+
+// ByName returns the built-in source configurations keyed by their config name
+func (s SourcesConfig) ByName() map[string]SourceConfig {
+	return map[string]SourceConfig{
+		"remoteok":        s.RemoteOK,
+		"remotive":        s.Remotive,
+		"wework_remotely": s.WeWorkRemotely,
+		"indeed":          s.Indeed,
+	}
+}
+
+// FeedConfig holds configuration for a generic RSS/Atom job feed
+type FeedConfig struct {
+	Name       string           `json:"name" yaml:"name"`
+	URL        string           `json:"url" yaml:"url"`
+	Enabled    bool             `json:"enabled" yaml:"enabled"`
+	RateLimit  int              `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	RateWindow Duration         `json:"rate_window,omitempty" yaml:"rate_window,omitempty"`
+	Burst      int              `json:"burst,omitempty" yaml:"burst,omitempty"`
+	Timeout    Duration         `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Fields     FeedFieldMapping `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// FeedFieldMapping names the feed item elements mapped onto job fields.
+// Empty fields fall back to the standard RSS/Atom element names.
+type FeedFieldMapping struct {
+	Title       string `json:"title,omitempty" yaml:"title,omitempty"`
+	URL         string `json:"url,omitempty" yaml:"url,omitempty"`
+	PostedDate  string `json:"posted_date,omitempty" yaml:"posted_date,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Company     string `json:"company,omitempty" yaml:"company,omitempty"`
+	Location    string `json:"location,omitempty" yaml:"location,omitempty"`
+	Category    string `json:"category,omitempty" yaml:"category,omitempty"`
+	Salary      string `json:"salary,omitempty" yaml:"salary,omitempty"`
 }
 
 // MonitoringConfig holds monitoring configuration
 type MonitoringConfig struct {
-	Enabled         bool          `json:"enabled"`
-	MetricsInterval time.Duration `json:"metrics_interval"`
-	LogLevel        string        `json:"log_level"`
-	LogFile         string        `json:"log_file"`
+	Enabled         bool     `json:"enabled" yaml:"enabled"`
+	MetricsInterval Duration `json:"metrics_interval" yaml:"metrics_interval"`
+	LogLevel        string   `json:"log_level" yaml:"log_level"`
+	LogFormat       string   `json:"log_format,omitempty" yaml:"log_format,omitempty"` // "text" (default) or "json" lines
+	LogFile         string   `json:"log_file" yaml:"log_file"`
+	MaxLogSizeMB    int      `json:"max_log_size_mb" yaml:"max_log_size_mb"`                 // rotate the log file at this size, 0 never rotates
+	MaxLogBackups   int      `json:"max_log_backups" yaml:"max_log_backups"`                 // rotated files to keep, 0 keeps all
+	MaxLogAgeDays   int      `json:"max_log_age_days" yaml:"max_log_age_days"`               // days to keep rotated files, 0 keeps all
+	ResetMetrics    bool     `json:"reset_metrics,omitempty" yaml:"reset_metrics,omitempty"` // reset metrics after each report, for per-interval totals
+	MetricsFile     string   `json:"metrics_file,omitempty" yaml:"metrics_file,omitempty"`   // snapshots of the metrics after each run, read by the CLI metrics command
+}
+
+// NotificationsConfig holds configuration for new-job notifications
+type NotificationsConfig struct {
+	Enabled           bool     `json:"enabled" yaml:"enabled"`
+	Type              string   `json:"type" yaml:"type"` // webhook or slack
+	WebhookURL        string   `json:"webhook_url" yaml:"webhook_url"`
+	MaxJobsPerMessage int      `json:"max_jobs_per_message,omitempty" yaml:"max_jobs_per_message,omitempty"` // slack only
+	PostInterval      Duration `json:"post_interval,omitempty" yaml:"post_interval,omitempty"`               // slack only, minimum time between posts
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:         8080,
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  60 * time.Second,
+			Port:            8080,
+			ReadTimeout:     Duration{10 * time.Second},
+			WriteTimeout:    Duration{10 * time.Second},
+			IdleTimeout:     Duration{60 * time.Second},
+			ShutdownTimeout: Duration{30 * time.Second},
 		},
 		Database: DatabaseConfig{
 			SupabaseURL: os.Getenv("SUPABASE_URL"),
 			SupabaseKey: os.Getenv("SUPABASE_KEY"),
+			Table:       "jobs",
 		},
 		Scraper: ScraperConfig{
 			ConcurrentSources: 5,
 			BatchSize:         50,
+			ConcurrentSaves:   1, // avoid overwhelming the database
 			RetryAttempts:     3,
-			RetryDelay:        2 * time.Second,
-			ScrapingInterval:  15 * time.Minute,
-			RequestTimeout:    30 * time.Second,
-			EnableDedup:       true,
+			SaveRetryAttempts: 2,
+			RetryDelay:        Duration{2 * time.Second},
+			MaxRetryDelay:     Duration{30 * time.Second},
+			BackoffFactor:     2.0,
+			RetryJitter:       true,
+			ScrapingInterval:  Duration{15 * time.Minute},
+			RequestTimeout:    Duration{30 * time.Second},
+			ScrapeTimeout:     Duration{5 * time.Minute},
+			MaxResponseBytes:  50 << 20, // 50 MB
+			Transport: TransportConfig{
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: 10, // a few hosts are polled repeatedly
+				IdleConnTimeout:     Duration{90 * time.Second},
+			},
+			RefetchMalformedJSON: true,
+			EnableDedup:          true,
+			SimilarityThreshold:  0.85, // compares every pair of a run's jobs, O(n²); set 0 to skip on large scrapes
+			Validation:           models.ValidationBasic,
 		},
 		Sources: SourcesConfig{
 			RemoteOK: SourceConfig{
@@ -109,41 +248,79 @@ func DefaultConfig() *Config {
 				Locations:   []string{"remote"},
 				JobTypes:    []string{"full-time"},
 			},
+			Indeed: SourceConfig{
+				Enabled:     false,
+				RateLimit:   10,
+				SearchTerms: []string{"golang", "go developer"},
+				Locations:   []string{"remote"},
+			},
 		},
 		Monitoring: MonitoringConfig{
 			Enabled:         true,
-			MetricsInterval: 1 * time.Minute,
+			MetricsInterval: Duration{1 * time.Minute},
 			LogLevel:        "info",
+			LogFormat:       "text",
 			LogFile:         "logs/scraper.log",
+			MaxLogSizeMB:    100,
+			MaxLogBackups:   5,
+			MaxLogAgeDays:   30,
+			MetricsFile:     "data/metrics.json",
+		},
+		Notifications: NotificationsConfig{
+			Enabled:           false,
+			Type:              "webhook",
+			MaxJobsPerMessage: 20,
+			PostInterval:      Duration{1 * time.Second},
 		},
 	}
 }
 
-// LoadConfig loads configuration from a JSON file
+// LoadConfig loads configuration from a JSON or YAML file, chosen by the
+// file extension (.yaml/.yml for YAML, anything else for JSON). Environment
+// variables are applied on top, so the precedence is defaults < file < env.
 func LoadConfig(filename string) (*Config, error) {
 	// Start with default config
 	config := DefaultConfig()
 
-	// If file doesn't exist, return default config
+	if err := config.loadFile(filename); err != nil {
+		return nil, err
+	}
+
+	if err := config.ApplyEnvOverrides(); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
+	return config, nil
+}
+
+// loadFile decodes a config file over the current values; a missing file is not an error
+func (c *Config) loadFile(filename string) error {
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		return config, nil
+		return nil
 	}
 
 	file, err := os.Open(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %w", err)
+		return fmt.Errorf("failed to open config file: %w", err)
 	}
 	defer file.Close()
 
+	if isYAML(filename) {
+		if err := yaml.NewDecoder(file).Decode(c); err != nil {
+			return fmt.Errorf("failed to decode config file: %w", err)
+		}
+		return nil
+	}
+
 	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(config); err != nil {
-		return nil, fmt.Errorf("failed to decode config file: %w", err)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("failed to decode config file: %w", err)
 	}
 
-	return config, nil
+	return nil
 }
 
-// SaveConfig saves configuration to a JSON file
+// SaveConfig saves configuration to a JSON or YAML file, chosen by the file extension
 func (c *Config) SaveConfig(filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -151,6 +328,16 @@ func (c *Config) SaveConfig(filename string) error {
 	}
 	defer file.Close()
 
+	if isYAML(filename) {
+		encoder := yaml.NewEncoder(file)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(c); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		return encoder.Close()
+	}
+
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
 
@@ -161,6 +348,12 @@ func (c *Config) SaveConfig(filename string) error {
 	return nil
 }
 
+// isYAML reports whether a config file name has a YAML extension
+func isYAML(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".yaml" || ext == ".yml"
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if c.Database.SupabaseURL == "" {
@@ -171,6 +364,22 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("supabase key is required")
 	}
 
+	if c.Database.Table == "" {
+		return fmt.Errorf("database table is required")
+	}
+
+	if c.Database.RetentionPeriod.Duration < 0 {
+		return fmt.Errorf("retention period cannot be negative, got %v", c.Database.RetentionPeriod)
+	}
+
+	if c.Monitoring.MaxLogSizeMB < 0 || c.Monitoring.MaxLogBackups < 0 || c.Monitoring.MaxLogAgeDays < 0 {
+		return fmt.Errorf("log rotation limits cannot be negative")
+	}
+
+	if c.Server.ShutdownTimeout.Duration <= 0 {
+		return fmt.Errorf("shutdown timeout must be positive, got %v", c.Server.ShutdownTimeout)
+	}
+
 	if c.Scraper.ConcurrentSources <= 0 {
 		return fmt.Errorf("concurrent sources must be positive")
 	}
@@ -179,14 +388,120 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("batch size must be positive")
 	}
 
+	if c.Scraper.ConcurrentSaves <= 0 {
+		return fmt.Errorf("concurrent saves must be positive")
+	}
+
+	if c.Scraper.SimilarityThreshold < 0 || c.Scraper.SimilarityThreshold >= 1 {
+		return fmt.Errorf("similarity threshold must be between 0 and 1 (0 disables near-duplicate suppression), got %v", c.Scraper.SimilarityThreshold)
+	}
+
 	if c.Scraper.RetryAttempts < 0 {
 		return fmt.Errorf("retry attempts cannot be negative")
 	}
 
+	if c.Scraper.SaveRetryAttempts < 0 {
+		return fmt.Errorf("save retry attempts cannot be negative")
+	}
+
+	if c.Scraper.ScrapingInterval.Duration < 0 {
+		return fmt.Errorf("scraping interval cannot be negative, got %v", c.Scraper.ScrapingInterval)
+	}
+
+	if !models.ValidValidationLevel(c.Scraper.Validation) {
+		return fmt.Errorf("invalid validation level %q, expected off, basic or strict", c.Scraper.Validation)
+	}
+
+	if c.Scraper.MaxResponseBytes < 0 {
+		return fmt.Errorf("max response bytes cannot be negative")
+	}
+
+	if c.Scraper.Transport.MaxIdleConns < 0 || c.Scraper.Transport.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("transport idle connection limits cannot be negative")
+	}
+
+	if c.Scraper.RequestTimeout.Duration <= 0 {
+		return fmt.Errorf("request timeout must be positive, got %v", c.Scraper.RequestTimeout)
+	}
+
+	if c.Scraper.ScrapeTimeout.Duration < 0 {
+		return fmt.Errorf("scrape timeout cannot be negative, got %v", c.Scraper.ScrapeTimeout)
+	}
+
+	sources := []struct {
+		name   string
+		config SourceConfig
+	}{
+		{"remoteok", c.Sources.RemoteOK},
+		{"remotive", c.Sources.Remotive},
+		{"wework_remotely", c.Sources.WeWorkRemotely},
+		{"indeed", c.Sources.Indeed},
+	}
+
 	// Validate at least one source is enabled
-	hasEnabledSource := c.Sources.RemoteOK.Enabled ||
-		c.Sources.Remotive.Enabled ||
-		c.Sources.WeWorkRemotely.Enabled
+	hasEnabledSource := false
+	for _, entry := range sources {
+		name, source := entry.name, entry.config
+		if !source.Enabled {
+			continue
+		}
+		hasEnabledSource = true
+
+		if source.RateLimit <= 0 {
+			return fmt.Errorf("source %s: rate limit must be positive, got %d", name, source.RateLimit)
+		}
+		if source.RateWindow.Duration < 0 {
+			return fmt.Errorf("source %s: rate window cannot be negative, got %v", name, source.RateWindow)
+		}
+		if source.Burst < 0 {
+			return fmt.Errorf("source %s: burst cannot be negative, got %d", name, source.Burst)
+		}
+		if source.Timeout.Duration < 0 {
+			return fmt.Errorf("source %s: timeout cannot be negative, got %v", name, source.Timeout)
+		}
+		if source.RequestDelay.Duration < 0 {
+			return fmt.Errorf("source %s: request delay cannot be negative, got %v", name, source.RequestDelay)
+		}
+		if source.MinExpectedJobs < 0 {
+			return fmt.Errorf("source %s: min expected jobs cannot be negative, got %d", name, source.MinExpectedJobs)
+		}
+		if source.Auth.BearerToken != "" && source.Auth.Header != "" {
+			return fmt.Errorf("source %s: auth accepts a header or a bearer token, not both", name)
+		}
+		if source.Auth.Header != "" && source.Auth.Value == "" {
+			return fmt.Errorf("source %s: auth header %s has no value", name, source.Auth.Header)
+		}
+		if source.Auth.Header == "" && source.Auth.Value != "" {
+			return fmt.Errorf("source %s: auth value requires a header name", name)
+		}
+	}
+
+	feedNames := make(map[string]bool)
+	for i, feed := range c.Sources.Feeds {
+		if feed.Name == "" {
+			return fmt.Errorf("feed %d: name is required", i)
+		}
+		if feed.URL == "" {
+			return fmt.Errorf("feed %s: url is required", feed.Name)
+		}
+		if feedNames[strings.ToLower(feed.Name)] {
+			return fmt.Errorf("feed %s: duplicate feed name", feed.Name)
+		}
+		feedNames[strings.ToLower(feed.Name)] = true
+
+		if feed.RateLimit < 0 {
+			return fmt.Errorf("feed %s: rate limit cannot be negative, got %d", feed.Name, feed.RateLimit)
+		}
+		if feed.Burst < 0 {
+			return fmt.Errorf("feed %s: burst cannot be negative, got %d", feed.Name, feed.Burst)
+		}
+		if feed.Timeout.Duration < 0 {
+			return fmt.Errorf("feed %s: timeout cannot be negative, got %v", feed.Name, feed.Timeout)
+		}
+		if feed.Enabled {
+			hasEnabledSource = true
+		}
+	}
 
 	if !hasEnabledSource {
 		return fmt.Errorf("at least one job source must be enabled")
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigYAMLMatchesJSON(t *testing.T) {
+	fromJSON, err := LoadConfig(filepath.Join("testdata", "config.json"))
+	if err != nil {
+		t.Fatalf("LoadConfig(config.json): %v", err)
+	}
+	fromYAML, err := LoadConfig(filepath.Join("testdata", "config.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig(config.yaml): %v", err)
+	}
+
+	if !reflect.DeepEqual(fromJSON, fromYAML) {
+		t.Errorf("YAML config = %+v, want the same as JSON %+v", fromYAML, fromJSON)
+	}
+
+	// The files override some defaults and leave the rest alone
+	if fromYAML.Server.Port != 9090 || fromYAML.Server.ReadTimeout.Duration != 15*time.Second || fromYAML.Server.IdleTimeout.Duration != 2*time.Minute {
+		t.Errorf("server = %+v, want port 9090 with 15s read and 2m idle timeouts", fromYAML.Server)
+	}
+	if fromYAML.Server.WriteTimeout != DefaultConfig().Server.WriteTimeout {
+		t.Errorf("write timeout = %v, want the default", fromYAML.Server.WriteTimeout)
+	}
+	if fromYAML.Scraper.ScrapingInterval.Duration != 30*time.Minute || fromYAML.Scraper.RetryDelay.Duration != 500*time.Millisecond {
+		t.Errorf("scraper intervals = %v and %v, want 30m and 500ms", fromYAML.Scraper.ScrapingInterval, fromYAML.Scraper.RetryDelay)
+	}
+	if want := []string{"Remotive", "RemoteOK"}; !reflect.DeepEqual(fromYAML.Scraper.SourcePriority, want) {
+		t.Errorf("source priority = %q, want %q", fromYAML.Scraper.SourcePriority, want)
+	}
+	if fromYAML.Sources.RemoteOK.Enabled || !reflect.DeepEqual(fromYAML.Sources.RemoteOK.SearchTerms, []string{"rust", "go"}) {
+		t.Errorf("remoteok = %+v, want disabled with search terms rust and go", fromYAML.Sources.RemoteOK)
+	}
+	if len(fromYAML.Sources.Feeds) != 1 || fromYAML.Sources.Feeds[0].Fields.Company != "author" || fromYAML.Sources.Feeds[0].Timeout.Duration != 20*time.Second {
+		t.Errorf("feeds = %+v, want the Company Careers feed", fromYAML.Sources.Feeds)
+	}
+	if fromYAML.Notifications.Type != "slack" || fromYAML.Notifications.PostInterval.Duration != 2*time.Second {
+		t.Errorf("notifications = %+v, want slack posting every 2s", fromYAML.Notifications)
+	}
+}
+
+func TestSaveConfigRoundTrips(t *testing.T) {
+	original, err := LoadConfig(filepath.Join("testdata", "config.json"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	for _, name := range []string{"saved.json", "saved.yaml", "saved.yml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := original.SaveConfig(path); err != nil {
+				t.Fatalf("SaveConfig: %v", err)
+			}
+
+			loaded, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			if !reflect.DeepEqual(loaded.Scraper, original.Scraper) || !reflect.DeepEqual(loaded.Sources.Feeds, original.Sources.Feeds) || loaded.Server != original.Server {
+				t.Errorf("loaded %+v, want %+v", loaded, original)
+			}
+		})
+	}
+}
+
+func TestLoadConfigMissingFileUsesDefaults(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("LoadConfig of a missing file = %+v, want the defaults", cfg)
+	}
+}
+
+// validConfig returns the defaults with the required database credentials set
+func validConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Database.SupabaseURL = "https://example.supabase.co"
+	cfg.Database.SupabaseKey = "secret"
+	return cfg
+}
+
+// validateTest is a change to a valid config and the error it should cause
+type validateTest struct {
+	name    string
+	modify  func(*Config)
+	wantErr string
+}
+
+// runValidateTests checks Validate reports wantErr, or succeeds when it is empty
+func runValidateTests(t *testing.T, tests []validateTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRateLimitsAndIntervals(t *testing.T) {
+	runValidateTests(t, []validateTest{
+		{"defaults", func(c *Config) {}, ""},
+		{"zero rate limit", func(c *Config) { c.Sources.RemoteOK.RateLimit = 0 }, "source remoteok: rate limit must be positive, got 0"},
+		{"negative rate limit", func(c *Config) { c.Sources.Remotive.RateLimit = -5 }, "source remotive: rate limit must be positive, got -5"},
+		{"zero rate limit on disabled source", func(c *Config) { c.Sources.Indeed.RateLimit = 0 }, ""},
+		{"negative scraping interval", func(c *Config) { c.Scraper.ScrapingInterval.Duration = -time.Minute }, "scraping interval cannot be negative"},
+		{"zero scraping interval", func(c *Config) { c.Scraper.ScrapingInterval.Duration = 0 }, ""},
+		{"zero request timeout", func(c *Config) { c.Scraper.RequestTimeout.Duration = 0 }, "request timeout must be positive"},
+		{"negative request timeout", func(c *Config) { c.Scraper.RequestTimeout.Duration = -time.Second }, "request timeout must be positive"},
+	})
+}
+
+func TestValidateScrapeTimeout(t *testing.T) {
+	if timeout := DefaultConfig().Scraper.ScrapeTimeout.Duration; timeout != 5*time.Minute {
+		t.Errorf("default scrape timeout = %v, want 5m", timeout)
+	}
+	runValidateTests(t, []validateTest{
+		{"unset", func(c *Config) { c.Scraper.ScrapeTimeout.Duration = 0 }, ""},
+		{"positive", func(c *Config) { c.Scraper.ScrapeTimeout.Duration = 15 * time.Minute }, ""},
+		{"negative", func(c *Config) { c.Scraper.ScrapeTimeout.Duration = -time.Minute }, "scrape timeout cannot be negative"},
+	})
+}
+
+func TestValidateRetentionPeriod(t *testing.T) {
+	runValidateTests(t, []validateTest{
+		{"unset", func(c *Config) { c.Database.RetentionPeriod.Duration = 0 }, ""},
+		{"positive", func(c *Config) { c.Database.RetentionPeriod.Duration = 30 * 24 * time.Hour }, ""},
+		{"negative", func(c *Config) { c.Database.RetentionPeriod.Duration = -time.Hour }, "retention period cannot be negative"},
+	})
+}
+
+func TestValidateSourceTimeouts(t *testing.T) {
+	runValidateTests(t, []validateTest{
+		{"unset", func(c *Config) { c.Sources.RemoteOK.Timeout.Duration = 0 }, ""},
+		{"positive", func(c *Config) { c.Sources.RemoteOK.Timeout.Duration = 10 * time.Second }, ""},
+		{"negative", func(c *Config) { c.Sources.Remotive.Timeout.Duration = -time.Second }, "source remotive: timeout cannot be negative"},
+		{"negative feed", func(c *Config) {
+			c.Sources.Feeds = []FeedConfig{{Name: "Careers", URL: "https://example.com/feed", Enabled: true, Timeout: Duration{-time.Second}}}
+		}, "feed Careers: timeout cannot be negative"},
+	})
+}
+
+func TestValidateValidationLevel(t *testing.T) {
+	runValidateTests(t, []validateTest{
+		{"off", func(c *Config) { c.Scraper.Validation = "off" }, ""},
+		{"strict", func(c *Config) { c.Scraper.Validation = "strict" }, ""},
+		{"unknown", func(c *Config) { c.Scraper.Validation = "lenient" }, `invalid validation level "lenient"`},
+	})
+}
+
+func TestValidateDatabaseTable(t *testing.T) {
+	if table := DefaultConfig().Database.Table; table != "jobs" {
+		t.Errorf("default table = %q, want jobs", table)
+	}
+	runValidateTests(t, []validateTest{
+		{"custom", func(c *Config) { c.Database.Table = "jobs_staging" }, ""},
+		{"empty", func(c *Config) { c.Database.Table = "" }, "database table is required"},
+	})
+}
+
+func TestValidateBurst(t *testing.T) {
+	runValidateTests(t, []validateTest{
+		{"unset", func(c *Config) { c.Sources.RemoteOK.Burst = 0 }, ""},
+		{"positive", func(c *Config) { c.Sources.RemoteOK.Burst = 5 }, ""},
+		{"negative", func(c *Config) { c.Sources.RemoteOK.Burst = -1 }, "source remoteok: burst cannot be negative, got -1"},
+		{"negative feed", func(c *Config) {
+			c.Sources.Feeds = []FeedConfig{{Name: "Careers", URL: "https://example.com/feed", Enabled: true, Burst: -1}}
+		}, "feed Careers: burst cannot be negative, got -1"},
+	})
+}
+
+func TestAuthConfigHeaderValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		auth      AuthConfig
+		wantName  string
+		wantValue string
+	}{
+		{name: "none"},
+		{name: "api key", auth: AuthConfig{Header: "X-Api-Key", Value: "key-123"}, wantName: "X-Api-Key", wantValue: "key-123"},
+		{name: "bearer token", auth: AuthConfig{BearerToken: "token-456"}, wantName: "Authorization", wantValue: "Bearer token-456"},
+		{name: "bearer token wins", auth: AuthConfig{Header: "X-Api-Key", Value: "key-123", BearerToken: "token-456"}, wantName: "Authorization", wantValue: "Bearer token-456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, value := tt.auth.HeaderValue()
+			if name != tt.wantName || value != tt.wantValue {
+				t.Errorf("HeaderValue = %q, %q, want %q, %q", name, value, tt.wantName, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		secret string
+		want   string
+	}{
+		{"", "***"},
+		{"short", "***"},
+		{"12345678", "***"},
+		{"sk-live-abcdef123456", "sk-l***3456"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskSecret(tt.secret); got != tt.want {
+			t.Errorf("MaskSecret(%q) = %q, want %q", tt.secret, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSimilarityThreshold(t *testing.T) {
+	runValidateTests(t, []validateTest{
+		{"default", func(c *Config) {}, ""},
+		{"disabled", func(c *Config) { c.Scraper.SimilarityThreshold = 0 }, ""},
+		{"lenient", func(c *Config) { c.Scraper.SimilarityThreshold = 0.5 }, ""},
+		{"negative", func(c *Config) { c.Scraper.SimilarityThreshold = -0.1 }, "similarity threshold must be between 0 and 1"},
+		{"one", func(c *Config) { c.Scraper.SimilarityThreshold = 1 }, "similarity threshold must be between 0 and 1"},
+	})
+}
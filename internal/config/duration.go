@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration is a time.Duration that is written as a human-readable string such
+// as "15m" or "30s". Raw nanosecond numbers are still accepted when reading for
+// backward compatibility with older configuration files.
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON writes the duration as a string like "15m0s"
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a duration string ("15m") or a number of nanoseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.set(value)
+}
+
+// MarshalYAML writes the duration as a string like "15m0s"
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML accepts a duration string ("15m") or a number of nanoseconds
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	return d.set(value)
+}
+
+// set parses a decoded string or numeric value into the duration
+func (d *Duration) set(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", v, err)
+		}
+		d.Duration = parsed
+	case float64:
+		d.Duration = time.Duration(v)
+	case int:
+		d.Duration = time.Duration(v)
+	default:
+		return fmt.Errorf("invalid duration %v: expected a string like \"15m\" or a number of nanoseconds", value)
+	}
+	return nil
+}
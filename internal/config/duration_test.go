@@ -0,0 +1,81 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDurationUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		yaml    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "minutes", json: `"15m"`, yaml: `15m`, want: 15 * time.Minute},
+		{name: "seconds", json: `"30s"`, yaml: `"30s"`, want: 30 * time.Second},
+		{name: "compound", json: `"2h30m"`, yaml: `2h30m`, want: 150 * time.Minute},
+		{name: "nanoseconds", json: `900000000000`, yaml: `900000000000`, want: 15 * time.Minute},
+		{name: "zero", json: `0`, yaml: `0`, want: 0},
+		{name: "missing unit", json: `"15"`, yaml: `"15"`, wantErr: true},
+		{name: "not a duration", json: `"soon"`, yaml: `soon`, wantErr: true},
+		{name: "wrong type", json: `true`, yaml: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromJSON Duration
+			err := json.Unmarshal([]byte(tt.json), &fromJSON)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JSON %s error = %v, want error %v", tt.json, err, tt.wantErr)
+			}
+			if fromJSON.Duration != tt.want {
+				t.Errorf("JSON %s = %v, want %v", tt.json, fromJSON.Duration, tt.want)
+			}
+
+			var fromYAML Duration
+			err = yaml.Unmarshal([]byte(tt.yaml), &fromYAML)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("YAML %s error = %v, want error %v", tt.yaml, err, tt.wantErr)
+			}
+			if fromYAML.Duration != tt.want {
+				t.Errorf("YAML %s = %v, want %v", tt.yaml, fromYAML.Duration, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationRoundTrip(t *testing.T) {
+	type wrapper struct {
+		Interval Duration `json:"interval" yaml:"interval"`
+	}
+
+	for _, d := range []time.Duration{0, 500 * time.Millisecond, 30 * time.Second, 15 * time.Minute, 26 * time.Hour} {
+		original := wrapper{Interval: Duration{d}}
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("json.Marshal(%v): %v", d, err)
+		}
+		if want := `{"interval":"` + d.String() + `"}`; string(data) != want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", d, data, want)
+		}
+		var fromJSON wrapper
+		if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != original {
+			t.Errorf("JSON round trip of %v = %v (%v), want %v", d, fromJSON.Interval, err, d)
+		}
+
+		data, err = yaml.Marshal(original)
+		if err != nil {
+			t.Fatalf("yaml.Marshal(%v): %v", d, err)
+		}
+		var fromYAML wrapper
+		if err := yaml.Unmarshal(data, &fromYAML); err != nil || fromYAML != original {
+			t.Errorf("YAML round trip of %v = %v (%v), want %v", d, fromYAML.Interval, err, d)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ApplyEnvOverrides overrides configuration values with environment variables.
+// Values from the environment take precedence over the config file. Sources use
+// the SOURCE_<NAME>_ prefix, e.g. SOURCE_REMOTIVE_ENABLED; lists are comma separated.
+func (c *Config) ApplyEnvOverrides() error {
+	env := &envReader{}
+
+	env.int("SERVER_PORT", &c.Server.Port)
+	env.duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	env.duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	env.duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	env.duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+
+	env.string("SUPABASE_URL", &c.Database.SupabaseURL)
+	env.string("SUPABASE_KEY", &c.Database.SupabaseKey)
+	env.string("DATABASE_TABLE", &c.Database.Table)
+	env.duration("DATABASE_RETENTION_PERIOD", &c.Database.RetentionPeriod)
+
+	env.int("SCRAPER_CONCURRENT_SOURCES", &c.Scraper.ConcurrentSources)
+	env.int("SCRAPER_BATCH_SIZE", &c.Scraper.BatchSize)
+	env.int("SCRAPER_CONCURRENT_SAVES", &c.Scraper.ConcurrentSaves)
+	env.int("SCRAPER_RETRY_ATTEMPTS", &c.Scraper.RetryAttempts)
+	env.int("SCRAPER_SAVE_RETRY_ATTEMPTS", &c.Scraper.SaveRetryAttempts)
+	env.duration("SCRAPER_RETRY_DELAY", &c.Scraper.RetryDelay)
+	env.duration("SCRAPER_MAX_RETRY_DELAY", &c.Scraper.MaxRetryDelay)
+	env.float("SCRAPER_BACKOFF_FACTOR", &c.Scraper.BackoffFactor)
+	env.bool("SCRAPER_RETRY_JITTER", &c.Scraper.RetryJitter)
+	env.duration("SCRAPER_INTERVAL", &c.Scraper.ScrapingInterval)
+	env.duration("SCRAPER_REQUEST_TIMEOUT", &c.Scraper.RequestTimeout)
+	env.duration("SCRAPER_SCRAPE_TIMEOUT", &c.Scraper.ScrapeTimeout)
+	env.int("SCRAPER_MAX_RESPONSE_BYTES", &c.Scraper.MaxResponseBytes)
+	env.string("SCRAPER_PROXY_URL", &c.Scraper.ProxyURL)
+	env.bool("SCRAPER_CONDITIONAL_FETCH", &c.Scraper.ConditionalFetch)
+	env.bool("SCRAPER_REFETCH_MALFORMED_JSON", &c.Scraper.RefetchMalformedJSON)
+	env.int("SCRAPER_TRANSPORT_MAX_IDLE_CONNS", &c.Scraper.Transport.MaxIdleConns)
+	env.int("SCRAPER_TRANSPORT_MAX_IDLE_CONNS_PER_HOST", &c.Scraper.Transport.MaxIdleConnsPerHost)
+	env.duration("SCRAPER_TRANSPORT_IDLE_CONN_TIMEOUT", &c.Scraper.Transport.IdleConnTimeout)
+	env.bool("SCRAPER_TRANSPORT_DISABLE_KEEP_ALIVES", &c.Scraper.Transport.DisableKeepAlives)
+	env.bool("SCRAPER_ENABLE_DEDUP", &c.Scraper.EnableDedup)
+	env.bool("SCRAPER_MERGE_DUPLICATES", &c.Scraper.MergeDuplicates)
+	env.float("SCRAPER_SIMILARITY_THRESHOLD", &c.Scraper.SimilarityThreshold)
+	env.bool("SCRAPER_SEED_DEDUP", &c.Scraper.SeedDedup)
+	env.string("SCRAPER_STATE_FILE", &c.Scraper.StateFile)
+	env.string("SCRAPER_VALIDATION", &c.Scraper.Validation)
+
+	env.source("REMOTEOK", &c.Sources.RemoteOK)
+	env.source("REMOTIVE", &c.Sources.Remotive)
+	env.source("WEWORK_REMOTELY", &c.Sources.WeWorkRemotely)
+	env.source("INDEED", &c.Sources.Indeed)
+
+	env.bool("MONITORING_ENABLED", &c.Monitoring.Enabled)
+	env.duration("MONITORING_METRICS_INTERVAL", &c.Monitoring.MetricsInterval)
+	env.string("MONITORING_LOG_LEVEL", &c.Monitoring.LogLevel)
+	env.string("MONITORING_LOG_FORMAT", &c.Monitoring.LogFormat)
+	env.string("MONITORING_LOG_FILE", &c.Monitoring.LogFile)
+	env.int("MONITORING_MAX_LOG_SIZE_MB", &c.Monitoring.MaxLogSizeMB)
+	env.int("MONITORING_MAX_LOG_BACKUPS", &c.Monitoring.MaxLogBackups)
+	env.int("MONITORING_MAX_LOG_AGE_DAYS", &c.Monitoring.MaxLogAgeDays)
+	env.bool("MONITORING_RESET_METRICS", &c.Monitoring.ResetMetrics)
+	env.string("MONITORING_METRICS_FILE", &c.Monitoring.MetricsFile)
+
+	env.bool("NOTIFICATIONS_ENABLED", &c.Notifications.Enabled)
+	env.string("NOTIFICATIONS_TYPE", &c.Notifications.Type)
+	env.string("NOTIFICATIONS_WEBHOOK_URL", &c.Notifications.WebhookURL)
+	env.int("NOTIFICATIONS_MAX_JOBS_PER_MESSAGE", &c.Notifications.MaxJobsPerMessage)
+	env.duration("NOTIFICATIONS_POST_INTERVAL", &c.Notifications.PostInterval)
+
+	return env.err
+}
+
+// envReader reads typed environment variables, keeping the first parse error
+type envReader struct {
+	err error
+}
+
+// lookup returns the value of a non-empty environment variable
+func (e *envReader) lookup(name string) (string, bool) {
+	if e.err != nil {
+		return "", false
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok || strings.TrimSpace(value) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+// fail records an invalid environment variable
+func (e *envReader) fail(name, value string, err error) {
+	e.err = fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+}
+
+func (e *envReader) source(name string, source *SourceConfig) {
+	prefix := "SOURCE_" + name + "_"
+	e.bool(prefix+"ENABLED", &source.Enabled)
+	e.int(prefix+"RATE_LIMIT", &source.RateLimit)
+	e.duration(prefix+"RATE_WINDOW", &source.RateWindow)
+	e.int(prefix+"BURST", &source.Burst)
+	e.duration(prefix+"TIMEOUT", &source.Timeout)
+	e.duration(prefix+"REQUEST_DELAY", &source.RequestDelay)
+	e.int(prefix+"MIN_EXPECTED_JOBS", &source.MinExpectedJobs)
+	e.bool(prefix+"FAIL_BELOW_MIN_JOBS", &source.FailBelowMinJobs)
+	e.list(prefix+"SEARCH_TERMS", &source.SearchTerms)
+	e.string(prefix+"SEARCH_MODE", &source.SearchMode)
+	e.list(prefix+"LOCATIONS", &source.Locations)
+	e.list(prefix+"JOB_TYPES", &source.JobTypes)
+	e.bool(prefix+"PRESERVE_HTML", &source.PreserveHTML)
+	e.string(prefix+"AUTH_HEADER", &source.Auth.Header)
+	e.string(prefix+"AUTH_VALUE", &source.Auth.Value)
+	e.string(prefix+"BEARER_TOKEN", &source.Auth.BearerToken)
+}
+
+func (e *envReader) string(name string, target *string) {
+	if value, ok := e.lookup(name); ok {
+		*target = value
+	}
+}
+
+func (e *envReader) int(name string, target *int) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*target = parsed
+}
+
+func (e *envReader) float(name string, target *float64) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*target = parsed
+}
+
+func (e *envReader) bool(name string, target *bool) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*target = parsed
+}
+
+func (e *envReader) duration(name string, target *Duration) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	target.Duration = parsed
+}
+
+func (e *envReader) list(name string, target *[]string) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*target = items
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnvOverridesFileValues(t *testing.T) {
+	t.Setenv("SERVER_PORT", "7070")
+	t.Setenv("SCRAPER_CONCURRENT_SOURCES", "8")
+	t.Setenv("SCRAPER_INTERVAL", "45m")
+	t.Setenv("SCRAPER_SCRAPE_TIMEOUT", "20m")
+	t.Setenv("SCRAPER_SIMILARITY_THRESHOLD", "0.75")
+	t.Setenv("MONITORING_LOG_LEVEL", "warn")
+	t.Setenv("SOURCE_REMOTEOK_ENABLED", "true")
+	t.Setenv("SOURCE_REMOTEOK_SEARCH_TERMS", "python, , data ")
+	t.Setenv("SOURCE_REMOTIVE_ENABLED", "false")
+	t.Setenv("NOTIFICATIONS_TYPE", "webhook")
+	t.Setenv("SCRAPER_RETRY_ATTEMPTS", "  ") // blank values are ignored
+
+	cfg, err := LoadConfig(filepath.Join("testdata", "config.json"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"server port", cfg.Server.Port, 7070},
+		{"concurrent sources", cfg.Scraper.ConcurrentSources, 8},
+		{"scraping interval", cfg.Scraper.ScrapingInterval.Duration, 45 * time.Minute},
+		{"scrape timeout", cfg.Scraper.ScrapeTimeout.Duration, 20 * time.Minute},
+		{"similarity threshold", cfg.Scraper.SimilarityThreshold, 0.75},
+		{"log level", cfg.Monitoring.LogLevel, "warn"},
+		{"remoteok enabled", cfg.Sources.RemoteOK.Enabled, true},
+		{"remoteok search terms", cfg.Sources.RemoteOK.SearchTerms, []string{"python", "data"}},
+		{"remotive enabled", cfg.Sources.Remotive.Enabled, false},
+		{"notification type", cfg.Notifications.Type, "webhook"},
+		// Values without an environment variable keep the file's value
+		{"retry attempts", cfg.Scraper.RetryAttempts, 5},
+		{"remoteok rate limit", cfg.Sources.RemoteOK.RateLimit, 20},
+		{"metrics interval", cfg.Monitoring.MetricsInterval.Duration, 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestEnvOverridesRejectInvalidValues(t *testing.T) {
+	tests := []struct {
+		name, value string
+	}{
+		{"SCRAPER_CONCURRENT_SOURCES", "many"},
+		{"SCRAPER_INTERVAL", "15"},
+		{"SCRAPER_BACKOFF_FACTOR", "double"},
+		{"SOURCE_REMOTIVE_ENABLED", "maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.name, tt.value)
+
+			_, err := LoadConfig(filepath.Join("testdata", "config.json"))
+			if err == nil || !strings.Contains(err.Error(), tt.name) {
+				t.Errorf("LoadConfig with %s=%q error = %v, want an error naming the variable", tt.name, tt.value, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Level represents the severity of a log message
+type Level int
+
+// Log levels, from most to least verbose
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// slogLevel returns the log/slog level matching l
+func (l Level) slogLevel() slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// ParseLevel converts a level name ("debug", "info", "warn", "error") to a Level.
+// An empty name defaults to info.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// Format is the encoding of log lines
+type Format int
+
+// Log formats
+const (
+	FormatText Format = iota // "[LEVEL] message" lines, as written by log.Logger
+	FormatJSON               // one JSON object per line, for log aggregators
+)
+
+// ParseFormat converts a format name ("text", "json") to a Format. An empty
+// name defaults to text.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return FormatText, fmt.Errorf("unknown log format %q", name)
+	}
+}
+
+// Logger wraps log.Logger and drops messages below the configured level
+type Logger struct {
+	out   *log.Logger
+	json  slog.Handler // writes JSON lines instead of out, if set
+	level Level
+}
+
+// New creates a leveled logger writing to out with the given log.Logger prefix and flags
+func New(out io.Writer, prefix string, flag int, level Level) *Logger {
+	return &Logger{
+		out:   log.New(out, prefix, flag),
+		level: level,
+	}
+}
+
+// NewWithFormat creates a leveled logger writing lines in the given format.
+// JSON lines hold the time, level and message, and the source file and line
+// when flag includes log.Lshortfile or log.Llongfile; the prefix is only used
+// by the text format.
+func NewWithFormat(out io.Writer, prefix string, flag int, level Level, format Format) *Logger {
+	logger := New(out, prefix, flag, level)
+	if format == FormatJSON {
+		logger.json = slog.NewJSONHandler(out, &slog.HandlerOptions{
+			AddSource: flag&(log.Lshortfile|log.Llongfile) != 0,
+			Level:     slog.LevelDebug, // levels are filtered by logf
+		})
+	}
+	return logger
+}
+
+// SetLevel changes the minimum level that will be logged
+func (l *Logger) SetLevel(level Level) {
+	l.level = level
+}
+
+// Enabled reports whether messages at level would be logged
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+// Debugf logs a debug message
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.logf(LevelDebug, format, v...)
+}
+
+// Infof logs an informational message
+func (l *Logger) Infof(format string, v ...interface{}) {
+	l.logf(LevelInfo, format, v...)
+}
+
+// Warnf logs a warning
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.logf(LevelWarn, format, v...)
+}
+
+// Errorf logs an error
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	l.logf(LevelError, format, v...)
+}
+
+// Printf logs an informational message, matching log.Logger.Printf
+func (l *Logger) Printf(format string, v ...interface{}) {
+	l.logf(LevelInfo, format, v...)
+}
+
+// Println logs an informational message, matching log.Logger.Println
+func (l *Logger) Println(v ...interface{}) {
+	l.logf(LevelInfo, "%s", strings.TrimSuffix(fmt.Sprintln(v...), "\n"))
+}
+
+// Fatalf logs an error and exits the process
+func (l *Logger) Fatalf(format string, v ...interface{}) {
+	l.logf(LevelError, format, v...)
+	os.Exit(1)
+}
+
+// logf formats and writes a message if its level is enabled
+func (l *Logger) logf(level Level, format string, v ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	if l.json != nil {
+		// Skip runtime.Callers, logf and the exported logging method
+		var pcs [1]uintptr
+		runtime.Callers(3, pcs[:])
+
+		record := slog.NewRecord(time.Now(), level.slogLevel(), fmt.Sprintf(format, v...), pcs[0])
+		l.json.Handle(context.Background(), record)
+		return
+	}
+
+	// Calldepth 3 reports the caller of the exported logging method
+	l.out.Output(3, fmt.Sprintf("[%s] ", level)+fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLoggerSuppressesMessagesBelowLevel(t *testing.T) {
+	level, err := ParseLevel("error")
+	if err != nil {
+		t.Fatalf("ParseLevel: %v", err)
+	}
+
+	var buf bytes.Buffer
+	logger := New(&buf, "", 0, level)
+	logger.Debugf("debug message")
+	logger.Infof("info message")
+	logger.Printf("printf message")
+	logger.Warnf("warn message")
+	logger.Errorf("error message %d", 42)
+
+	if got, want := buf.String(), "[ERROR] error message 42\n"; got != want {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+}
+
+func TestLoggerLevels(t *testing.T) {
+	tests := []struct {
+		level string
+		want  []string
+	}{
+		{"debug", []string{"DEBUG", "INFO", "WARN", "ERROR"}},
+		{"info", []string{"INFO", "WARN", "ERROR"}},
+		{"", []string{"INFO", "WARN", "ERROR"}},
+		{"warning", []string{"WARN", "ERROR"}},
+		{"ERROR", []string{"ERROR"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLevel(tt.level)
+			if err != nil {
+				t.Fatalf("ParseLevel(%q): %v", tt.level, err)
+			}
+
+			var buf bytes.Buffer
+			logger := New(&buf, "", 0, level)
+			logger.Debugf("message")
+			logger.Infof("message")
+			logger.Warnf("message")
+			logger.Errorf("message")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("logged %q, want levels %v", lines, tt.want)
+			}
+			for i, line := range lines {
+				if want := "[" + tt.want[i] + "] message"; line != want {
+					t.Errorf("line %d = %q, want %q", i, line, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLevelRejectsUnknownNames(t *testing.T) {
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) succeeded, want an error")
+	}
+}
+
+func TestJSONFormatWritesOneObjectPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithFormat(&buf, "[SCRAPER] ", log.LstdFlags|log.Lshortfile, LevelInfo, FormatJSON)
+	logger.Debugf("hidden")
+	logger.Infof("scraped %d jobs", 12)
+	logger.Errorf("save failed")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2: %q", len(lines), buf.String())
+	}
+
+	want := []struct{ level, msg string }{
+		{"INFO", "scraped 12 jobs"},
+		{"ERROR", "save failed"},
+	}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %d is not JSON: %v: %q", i, err, line)
+		}
+		if entry["level"] != want[i].level || entry["msg"] != want[i].msg {
+			t.Errorf("line %d: level %v, msg %v, want %s, %s", i, entry["level"], entry["msg"], want[i].level, want[i].msg)
+		}
+		if _, ok := entry["time"]; !ok {
+			t.Errorf("line %d has no time: %q", i, line)
+		}
+		source, _ := entry["source"].(map[string]interface{})
+		if file, _ := source["file"].(string); !strings.HasSuffix(file, "logger_test.go") {
+			t.Errorf("line %d source = %v, want the caller in logger_test.go", i, entry["source"])
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{name: "", want: FormatText},
+		{name: "text", want: FormatText},
+		{name: " JSON ", want: FormatJSON},
+		{name: "xml", want: FormatText, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFormat(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
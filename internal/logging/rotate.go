@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat names rotated files so that they sort by age
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile appends to a log file and, once a write would grow it past the
+// size limit, renames it to a timestamped backup such as
+// scraper-2024-05-01T12-00-00.000.log and starts a new file. Old backups are
+// removed by count and age. It is safe for concurrent use.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFile opens path for appending, creating its directory if needed.
+// A maxSizeMB of zero never rotates, and zero maxBackups or maxAgeDays keep
+// backups regardless of their count or age.
+func NewRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	rf := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) << 20,
+		maxBackups: maxBackups,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Write appends p to the log file, rotating it first if p would not fit
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.file == nil {
+		return nil
+	}
+	err := rf.file.Close()
+	rf.file = nil
+	return err
+}
+
+// open opens the log file for appending and records its current size
+func (rf *RotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file to a backup, opens a new one and removes
+// expired backups
+func (rf *RotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	rf.file = nil
+
+	if err := os.Rename(rf.path, rf.backupName(time.Now())); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := rf.open(); err != nil {
+		return err
+	}
+
+	return rf.removeOldBackups(time.Now())
+}
+
+// backupName returns the backup path for a rotation at t
+func (rf *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(rf.path)
+	return strings.TrimSuffix(rf.path, ext) + "-" + t.Format(backupTimeFormat) + ext
+}
+
+// removeOldBackups removes the backups beyond maxBackups and those older than maxAge
+func (rf *RotatingFile) removeOldBackups(now time.Time) error {
+	if rf.maxBackups <= 0 && rf.maxAge <= 0 {
+		return nil
+	}
+
+	ext := filepath.Ext(rf.path)
+	prefix := filepath.Base(strings.TrimSuffix(rf.path, ext)) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(rf.path))
+	if err != nil {
+		return fmt.Errorf("failed to list log backups: %w", err)
+	}
+
+	type backup struct {
+		name string
+		time time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		rotated, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue // not a backup of this file
+		}
+		backups = append(backups, backup{name, rotated})
+	}
+
+	// Newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+
+	for i, b := range backups {
+		expired := rf.maxAge > 0 && now.Sub(b.time) > rf.maxAge
+		if (rf.maxBackups > 0 && i >= rf.maxBackups) || expired {
+			if err := os.Remove(filepath.Join(filepath.Dir(rf.path), b.name)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove old log backup: %w", err)
+			}
+		}
+	}
+	return nil
+}
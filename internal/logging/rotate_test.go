@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// backups returns the names of the rotated backups in dir
+func backups(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "scraper-") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+func TestRotatingFileRotatesPastSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "scraper.log")
+
+	rf, err := NewRotatingFile(path, 1, 0, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	defer rf.Close()
+
+	chunk := bytes.Repeat([]byte("x"), 600<<10)
+	for i := 0; i < 2; i++ {
+		if _, err := rf.Write(chunk); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	rotated := backups(t, filepath.Dir(path))
+	if len(rotated) != 1 {
+		t.Fatalf("backups = %q, want one", rotated)
+	}
+	for _, name := range []string{rotated[0], filepath.Base(path)} {
+		info, err := os.Stat(filepath.Join(filepath.Dir(path), name))
+		if err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+		if info.Size() != int64(len(chunk)) {
+			t.Errorf("%s holds %d bytes, want %d", name, info.Size(), len(chunk))
+		}
+	}
+}
+
+func TestRotatingFileKeepsMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scraper.log")
+
+	rf, err := NewRotatingFile(path, 1, 2, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	defer rf.Close()
+
+	chunk := bytes.Repeat([]byte("x"), 600<<10)
+	for i := 0; i < 5; i++ {
+		if _, err := rf.Write(chunk); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		time.Sleep(2 * time.Millisecond) // backups are named to the millisecond
+	}
+
+	if rotated := backups(t, dir); len(rotated) != 2 {
+		t.Errorf("backups = %q, want the newest two", rotated)
+	}
+}
+
+func TestRotatingFileWorksWithLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scraper.log")
+	rf, err := NewRotatingFile(path, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+
+	logger := New(rf, "[SCRAPER] ", 0, LevelInfo)
+	logger.Infof("started")
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if got, want := string(data), "[SCRAPER] [INFO] started\n"; got != want {
+		t.Errorf("log file = %q, want %q", got, want)
+	}
+	if _, err := rf.Write([]byte("late")); err != os.ErrClosed {
+		t.Errorf("Write after Close error = %v, want os.ErrClosed", err)
+	}
+}
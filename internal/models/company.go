@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// companySuffixes are legal-form words dropped from the end of company names
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "plc": true, "gmbh": true, "ag": true,
+	"sa": true, "sas": true, "sarl": true, "srl": true, "bv": true, "nv": true, "ab": true,
+	"oy": true, "pty": true, "pte": true, "kk": true, "kg": true,
+}
+
+// NormalizeCompany reduces a company name to a comparable form: lowercased,
+// without punctuation or trailing legal suffixes such as Inc, LLC, Ltd or GmbH,
+// and with whitespace collapsed, so "Acme, Inc." and "ACME" both become "acme"
+func NormalizeCompany(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	})
+
+	// Join dotted abbreviations such as "l.l.c." into "llc"
+	var cleaned []string
+	for _, word := range words {
+		if word = strings.ReplaceAll(word, ".", ""); word != "" {
+			cleaned = append(cleaned, word)
+		}
+	}
+
+	// Keep at least one word so a company named e.g. "Co" still has a name
+	for len(cleaned) > 1 && companySuffixes[cleaned[len(cleaned)-1]] {
+		cleaned = cleaned[:len(cleaned)-1]
+	}
+
+	return strings.Join(cleaned, " ")
+}
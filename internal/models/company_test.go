@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+func TestNormalizeCompany(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Acme", "acme"},
+		{"ACME", "acme"},
+		{"Acme, Inc.", "acme"},
+		{"Acme Inc", "acme"},
+		{"  Acme   L.L.C. ", "acme"},
+		{"Acme Corp.", "acme"},
+		{"Acme Holdings GmbH", "acme holdings"},
+		{"Acme Co. Ltd", "acme"},
+		{"Globex-Tech Ltd.", "globex tech"},
+		// A suffix alone is the name itself
+		{"Co", "co"},
+		// Suffixes are only dropped at the end
+		{"Inc Magazine", "inc magazine"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeCompany(tt.name); got != tt.want {
+			t.Errorf("NormalizeCompany(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
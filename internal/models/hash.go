@@ -0,0 +1,22 @@
+package models
+
+import (
+	"crypto/md5"
+	"fmt"
+	"strings"
+)
+
+// JobHash returns a deterministic identifier for a job based on its title,
+// normalized company and location, so the same posting hashes equally across runs and sources
+func JobHash(job Job) string {
+	// Normalize strings for better matching
+	title := strings.ToLower(strings.TrimSpace(job.Title))
+	company := NormalizeCompany(job.Company)
+	location := strings.ToLower(strings.TrimSpace(job.Location))
+
+	// Create composite key
+	key := fmt.Sprintf("%s|%s|%s", title, company, location)
+
+	hash := md5.Sum([]byte(key))
+	return fmt.Sprintf("%x", hash)
+}
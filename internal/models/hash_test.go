@@ -0,0 +1,33 @@
+package models
+
+import "testing"
+
+func TestJobHash(t *testing.T) {
+	base := Job{Title: "Go Developer", Company: "Acme Inc.", Location: "Remote", URL: "https://example.com/1", Source: "RemoteOK"}
+
+	tests := []struct {
+		name string
+		job  Job
+		same bool
+	}{
+		{"identical", base, true},
+		// Only the title, company and location identify a posting
+		{"other source and url", Job{Title: "Go Developer", Company: "Acme Inc.", Location: "Remote", URL: "https://example.com/2", Source: "Remotive"}, true},
+		{"case and spacing", Job{Title: "  go developer ", Company: "ACME", Location: "REMOTE"}, true},
+		{"other title", Job{Title: "Rust Developer", Company: "Acme Inc.", Location: "Remote"}, false},
+		{"other company", Job{Title: "Go Developer", Company: "Globex", Location: "Remote"}, false},
+		{"other location", Job{Title: "Go Developer", Company: "Acme Inc.", Location: "Berlin"}, false},
+	}
+
+	want := JobHash(base)
+	if len(want) != 32 {
+		t.Errorf("JobHash = %q, want 32 hex digits", want)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JobHash(tt.job); (got == want) != tt.same {
+				t.Errorf("JobHash = %s for %+v and %s for %+v, want same = %t", got, tt.job, want, base, tt.same)
+			}
+		})
+	}
+}
@@ -2,25 +2,130 @@ package models
 
 import "time"
 
+// Job is a job posting. Every field but ID is always encoded, even when
+// empty, so that the jobs of a batch insert have the same columns.
 type Job struct {
-	ID          int        `json:"id,omitempty"`
-	Title       string     `json:"title"`
-	Company     string     `json:"company"`
-	Location    string     `json:"location"`
-	URL         string     `json:"url"`
-	Description string     `json:"description,omitempty"`
-	Salary      string     `json:"salary,omitempty"`
-	PostedDate  *time.Time `json:"posted_date,omitempty"`
-	Source      string     `json:"source"`
-	JobCategory string     `json:"job_category,omitempty"`
-	JobType     string     `json:"job_type,omitempty"` // full-time, part-time, contract, freelance
-	ScrapedAt   time.Time  `json:"scraped_at"`
+	ID              int        `json:"id,omitempty"`
+	Hash            string     `json:"hash"` // stable identifier from JobHash
+	Title           string     `json:"title"`
+	Company         string     `json:"company"`
+	Location        string     `json:"location"`
+	URL             string     `json:"url"`
+	Description     string     `json:"description"`
+	Salary          string     `json:"salary"`
+	SalaryMin       int        `json:"salary_min"`
+	SalaryMax       int        `json:"salary_max"`
+	SalaryCurrency  string     `json:"salary_currency"`
+	SalaryPeriod    string     `json:"salary_period"` // hour, day, week, month, year
+	PostedDate      *time.Time `json:"posted_date"`
+	Source          string     `json:"source"`
+	JobCategory     string     `json:"job_category"`
+	JobType         string     `json:"job_type"`         // full-time, part-time, contract, freelance
+	WorkMode        string     `json:"work_mode"`        // remote, hybrid, onsite
+	ExperienceLevel string     `json:"experience_level"` // intern, junior, mid, senior, lead
+	Tags            []string   `json:"tags"`             // stored as a JSON array column
+	ScrapedAt       time.Time  `json:"scraped_at"`
 }
 
 // JobType constants (renamed from ContractType)
 const (
-	JobTypeFullTime  = "full-time"
-	JobTypePartTime  = "part-time"
-	JobTypeContract  = "contract"
-	JobTypeFreelance = "freelance"
+	JobTypeFullTime   = "full-time"
+	JobTypePartTime   = "part-time"
+	JobTypeContract   = "contract"
+	JobTypeFreelance  = "freelance"
+	JobTypeInternship = "internship"
 )
+
+// WorkMode constants
+const (
+	WorkModeRemote = "remote"
+	WorkModeHybrid = "hybrid"
+	WorkModeOnsite = "onsite"
+)
+
+// ExperienceLevel constants
+const (
+	ExperienceIntern = "intern"
+	ExperienceJunior = "junior"
+	ExperienceMid    = "mid"
+	ExperienceSenior = "senior"
+	ExperienceLead   = "lead"
+)
+
+// ValidJobType reports whether s is one of the standardized job types
+func ValidJobType(s string) bool {
+	switch s {
+	case JobTypeFullTime, JobTypePartTime, JobTypeContract, JobTypeFreelance, JobTypeInternship:
+		return true
+	}
+	return false
+}
+
+// Equal reports whether j and other hold the same posting details. ID and
+// ScrapedAt are ignored, since they record when and where a copy was stored.
+func (j Job) Equal(other Job) bool {
+	if j.Hash != other.Hash || j.Title != other.Title || j.Company != other.Company ||
+		j.Location != other.Location || j.URL != other.URL || j.Description != other.Description ||
+		j.Source != other.Source || j.JobCategory != other.JobCategory || j.JobType != other.JobType ||
+		j.WorkMode != other.WorkMode || j.ExperienceLevel != other.ExperienceLevel {
+		return false
+	}
+	if j.Salary != other.Salary || j.SalaryMin != other.SalaryMin || j.SalaryMax != other.SalaryMax ||
+		j.SalaryCurrency != other.SalaryCurrency || j.SalaryPeriod != other.SalaryPeriod {
+		return false
+	}
+	if !timesEqual(j.PostedDate, other.PostedDate) || len(j.Tags) != len(other.Tags) {
+		return false
+	}
+	for i := range j.Tags {
+		if j.Tags[i] != other.Tags[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge returns j with its empty fields filled from other, a duplicate of the
+// same posting, e.g. the salary from one source and the description from
+// another. The salary fields are taken together, and only when j has no
+// salary at all. The fields identifying the posting are kept from j.
+func (j Job) Merge(other Job) Job {
+	if j.Salary == "" && j.SalaryMin == 0 && j.SalaryMax == 0 {
+		j.Salary = other.Salary
+		j.SalaryMin = other.SalaryMin
+		j.SalaryMax = other.SalaryMax
+		j.SalaryCurrency = other.SalaryCurrency
+		j.SalaryPeriod = other.SalaryPeriod
+	}
+
+	fillString(&j.Company, other.Company)
+	fillString(&j.Location, other.Location)
+	fillString(&j.Description, other.Description)
+	fillString(&j.JobCategory, other.JobCategory)
+	fillString(&j.JobType, other.JobType)
+	fillString(&j.WorkMode, other.WorkMode)
+	fillString(&j.ExperienceLevel, other.ExperienceLevel)
+
+	if j.PostedDate == nil {
+		j.PostedDate = other.PostedDate
+	}
+	if len(j.Tags) == 0 {
+		j.Tags = other.Tags
+	}
+	return j
+}
+
+// fillString sets an empty field to value
+func fillString(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// timesEqual reports whether two optional times are both unset or the same instant
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
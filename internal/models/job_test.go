@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidJobType(t *testing.T) {
+	tests := []struct {
+		jobType string
+		want    bool
+	}{
+		{JobTypeFullTime, true},
+		{JobTypePartTime, true},
+		{JobTypeContract, true},
+		{JobTypeFreelance, true},
+		{JobTypeInternship, true},
+		{"internship", true},
+		{"", false},
+		{"Internship", false},
+		{"full_time", false},
+		{"temporary", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidJobType(tt.jobType); got != tt.want {
+			t.Errorf("ValidJobType(%q) = %t, want %t", tt.jobType, got, tt.want)
+		}
+	}
+}
+
+func TestJobEncodesEmptyFields(t *testing.T) {
+	data, err := json.Marshal(Job{Title: "Go Developer"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for _, key := range []string{"description", "salary", "job_category", "salary_currency"} {
+		value, ok := fields[key]
+		if !ok {
+			t.Errorf("%s is missing from %s", key, data)
+			continue
+		}
+		if value != "" {
+			t.Errorf("%s = %q, want an empty string", key, value)
+		}
+	}
+	if _, ok := fields["id"]; ok {
+		t.Errorf("id is encoded in %s, want it omitted when zero", data)
+	}
+}
+
+func TestJobMerge(t *testing.T) {
+	posted := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	remoteOK := Job{
+		Title:          "Go Developer",
+		Company:        "Acme",
+		URL:            "https://remoteok.com/1",
+		Source:         "RemoteOK",
+		Salary:         "$90k - $120k",
+		SalaryMin:      90000,
+		SalaryMax:      120000,
+		SalaryCurrency: "USD",
+		SalaryPeriod:   "year",
+		Tags:           []string{"go"},
+	}
+	remotive := Job{
+		Title:          "Go Developer",
+		Company:        "Acme Inc",
+		Location:       "Europe",
+		URL:            "https://remotive.com/1",
+		Source:         "Remotive",
+		Description:    "Build APIs",
+		JobCategory:    "Software Development",
+		PostedDate:     &posted,
+		Salary:         "€80k",
+		SalaryMin:      80000,
+		SalaryMax:      80000,
+		SalaryCurrency: "EUR",
+		SalaryPeriod:   "year",
+		Tags:           []string{"golang", "backend"},
+	}
+
+	want := remoteOK
+	want.Location = "Europe"
+	want.Description = "Build APIs"
+	want.JobCategory = "Software Development"
+	want.PostedDate = &posted
+
+	if got := remoteOK.Merge(remotive); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge = %+v, want %+v", got, want)
+	}
+
+	// Without a salary of its own, the job takes every salary field of the duplicate
+	noSalary := Job{Title: "Go Developer", Source: "Remotive"}
+	got := noSalary.Merge(remoteOK)
+	if got.Salary != remoteOK.Salary || got.SalaryMin != 90000 || got.SalaryMax != 120000 || got.SalaryCurrency != "USD" || got.SalaryPeriod != "year" {
+		t.Errorf("merged salary = %q %d-%d %s per %s, want the RemoteOK salary", got.Salary, got.SalaryMin, got.SalaryMax, got.SalaryCurrency, got.SalaryPeriod)
+	}
+	if got.Source != "Remotive" || got.URL != "" {
+		t.Errorf("merged Source = %q, URL = %q, want the job's own", got.Source, got.URL)
+	}
+}
+
+func TestJobEqual(t *testing.T) {
+	posted := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	samePosted := posted.In(time.FixedZone("CEST", 2*60*60))
+	job := Job{ID: 1, Title: "Go Developer", Company: "Acme", PostedDate: &posted, Tags: []string{"go"}, ScrapedAt: posted}
+
+	tests := []struct {
+		name  string
+		other Job
+		want  bool
+	}{
+		{"identical", job, true},
+		{"different ID and ScrapedAt", Job{ID: 2, Title: "Go Developer", Company: "Acme", PostedDate: &posted, Tags: []string{"go"}}, true},
+		{"same instant in another zone", Job{ID: 1, Title: "Go Developer", Company: "Acme", PostedDate: &samePosted, Tags: []string{"go"}, ScrapedAt: posted}, true},
+		{"different title", Job{ID: 1, Title: "Rust Developer", Company: "Acme", PostedDate: &posted, Tags: []string{"go"}}, false},
+		{"no posted date", Job{ID: 1, Title: "Go Developer", Company: "Acme", Tags: []string{"go"}}, false},
+		{"different tags", Job{ID: 1, Title: "Go Developer", Company: "Acme", PostedDate: &posted, Tags: []string{"golang"}}, false},
+		{"salary added", Job{ID: 1, Title: "Go Developer", Company: "Acme", PostedDate: &posted, Tags: []string{"go"}, SalaryMin: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := job.Equal(tt.other); got != tt.want {
+				t.Errorf("Equal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SalaryPeriod constants
+const (
+	SalaryPeriodHour  = "hour"
+	SalaryPeriodDay   = "day"
+	SalaryPeriodWeek  = "week"
+	SalaryPeriodMonth = "month"
+	SalaryPeriodYear  = "year"
+)
+
+// SalaryInfo holds salary information parsed from a free-text salary string
+type SalaryInfo struct {
+	Min      int
+	Max      int
+	Currency string
+	Period   string
+}
+
+// retirementPlanPattern matches mentions of US 401(k) plans, which look like amounts
+var retirementPlanPattern = regexp.MustCompile(`\b401\s?\(?k\)?`)
+
+// salaryAmountPattern matches lowercased amounts like "90k", "60,000", "45.5k" or "1.2m"
+var salaryAmountPattern = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s?([km])?\b`)
+
+// currencySymbols maps currency markers to ISO codes, longest markers first
+var currencySymbols = []struct {
+	marker string
+	code   string
+}{
+	{"ca$", "CAD"},
+	{"c$", "CAD"},
+	{"a$", "AUD"},
+	{"us$", "USD"},
+	{"usd", "USD"},
+	{"eur", "EUR"},
+	{"gbp", "GBP"},
+	{"cad", "CAD"},
+	{"aud", "AUD"},
+	{"chf", "CHF"},
+	{"inr", "INR"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"₹", "INR"},
+	{"$", "USD"},
+}
+
+// salaryPeriods maps period markers to salary periods
+var salaryPeriods = []struct {
+	markers []string
+	period  string
+}{
+	{[]string{"per hour", "/hour", "/hr", "hourly", "an hour"}, SalaryPeriodHour},
+	{[]string{"per day", "/day", "daily", "a day"}, SalaryPeriodDay},
+	{[]string{"per week", "/week", "/wk", "weekly", "a week"}, SalaryPeriodWeek},
+	{[]string{"per month", "/month", "/mo", "monthly", "a month"}, SalaryPeriodMonth},
+	{[]string{"per year", "/year", "/yr", "yearly", "annual", "a year", "p.a.", "per annum"}, SalaryPeriodYear},
+}
+
+// ParseSalary parses a free-text salary such as "$90k - $120k" or "€60,000 per year"
+// into structured fields. It returns false when no amount can be found.
+func ParseSalary(raw string) (SalaryInfo, bool) {
+	text := strings.ToLower(strings.TrimSpace(raw))
+	text = retirementPlanPattern.ReplaceAllString(text, "")
+	if text == "" {
+		return SalaryInfo{}, false
+	}
+
+	matches := salaryAmountPattern.FindAllStringSubmatch(text, -1)
+	var amounts, multipliers []float64
+	for _, match := range matches {
+		amount, ok := parseSalaryAmount(match[1], match[2] != "")
+		if !ok || amount <= 0 {
+			continue
+		}
+		multiplier := 1.0
+		switch match[2] {
+		case "k":
+			multiplier = 1000
+		case "m":
+			multiplier = 1000000
+		}
+		amounts = append(amounts, amount)
+		multipliers = append(multipliers, multiplier)
+		if len(amounts) == 2 {
+			break
+		}
+	}
+
+	if len(amounts) == 0 {
+		return SalaryInfo{}, false
+	}
+
+	// "90-120k" carries the suffix only on the upper bound
+	if len(amounts) == 2 && multipliers[0] == 1 && multipliers[1] > 1 && amounts[0] < 1000 {
+		multipliers[0] = multipliers[1]
+	}
+
+	info := SalaryInfo{
+		Min: int(amounts[0] * multipliers[0]),
+		Max: int(amounts[0] * multipliers[0]),
+	}
+	if len(amounts) == 2 {
+		info.Max = int(amounts[1] * multipliers[1])
+		if info.Min > info.Max {
+			info.Min, info.Max = info.Max, info.Min
+		}
+	}
+
+	for _, currency := range currencySymbols {
+		if strings.Contains(text, currency.marker) {
+			info.Currency = currency.code
+			break
+		}
+	}
+
+	for _, candidate := range salaryPeriods {
+		for _, marker := range candidate.markers {
+			if strings.Contains(text, marker) {
+				info.Period = candidate.period
+				break
+			}
+		}
+		if info.Period != "" {
+			break
+		}
+	}
+
+	// Large amounts without an explicit period are almost always annual
+	if info.Period == "" && info.Min >= 10000 {
+		info.Period = SalaryPeriodYear
+	}
+
+	return info, true
+}
+
+// parseSalaryAmount parses a number that may use "," or "." as a thousands separator
+func parseSalaryAmount(number string, hasSuffix bool) (float64, bool) {
+	number = strings.ReplaceAll(number, ",", "")
+
+	// "60.000" uses "." as a thousands separator unless followed by a k/m suffix
+	if !hasSuffix {
+		parts := strings.Split(number, ".")
+		if len(parts) > 1 && len(parts[len(parts)-1]) == 3 {
+			number = strings.Join(parts, "")
+		}
+	}
+
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	return amount, true
+}
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		raw    string
+		want   SalaryInfo
+		wantOK bool
+	}{
+		{"$90k - $120k", SalaryInfo{90000, 120000, "USD", SalaryPeriodYear}, true},
+		{"€60,000", SalaryInfo{60000, 60000, "EUR", SalaryPeriodYear}, true},
+		{"£45,000 - £55,000 per annum", SalaryInfo{45000, 55000, "GBP", SalaryPeriodYear}, true},
+		{"$50 - $70 per hour", SalaryInfo{50, 70, "USD", SalaryPeriodHour}, true},
+		{"90-120k USD", SalaryInfo{90000, 120000, "USD", SalaryPeriodYear}, true},
+		{"60.000 EUR per year", SalaryInfo{60000, 60000, "EUR", SalaryPeriodYear}, true},
+		{"CA$80,000 - CA$95,000", SalaryInfo{80000, 95000, "CAD", SalaryPeriodYear}, true},
+		{"$1.2M", SalaryInfo{1200000, 1200000, "USD", SalaryPeriodYear}, true},
+		{"$5,000/month", SalaryInfo{5000, 5000, "USD", SalaryPeriodMonth}, true},
+		{"₹12,00,000 yearly", SalaryInfo{1200000, 1200000, "INR", SalaryPeriodYear}, true},
+		{"$120k - $90k", SalaryInfo{90000, 120000, "USD", SalaryPeriodYear}, true},
+		{"45.5k", SalaryInfo{45500, 45500, "", SalaryPeriodYear}, true},
+		{"Competitive", SalaryInfo{}, false},
+		{"", SalaryInfo{}, false},
+		{"401(k) matching", SalaryInfo{}, false},
+		{"DOE", SalaryInfo{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, ok := ParseSalary(tt.raw)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseSalary(%q) ok = %t, want %t", tt.raw, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSalary(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
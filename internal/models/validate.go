@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Validation levels controlling how strictly jobs are checked before saving
+const (
+	ValidationOff    = "off"    // save every job
+	ValidationBasic  = "basic"  // require a title, an absolute URL and a plausible posted date
+	ValidationStrict = "strict" // also require a company, and a standardized job type when one is set
+)
+
+// ValidValidationLevel reports whether s is one of the validation levels
+func ValidValidationLevel(s string) bool {
+	switch s {
+	case ValidationOff, ValidationBasic, ValidationStrict:
+		return true
+	}
+	return false
+}
+
+// earliestPostedDate rejects zero and garbage dates from source feeds
+var earliestPostedDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// maxPostedDateSkew allows for posted dates slightly ahead of the local clock
+const maxPostedDateSkew = 24 * time.Hour
+
+// Validate checks that the job is complete enough to store: it needs a title,
+// a company, an absolute URL and a plausible posted date, and its job type must
+// be standardized unless the source did not give one
+func (j Job) Validate() error {
+	return j.ValidateLevel(ValidationStrict)
+}
+
+// ValidateLevel checks the job at the given validation level
+func (j Job) ValidateLevel(level string) error {
+	if level == ValidationOff {
+		return nil
+	}
+
+	if strings.TrimSpace(j.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+	if err := validateURL(j.URL); err != nil {
+		return err
+	}
+	if j.PostedDate != nil {
+		if j.PostedDate.Before(earliestPostedDate) {
+			return fmt.Errorf("posted date %s is implausibly old", j.PostedDate.Format(time.RFC3339))
+		}
+		if j.PostedDate.After(time.Now().Add(maxPostedDateSkew)) {
+			return fmt.Errorf("posted date %s is in the future", j.PostedDate.Format(time.RFC3339))
+		}
+	}
+
+	if level != ValidationStrict {
+		return nil
+	}
+
+	if strings.TrimSpace(j.Company) == "" {
+		return fmt.Errorf("company is required")
+	}
+	// Sources such as feeds have no job type, and unknown ones are normalized
+	// to empty, so only a non-standard job type is rejected
+	if j.JobType != "" && !ValidJobType(j.JobType) {
+		return fmt.Errorf("unrecognized job type %q", j.JobType)
+	}
+	return nil
+}
+
+// validateURL requires an absolute http or https URL
+func validateURL(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid url %q", raw)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// validJob returns a job passing strict validation
+func validJob() Job {
+	posted := time.Now().Add(-time.Hour)
+	return Job{
+		Title:      "Go Developer",
+		Company:    "Acme",
+		URL:        "https://example.com/jobs/1",
+		JobType:    JobTypeFullTime,
+		PostedDate: &posted,
+	}
+}
+
+func TestValidateLevel(t *testing.T) {
+	ancient := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Now().Add(7 * 24 * time.Hour)
+	skewed := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		modify     func(*Job)
+		wantBasic  string // error at the basic level, empty when valid
+		wantStrict string
+	}{
+		{"valid", func(j *Job) {}, "", ""},
+		{"empty title", func(j *Job) { j.Title = "  " }, "title is required", "title is required"},
+		{"empty url", func(j *Job) { j.URL = "" }, "url is required", "url is required"},
+		{"relative url", func(j *Job) { j.URL = "/jobs/1" }, "invalid url", "invalid url"},
+		{"ftp url", func(j *Job) { j.URL = "ftp://example.com/jobs/1" }, "invalid url", "invalid url"},
+		{"ancient posted date", func(j *Job) { j.PostedDate = &ancient }, "implausibly old", "implausibly old"},
+		{"future posted date", func(j *Job) { j.PostedDate = &future }, "in the future", "in the future"},
+		{"slightly ahead posted date", func(j *Job) { j.PostedDate = &skewed }, "", ""},
+		{"no posted date", func(j *Job) { j.PostedDate = nil }, "", ""},
+		{"empty company", func(j *Job) { j.Company = "" }, "", "company is required"},
+		{"unknown job type", func(j *Job) { j.JobType = "temporary" }, "", `unrecognized job type "temporary"`},
+		// Sources without job types must still pass strict validation
+		{"empty job type", func(j *Job) { j.JobType = "" }, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := validJob()
+			tt.modify(&job)
+
+			for _, level := range []struct{ name, wantErr string }{
+				{ValidationBasic, tt.wantBasic},
+				{ValidationStrict, tt.wantStrict},
+			} {
+				err := job.ValidateLevel(level.name)
+				if level.wantErr == "" {
+					if err != nil {
+						t.Errorf("ValidateLevel(%s) = %v, want nil", level.name, err)
+					}
+					continue
+				}
+				if err == nil || !strings.Contains(err.Error(), level.wantErr) {
+					t.Errorf("ValidateLevel(%s) = %v, want an error containing %q", level.name, err, level.wantErr)
+				}
+			}
+
+			if err := job.ValidateLevel(ValidationOff); err != nil {
+				t.Errorf("ValidateLevel(off) = %v, want nil", err)
+			}
+			if got, want := job.Validate(), job.ValidateLevel(ValidationStrict); (got == nil) != (want == nil) {
+				t.Errorf("Validate() = %v, want the strict result %v", got, want)
+			}
+		})
+	}
+}
+
+func TestValidValidationLevel(t *testing.T) {
+	for _, level := range []string{ValidationOff, ValidationBasic, ValidationStrict} {
+		if !ValidValidationLevel(level) {
+			t.Errorf("ValidValidationLevel(%q) = false, want true", level)
+		}
+	}
+	for _, level := range []string{"", "Strict", "lenient"} {
+		if ValidValidationLevel(level) {
+			t.Errorf("ValidValidationLevel(%q) = true, want false", level)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package monitoring
+
+import (
+	"fmt"
+	"io"
+	"job-scraper-go/internal/scraper"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MetricsProvider supplies a snapshot of scraper metrics
+type MetricsProvider interface {
+	GetMetrics() scraper.ScraperMetrics
+}
+
+// MetricsHandler serves scraper metrics in the Prometheus text exposition format
+func MetricsHandler(provider MetricsProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		metrics := provider.GetMetrics()
+		WritePrometheus(w, &metrics)
+	})
+}
+
+// WritePrometheus writes metrics in the Prometheus text exposition format
+func WritePrometheus(w io.Writer, metrics *scraper.ScraperMetrics) {
+	writeMetric(w, "job_scraper_jobs_scraped_total", "counter", "Total jobs scraped from all sources", float64(metrics.TotalJobsScraped))
+	writeMetric(w, "job_scraper_jobs_saved_total", "counter", "Total jobs saved to storage", float64(metrics.TotalJobsSaved))
+	writeMetric(w, "job_scraper_duplicates_total", "counter", "Total duplicate jobs removed", float64(metrics.TotalDuplicates))
+	writeMetric(w, "job_scraper_within_source_duplicates_total", "counter", "Duplicate jobs repeated within a single source", float64(metrics.WithinSourceDuplicates))
+	writeMetric(w, "job_scraper_cross_source_duplicates_total", "counter", "Duplicate jobs first seen from another source", float64(metrics.CrossSourceDuplicates))
+	writeMetric(w, "job_scraper_errors_total", "counter", "Total scraping errors", float64(metrics.TotalErrors))
+	writeMetric(w, "job_scraper_suspicious_runs_total", "counter", "Fetches of fewer jobs than a source's min_expected_jobs", float64(metrics.SuspiciousRuns))
+	writeMetric(w, "job_scraper_scrape_duration_seconds", "gauge", "Duration of the last scrape run", metrics.ScrapingDuration.Seconds())
+
+	// Sort sources for stable output
+	names := make([]string, 0, len(metrics.SourcePerformance))
+	for name := range metrics.SourcePerformance {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sourceMetrics := []struct {
+		name  string
+		help  string
+		value func(scraper.SourceMetrics) float64
+	}{
+		{"job_scraper_source_jobs_scraped", "Jobs scraped from the source in the last run", func(m scraper.SourceMetrics) float64 { return float64(m.JobsScraped) }},
+		{"job_scraper_source_jobs_saved", "Jobs saved from the source in the last run", func(m scraper.SourceMetrics) float64 { return float64(m.JobsSaved) }},
+		{"job_scraper_source_duplicates", "Duplicate jobs from the source in the last run", func(m scraper.SourceMetrics) float64 { return float64(m.Duplicates) }},
+		{"job_scraper_source_cross_source_duplicates", "Duplicate jobs from the source first seen from another source in the last run", func(m scraper.SourceMetrics) float64 { return float64(m.CrossSourceDuplicates) }},
+		{"job_scraper_source_errors_total", "Scraping errors for the source", func(m scraper.SourceMetrics) float64 { return float64(m.Errors) }},
+		{"job_scraper_source_suspicious_runs_total", "Fetches of fewer jobs than the source's min_expected_jobs", func(m scraper.SourceMetrics) float64 { return float64(m.SuspiciousRuns) }},
+		{"job_scraper_source_response_time_seconds", "Response time of the last scrape of the source", func(m scraper.SourceMetrics) float64 { return m.ResponseTime.Seconds() }},
+		{"job_scraper_source_avg_response_time_seconds", "Moving average response time of the source", func(m scraper.SourceMetrics) float64 { return m.AvgResponseTime().Seconds() }},
+		{"job_scraper_source_success_rate", "Fraction of scrapes of the source that succeeded", func(m scraper.SourceMetrics) float64 { return m.SuccessRate() }},
+		{"job_scraper_source_last_scraped_timestamp_seconds", "Unix time of the last scrape of the source", func(m scraper.SourceMetrics) float64 {
+			if m.LastScraped.IsZero() {
+				return 0
+			}
+			return float64(m.LastScraped.Unix())
+		}},
+	}
+
+	for _, metric := range sourceMetrics {
+		metricType := "gauge"
+		if strings.HasSuffix(metric.name, "_total") {
+			metricType = "counter"
+		}
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metricType)
+		for _, name := range names {
+			fmt.Fprintf(w, "%s{source=\"%s\"} %g\n", metric.name, escapeLabel(name), metric.value(metrics.SourcePerformance[name]))
+		}
+	}
+}
+
+// writeMetric writes a single unlabeled metric with its HELP and TYPE lines
+func writeMetric(w io.Writer, name, metricType, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", name, help, name, metricType, name, value)
+}
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,85 @@
+package monitoring
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/scraper"
+)
+
+// staticMetrics is a MetricsProvider returning fixed metrics
+type staticMetrics struct{}
+
+func (staticMetrics) GetMetrics() scraper.ScraperMetrics {
+	return scraper.ScraperMetrics{
+		TotalJobsScraped: 12,
+		TotalJobsSaved:   9,
+		TotalDuplicates:  3,
+		TotalErrors:      1,
+		ScrapingDuration: 1500 * time.Millisecond,
+		SourcePerformance: map[string]scraper.SourceMetrics{
+			"Remotive": {JobsScraped: 5, JobsSaved: 4, ResponseTime: 250 * time.Millisecond},
+			"RemoteOK": {JobsScraped: 7, JobsSaved: 5, Errors: 1, ResponseTime: 2 * time.Second},
+		},
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	MetricsHandler(staticMetrics{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", contentType)
+	}
+
+	body, _ := io.ReadAll(recorder.Body)
+	for _, want := range []string{
+		"# TYPE job_scraper_jobs_scraped_total counter\njob_scraper_jobs_scraped_total 12\n",
+		"job_scraper_jobs_saved_total 9\n",
+		"job_scraper_duplicates_total 3\n",
+		"job_scraper_errors_total 1\n",
+		"# TYPE job_scraper_scrape_duration_seconds gauge\njob_scraper_scrape_duration_seconds 1.5\n",
+		"job_scraper_source_response_time_seconds{source=\"RemoteOK\"} 2\n",
+		"job_scraper_source_response_time_seconds{source=\"Remotive\"} 0.25\n",
+		"job_scraper_source_errors_total{source=\"RemoteOK\"} 1\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+
+	// Sources are listed in a stable order
+	if strings.Index(string(body), `{source="RemoteOK"}`) > strings.Index(string(body), `{source="Remotive"}`) {
+		t.Error("sources are not sorted by name")
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	if got, want := escapeLabel("a\"b\\c\nd"), `a\"b\\c\nd`; got != want {
+		t.Errorf("escapeLabel = %q, want %q", got, want)
+	}
+}
+
+func TestNewServerServesMetrics(t *testing.T) {
+	server := httptest.NewServer(NewServer(config.DefaultConfig().Server, staticMetrics{}, nil).Handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "job_scraper_jobs_scraped_total 12") {
+		t.Errorf("GET /metrics = %d:\n%s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,27 @@
+package monitoring
+
+import (
+	"fmt"
+	"job-scraper-go/internal/config"
+	"net/http"
+)
+
+// NewServer creates an HTTP server exposing /metrics on the configured port,
+// plus the /sources runtime controls when a controller is given
+func NewServer(cfg config.ServerConfig, provider MetricsProvider, controller SourceController) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler(provider))
+	if controller != nil {
+		sourcesHandler := SourcesHandler(controller)
+		mux.Handle("/sources", sourcesHandler)
+		mux.Handle("/sources/", sourcesHandler)
+	}
+
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		Handler:      mux,
+		ReadTimeout:  cfg.ReadTimeout.Duration,
+		WriteTimeout: cfg.WriteTimeout.Duration,
+		IdleTimeout:  cfg.IdleTimeout.Duration,
+	}
+}
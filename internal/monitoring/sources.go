@@ -0,0 +1,46 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// SourceController lists job sources and toggles them at runtime
+type SourceController interface {
+	SourceStates() map[string]bool
+	SetSourceEnabled(name string, enabled bool) error
+}
+
+// SourcesHandler serves the runtime source controls:
+//
+//	GET  /sources                 lists sources and whether they are enabled
+//	POST /sources/{name}/enable   resumes a paused source
+//	POST /sources/{name}/disable  pauses a source until re-enabled
+func SourcesHandler(controller SourceController) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sources", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, controller.SourceStates())
+	})
+	mux.HandleFunc("POST /sources/{name}/enable", setSourceEnabled(controller, true))
+	mux.HandleFunc("POST /sources/{name}/disable", setSourceEnabled(controller, false))
+	return mux
+}
+
+// setSourceEnabled returns a handler that enables or disables the named source
+func setSourceEnabled(controller SourceController, enabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if err := controller.SetSourceEnabled(name, enabled); err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"source": name, "enabled": enabled})
+	}
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
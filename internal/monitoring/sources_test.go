@@ -0,0 +1,56 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// mapController is a SourceController over a map of source states
+type mapController map[string]bool
+
+func (c mapController) SourceStates() map[string]bool { return c }
+
+func (c mapController) SetSourceEnabled(name string, enabled bool) error {
+	if _, ok := c[name]; !ok {
+		return fmt.Errorf("unknown source: %s", name)
+	}
+	c[name] = enabled
+	return nil
+}
+
+func TestSourcesHandler(t *testing.T) {
+	controller := mapController{"RemoteOK": true, "Remotive": true}
+	handler := SourcesHandler(controller)
+
+	tests := []struct {
+		method, target string
+		wantStatus     int
+		wantStates     map[string]bool
+	}{
+		{http.MethodPost, "/sources/RemoteOK/disable", http.StatusOK, map[string]bool{"RemoteOK": false, "Remotive": true}},
+		{http.MethodPost, "/sources/Monster/disable", http.StatusNotFound, map[string]bool{"RemoteOK": false, "Remotive": true}},
+		{http.MethodPost, "/sources/RemoteOK/enable", http.StatusOK, map[string]bool{"RemoteOK": true, "Remotive": true}},
+		{http.MethodGet, "/sources/RemoteOK/enable", http.StatusMethodNotAllowed, map[string]bool{"RemoteOK": true, "Remotive": true}},
+	}
+
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.target, nil))
+		if recorder.Code != tt.wantStatus {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.target, recorder.Code, tt.wantStatus)
+		}
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/sources", nil))
+		var states map[string]bool
+		if err := json.Unmarshal(recorder.Body.Bytes(), &states); err != nil {
+			t.Fatalf("decoding /sources: %v", err)
+		}
+		if fmt.Sprint(states) != fmt.Sprint(tt.wantStates) {
+			t.Errorf("after %s %s, /sources = %v, want %v", tt.method, tt.target, states, tt.wantStates)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// Notifier sends alerts about newly discovered jobs
+type Notifier interface {
+	Notify(ctx context.Context, jobs []models.Job) error
+}
+
+// NewFromConfig builds the notifier described by the configuration.
+// It returns nil when notifications are disabled.
+func NewFromConfig(cfg config.NotificationsConfig, client *httpclient.HttpClient) (Notifier, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("notifications are enabled but no webhook URL is configured")
+	}
+
+	switch cfg.Type {
+	case "", "webhook":
+		return NewWebhookNotifier(client, cfg.WebhookURL), nil
+	case "slack":
+		return NewSlackNotifier(client, cfg.WebhookURL, cfg.MaxJobsPerMessage, cfg.PostInterval.Duration), nil
+	default:
+		return nil, fmt.Errorf("unknown notification type %q (expected webhook or slack)", cfg.Type)
+	}
+}
@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSlackJobsPerMessage keeps messages well below Slack's 50 block limit
+	defaultSlackJobsPerMessage = 20
+	maxSlackJobsPerMessage     = 45
+
+	// defaultSlackPostInterval respects Slack's one message per second webhook limit
+	defaultSlackPostInterval = time.Second
+)
+
+// SlackNotifier posts new jobs to a Slack incoming webhook as message blocks
+type SlackNotifier struct {
+	client         *httpclient.HttpClient
+	url            string
+	jobsPerMessage int
+	postInterval   time.Duration
+}
+
+// slackMessage is the JSON body of a Slack incoming-webhook message
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewSlackNotifier creates a Slack notifier. Jobs are split into messages of at
+// most jobsPerMessage jobs, posted at least postInterval apart; zero values use defaults.
+func NewSlackNotifier(client *httpclient.HttpClient, url string, jobsPerMessage int, postInterval time.Duration) *SlackNotifier {
+	if jobsPerMessage <= 0 {
+		jobsPerMessage = defaultSlackJobsPerMessage
+	}
+	if jobsPerMessage > maxSlackJobsPerMessage {
+		jobsPerMessage = maxSlackJobsPerMessage
+	}
+	if postInterval <= 0 {
+		postInterval = defaultSlackPostInterval
+	}
+
+	return &SlackNotifier{
+		client:         client,
+		url:            url,
+		jobsPerMessage: jobsPerMessage,
+		postInterval:   postInterval,
+	}
+}
+
+// Notify posts the jobs to Slack in batches. Nothing is sent when there are no jobs.
+func (n *SlackNotifier) Notify(ctx context.Context, jobs []models.Job) error {
+	for start := 0; start < len(jobs); start += n.jobsPerMessage {
+		if start > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(n.postInterval):
+			}
+		}
+
+		end := start + n.jobsPerMessage
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+
+		body, err := json.Marshal(n.formatMessage(jobs[start:end], start, len(jobs)))
+		if err != nil {
+			return fmt.Errorf("failed to encode Slack message: %w", err)
+		}
+
+		if err := postJSON(ctx, n.client, n.url, body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatMessage builds a Slack message for one batch of jobs
+func (n *SlackNotifier) formatMessage(batch []models.Job, offset, total int) slackMessage {
+	title := fmt.Sprintf("%d new jobs found", total)
+	if total > len(batch) {
+		title = fmt.Sprintf("%d new jobs found (%d-%d)", total, offset+1, offset+len(batch))
+	}
+
+	blocks := []slackBlock{{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: title},
+	}}
+
+	for _, job := range batch {
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: formatSlackJob(job)},
+		})
+	}
+
+	return slackMessage{Text: title, Blocks: blocks}
+}
+
+// formatSlackJob formats a job as Slack mrkdwn text
+func formatSlackJob(job models.Job) string {
+	headline := "*" + escapeSlack(job.Title) + "*"
+	if job.URL != "" {
+		headline = "*<" + job.URL + "|" + escapeSlack(job.Title) + ">*"
+	}
+
+	details := []string{escapeSlack(job.Company)}
+	for _, detail := range []string{job.Location, job.Salary, job.JobType, job.Source} {
+		if strings.TrimSpace(detail) != "" {
+			details = append(details, escapeSlack(detail))
+		}
+	}
+
+	return headline + "\n" + strings.Join(details, " · ")
+}
+
+// escapeSlack escapes the control characters of Slack mrkdwn
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
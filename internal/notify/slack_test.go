@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/models"
+)
+
+// decodeSlack decodes a posted Slack message
+func decodeSlack(t *testing.T, body []byte) slackMessage {
+	t.Helper()
+
+	var message slackMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		t.Fatalf("decoding Slack message %s: %v", body, err)
+	}
+	return message
+}
+
+// messageText joins the text of all blocks of a message
+func messageText(message slackMessage) string {
+	var parts []string
+	for _, block := range message.Blocks {
+		if block.Text != nil {
+			parts = append(parts, block.Text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func TestSlackNotifierFormatsJobs(t *testing.T) {
+	server := newCaptureServer(t, http.StatusOK)
+	jobs := []models.Job{
+		{Title: "Go Developer", Company: "Acme", URL: "https://example.com/1", Location: "Remote", Salary: "$100k"},
+		{Title: "R&D <Engineer>", Company: "Globex"},
+	}
+
+	if err := NewSlackNotifier(testClient(), server.URL, 0, 0).Notify(context.Background(), jobs); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	posts := server.posts()
+	if len(posts) != 1 {
+		t.Fatalf("received %d posts, want 1", len(posts))
+	}
+	message := decodeSlack(t, posts[0])
+	if message.Text != "2 new jobs found" {
+		t.Errorf("message text = %q, want %q", message.Text, "2 new jobs found")
+	}
+	if len(message.Blocks) != 3 || message.Blocks[0].Type != "header" {
+		t.Fatalf("message has blocks %+v, want a header and one section per job", message.Blocks)
+	}
+
+	text := messageText(message)
+	for _, want := range []string{
+		"*<https://example.com/1|Go Developer>*",
+		"Acme · Remote · $100k",
+		"*R&amp;D &lt;Engineer&gt;*",
+		"Globex",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("message text %q does not contain %q", text, want)
+		}
+	}
+}
+
+func TestSlackNotifierBatchesJobs(t *testing.T) {
+	server := newCaptureServer(t, http.StatusOK)
+	var jobs []models.Job
+	for i := 1; i <= 5; i++ {
+		jobs = append(jobs, models.Job{Title: fmt.Sprintf("Job %d", i), Company: "Acme"})
+	}
+
+	start := time.Now()
+	if err := NewSlackNotifier(testClient(), server.URL, 2, 20*time.Millisecond).Notify(context.Background(), jobs); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("three posts took %v, want at least two post intervals", elapsed)
+	}
+
+	posts := server.posts()
+	wantTexts := []string{"5 new jobs found (1-2)", "5 new jobs found (3-4)", "5 new jobs found (5-5)"}
+	if len(posts) != len(wantTexts) {
+		t.Fatalf("received %d posts, want %d", len(posts), len(wantTexts))
+	}
+	for i, body := range posts {
+		message := decodeSlack(t, body)
+		if message.Text != wantTexts[i] {
+			t.Errorf("post %d text = %q, want %q", i, message.Text, wantTexts[i])
+		}
+		if !strings.Contains(messageText(message), fmt.Sprintf("Job %d", 2*i+1)) {
+			t.Errorf("post %d does not contain Job %d", i, 2*i+1)
+		}
+	}
+}
+
+func TestSlackNotifierStopsWhenCanceled(t *testing.T) {
+	server := newCaptureServer(t, http.StatusOK)
+	jobs := []models.Job{{Title: "One"}, {Title: "Two"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := NewSlackNotifier(testClient(), server.URL, 1, time.Hour).Notify(ctx, jobs)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Notify error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if posts := server.posts(); len(posts) != 1 {
+		t.Errorf("received %d posts, want only the first batch", len(posts))
+	}
+}
+
+func TestNewSlackNotifierClampsBatchSize(t *testing.T) {
+	tests := []struct {
+		jobsPerMessage int
+		want           int
+	}{
+		{0, defaultSlackJobsPerMessage},
+		{-3, defaultSlackJobsPerMessage},
+		{10, 10},
+		{100, maxSlackJobsPerMessage},
+	}
+
+	for _, tt := range tests {
+		if got := NewSlackNotifier(testClient(), "", tt.jobsPerMessage, 0).jobsPerMessage; got != tt.want {
+			t.Errorf("NewSlackNotifier(%d).jobsPerMessage = %d, want %d", tt.jobsPerMessage, got, tt.want)
+		}
+	}
+}
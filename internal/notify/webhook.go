@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+	"net/http"
+)
+
+// WebhookNotifier posts new jobs as JSON to a webhook URL
+type WebhookNotifier struct {
+	client *httpclient.HttpClient
+	url    string
+}
+
+// WebhookPayload is the JSON body sent to the webhook
+type WebhookPayload struct {
+	Count int          `json:"count"`
+	Jobs  []models.Job `json:"jobs"`
+}
+
+// NewWebhookNotifier creates a notifier posting to the given URL
+func NewWebhookNotifier(client *httpclient.HttpClient, url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: client,
+		url:    url,
+	}
+}
+
+// Notify posts the jobs to the webhook. Nothing is sent when there are no jobs.
+func (n *WebhookNotifier) Notify(ctx context.Context, jobs []models.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(WebhookPayload{Count: len(jobs), Jobs: jobs})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	return postJSON(ctx, n.client, n.url, body)
+}
+
+// postJSON sends a JSON body and treats any non-2xx response as an error
+func postJSON(ctx context.Context, client *httpclient.HttpClient, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// captureServer records the bodies POSTed to it and answers with status
+type captureServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	bodies [][]byte
+}
+
+func newCaptureServer(t *testing.T, status int) *captureServer {
+	t.Helper()
+
+	capture := &captureServer{}
+	capture.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+
+		capture.mu.Lock()
+		capture.bodies = append(capture.bodies, body)
+		capture.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(capture.Close)
+	return capture
+}
+
+// posts returns the bodies received so far
+func (c *captureServer) posts() [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([][]byte(nil), c.bodies...)
+}
+
+func testClient() *httpclient.HttpClient {
+	return httpclient.NewHttpClient(5 * time.Second)
+}
+
+func TestWebhookNotifierPostsJobs(t *testing.T) {
+	server := newCaptureServer(t, http.StatusNoContent)
+	jobs := []models.Job{
+		{Title: "Go Developer", Company: "Acme", URL: "https://example.com/1"},
+		{Title: "Designer", Company: "Globex", URL: "https://example.com/2"},
+	}
+
+	if err := NewWebhookNotifier(testClient(), server.URL).Notify(context.Background(), jobs); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	posts := server.posts()
+	if len(posts) != 1 {
+		t.Fatalf("received %d posts, want 1", len(posts))
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal(posts[0], &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	if payload.Count != 2 || len(payload.Jobs) != 2 || payload.Jobs[0].Title != "Go Developer" || payload.Jobs[1].URL != "https://example.com/2" {
+		t.Errorf("payload = %+v, want the two jobs", payload)
+	}
+}
+
+func TestWebhookNotifierSkipsEmptyBatches(t *testing.T) {
+	server := newCaptureServer(t, http.StatusOK)
+
+	if err := NewWebhookNotifier(testClient(), server.URL).Notify(context.Background(), nil); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if posts := server.posts(); len(posts) != 0 {
+		t.Errorf("received %d posts for no jobs, want none", len(posts))
+	}
+}
+
+func TestWebhookNotifierReportsErrorStatus(t *testing.T) {
+	server := newCaptureServer(t, http.StatusInternalServerError)
+
+	err := NewWebhookNotifier(testClient(), server.URL).Notify(context.Background(), []models.Job{{Title: "Go Developer"}})
+	if err == nil {
+		t.Error("Notify succeeded against a failing webhook, want an error")
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.NotificationsConfig
+		wantType string
+		wantErr  bool
+	}{
+		{"disabled", config.NotificationsConfig{WebhookURL: "https://example.com/hook"}, "", false},
+		{"default type", config.NotificationsConfig{Enabled: true, WebhookURL: "https://example.com/hook"}, "webhook", false},
+		{"webhook", config.NotificationsConfig{Enabled: true, Type: "webhook", WebhookURL: "https://example.com/hook"}, "webhook", false},
+		{"slack", config.NotificationsConfig{Enabled: true, Type: "slack", WebhookURL: "https://hooks.slack.com/x"}, "slack", false},
+		{"missing URL", config.NotificationsConfig{Enabled: true}, "", true},
+		{"unknown type", config.NotificationsConfig{Enabled: true, Type: "email", WebhookURL: "https://example.com/hook"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, err := NewFromConfig(tt.cfg, testClient())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFromConfig error = %v, want error %v", err, tt.wantErr)
+			}
+
+			var gotType string
+			switch notifier.(type) {
+			case *WebhookNotifier:
+				gotType = "webhook"
+			case *SlackNotifier:
+				gotType = "slack"
+			}
+			if gotType != tt.wantType {
+				t.Errorf("NewFromConfig returned %T, want %s notifier", notifier, tt.wantType)
+			}
+		})
+	}
+}
@@ -1,58 +1,233 @@
 package scraper
 
 import (
-	"crypto/md5"
-	"fmt"
 	"job-scraper-go/internal/models"
 	"strings"
 	"sync"
+	"unicode"
 )
 
 // Deduplicator removes duplicate jobs based on various criteria
 type Deduplicator struct {
-	seenJobs map[string]bool
-	mu       sync.RWMutex
+	seenJobs  map[string]string     // job hash to the source of the copy kept
+	merge     bool                  // keep the fields of dropped duplicates for ResolveDuplicates
+	priority  map[string]int        // source key to its rank, lower ranks win
+	preferred map[string]models.Job // job hash to a copy outranking one already returned
+	dropped   map[string]models.Job // job hash to the merged fields of its dropped duplicates
+	mu        sync.RWMutex
+}
+
+// DuplicateStats breaks duplicates down by whether the first copy of the job
+// came from the same source or from another one
+type DuplicateStats struct {
+	WithinSource int
+	CrossSource  int
+}
+
+// Total returns the number of duplicates
+func (s DuplicateStats) Total() int {
+	return s.WithinSource + s.CrossSource
+}
+
+// add counts a duplicate of a job first seen from firstSource
+func (s *DuplicateStats) add(source, firstSource string) {
+	if source == firstSource {
+		s.WithinSource++
+	} else {
+		s.CrossSource++
+	}
 }
 
 // NewDeduplicator creates a new deduplicator
 func NewDeduplicator() *Deduplicator {
 	return &Deduplicator{
-		seenJobs: make(map[string]bool),
+		seenJobs:  make(map[string]string),
+		preferred: make(map[string]models.Job),
+		dropped:   make(map[string]models.Job),
 	}
 }
 
+// SetMerge sets whether the fields of dropped duplicates are kept, so that
+// ResolveDuplicates can fill in the empty fields of the copies kept
+func (d *Deduplicator) SetMerge(merge bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.merge = merge
+}
+
+// SetSourcePriority sets the sources whose copy of a job is kept over the
+// others, highest priority first. Source names are matched ignoring case and
+// punctuation, so "wework_remotely" matches "WeWorkRemotely". Sources not
+// listed rank below those listed, and between sources of the same rank the
+// first copy seen is kept.
+func (d *Deduplicator) SetSourcePriority(sources []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.priority = make(map[string]int, len(sources))
+	for rank, source := range sources {
+		if _, exists := d.priority[sourceKey(source)]; !exists {
+			d.priority[sourceKey(source)] = rank
+		}
+	}
+}
+
+// outranks reports whether source has a higher priority than other
+func (d *Deduplicator) outranks(source, other string) bool {
+	if len(d.priority) == 0 {
+		return false
+	}
+
+	rank := func(name string) int {
+		if rank, ok := d.priority[sourceKey(name)]; ok {
+			return rank
+		}
+		return len(d.priority)
+	}
+	return rank(source) < rank(other)
+}
+
+// sourceKey normalizes a source name for priority matching
+func sourceKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
 // RemoveDuplicates removes duplicate jobs from a slice
 func (d *Deduplicator) RemoveDuplicates(jobs []models.Job) []models.Job {
+	uniqueJobs, _ := d.RemoveDuplicatesWithStats(jobs)
+	return uniqueJobs
+}
+
+// RemoveDuplicatesWithStats removes duplicate jobs from a slice and reports
+// how many duplicated a job from the same source and how many one from
+// another source, going by each job's Source. A copy from a source with a
+// higher priority replaces one kept earlier in jobs; one kept by an earlier
+// call is replaced by ResolveDuplicates.
+func (d *Deduplicator) RemoveDuplicatesWithStats(jobs []models.Job) ([]models.Job, DuplicateStats) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	var uniqueJobs []models.Job
+	var stats DuplicateStats
+	kept := make(map[string]int) // job hash to its index in uniqueJobs
 
 	for _, job := range jobs {
 		hash := d.generateJobHash(job)
 
-		if !d.seenJobs[hash] {
-			d.seenJobs[hash] = true
+		firstSource, seen := d.seenJobs[hash]
+		if !seen {
+			d.seenJobs[hash] = job.Source
+			kept[hash] = len(uniqueJobs)
 			uniqueJobs = append(uniqueJobs, job)
+			continue
+		}
+
+		stats.add(job.Source, firstSource)
+		if !d.outranks(job.Source, firstSource) {
+			d.keepDuplicate(hash, job)
+			continue
+		}
+
+		d.seenJobs[hash] = job.Source
+		if index, ok := kept[hash]; ok {
+			d.keepDuplicate(hash, uniqueJobs[index])
+			uniqueJobs[index] = job
+			continue
+		}
+		if previous, ok := d.preferred[hash]; ok {
+			d.keepDuplicate(hash, previous)
 		}
+		d.preferred[hash] = job
 	}
 
-	return uniqueJobs
+	return uniqueJobs, stats
 }
 
-// generateJobHash creates a hash for a job based on title, company, and location
-func (d *Deduplicator) generateJobHash(job models.Job) string {
-	// Normalize strings for better matching
-	title := strings.ToLower(strings.TrimSpace(job.Title))
-	company := strings.ToLower(strings.TrimSpace(job.Company))
-	location := strings.ToLower(strings.TrimSpace(job.Location))
+// keepDuplicate keeps the fields of a dropped duplicate when merging
+func (d *Deduplicator) keepDuplicate(hash string, job models.Job) {
+	if !d.merge {
+		return
+	}
+	if previous, ok := d.dropped[hash]; ok {
+		job = previous.Merge(job)
+	}
+	d.dropped[hash] = job
+}
+
+// ResolveDuplicates applies what the duplicates dropped since the last call
+// imply for jobs, the copies kept earlier: a job is replaced by a copy from
+// a source with a higher priority, and with merging enabled its empty fields,
+// such as a missing salary or description, are filled from the duplicates.
+// It returns the number of jobs replaced and enriched. The duplicates are
+// then forgotten, including those of jobs not passed in.
+func (d *Deduplicator) ResolveDuplicates(jobs []models.Job) (replaced, enriched int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	// Create composite key
-	key := fmt.Sprintf("%s|%s|%s", title, company, location)
+	if len(d.preferred) == 0 && len(d.dropped) == 0 {
+		return 0, 0
+	}
+
+	for i, job := range jobs {
+		hash := d.generateJobHash(job)
+
+		if preferred, ok := d.preferred[hash]; ok {
+			d.keepDuplicate(hash, job)
+			jobs[i] = preferred
+			replaced++
+		}
+		if duplicate, ok := d.dropped[hash]; ok {
+			if merged := jobs[i].Merge(duplicate); !merged.Equal(jobs[i]) {
+				jobs[i] = merged
+				enriched++
+			}
+		}
+	}
 
-	// Generate MD5 hash
-	hash := md5.Sum([]byte(key))
-	return fmt.Sprintf("%x", hash)
+	d.preferred = make(map[string]models.Job)
+	d.dropped = make(map[string]models.Job)
+	return replaced, enriched
+}
+
+// Seed marks jobs as already seen, so that later copies of them are treated
+// as duplicates. It returns the number of newly seen jobs.
+func (d *Deduplicator) Seed(jobs []models.Job) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	added := 0
+	for _, job := range jobs {
+		hash := d.generateJobHash(job)
+		if _, seen := d.seenJobs[hash]; !seen {
+			d.seenJobs[hash] = job.Source
+			added++
+		}
+	}
+	return added
+}
+
+// Forget unmarks jobs as seen, so that their next copies are kept, such as
+// jobs that failed to save and must be saved by a later run
+func (d *Deduplicator) Forget(jobs []models.Job) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, job := range jobs {
+		hash := d.generateJobHash(job)
+		delete(d.seenJobs, hash)
+		delete(d.preferred, hash)
+	}
+}
+
+// generateJobHash creates a hash for a job based on title, company, and location
+func (d *Deduplicator) generateJobHash(job models.Job) string {
+	return models.JobHash(job)
 }
 
 // IsDuplicate checks if a job is a duplicate without adding it to the seen jobs
@@ -61,7 +236,8 @@ func (d *Deduplicator) IsDuplicate(job models.Job) bool {
 	defer d.mu.RUnlock()
 
 	hash := d.generateJobHash(job)
-	return d.seenJobs[hash]
+	_, seen := d.seenJobs[hash]
+	return seen
 }
 
 // Reset clears all seen jobs
@@ -69,7 +245,9 @@ func (d *Deduplicator) Reset() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	d.seenJobs = make(map[string]bool)
+	d.seenJobs = make(map[string]string)
+	d.preferred = make(map[string]models.Job)
+	d.dropped = make(map[string]models.Job)
 }
 
 // GetSeenCount returns the number of unique jobs seen
@@ -108,11 +286,52 @@ func (d *Deduplicator) FindSimilarJobs(jobs []models.Job, threshold float64) []J
 	return similarities
 }
 
+// RemoveSimilarJobs drops near-duplicate jobs of different sources whose
+// similarity is at or above threshold, keeping the first job of each pair.
+// Similar jobs of one source, such as two openings of a company for the same
+// role, are separate postings and all kept. It returns the remaining jobs
+// along with the pairs that caused a job to be suppressed.
+func (d *Deduplicator) RemoveSimilarJobs(jobs []models.Job, threshold float64) ([]models.Job, []JobSimilarity) {
+	similarities := d.FindSimilarJobs(jobs, threshold)
+	if len(similarities) == 0 {
+		return jobs, nil
+	}
+
+	dropped := make(map[string]bool)
+	var suppressed []JobSimilarity
+
+	for _, pair := range similarities {
+		if pair.Job1.Source == pair.Job2.Source {
+			continue
+		}
+
+		keepHash := d.generateJobHash(pair.Job1)
+		dropHash := d.generateJobHash(pair.Job2)
+
+		// Skip pairs where one side has already been suppressed
+		if dropped[keepHash] || dropped[dropHash] {
+			continue
+		}
+
+		dropped[dropHash] = true
+		suppressed = append(suppressed, pair)
+	}
+
+	var remaining []models.Job
+	for _, job := range jobs {
+		if !dropped[d.generateJobHash(job)] {
+			remaining = append(remaining, job)
+		}
+	}
+
+	return remaining, suppressed
+}
+
 // calculateSimilarity calculates similarity between two jobs (0.0 to 1.0)
 func (d *Deduplicator) calculateSimilarity(job1, job2 models.Job) float64 {
 	// Simple similarity based on string matching
 	titleSim := d.stringSimilarity(job1.Title, job2.Title)
-	companySim := d.stringSimilarity(job1.Company, job2.Company)
+	companySim := d.stringSimilarity(models.NormalizeCompany(job1.Company), models.NormalizeCompany(job2.Company))
 	locationSim := d.stringSimilarity(job1.Location, job2.Location)
 
 	// Weighted average
@@ -0,0 +1,215 @@
+package scraper
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"job-scraper-go/internal/models"
+)
+
+func TestRemoveSimilarJobsKeepsFirstOfEachPair(t *testing.T) {
+	jobs := []models.Job{
+		testJob("RemoteOK", "Senior Go Developer", "Acme"),
+		testJob("Remotive", "Senior Golang Developer", "Acme"),
+		testJob("Remotive", "Data Scientist", "Initech"),
+	}
+
+	remaining, suppressed := NewDeduplicator().RemoveSimilarJobs(jobs, 0.7)
+
+	if got := titles(remaining); len(got) != 2 || got[0] != "Senior Go Developer" || got[1] != "Data Scientist" {
+		t.Errorf("remaining = %v, want [Senior Go Developer Data Scientist]", got)
+	}
+	if len(suppressed) != 1 || suppressed[0].Job2.Source != "Remotive" {
+		t.Fatalf("suppressed = %+v, want the Remotive copy", suppressed)
+	}
+	if suppressed[0].Similarity < 0.7 || suppressed[0].Similarity >= 1 {
+		t.Errorf("similarity = %.2f, want in [0.7, 1)", suppressed[0].Similarity)
+	}
+}
+
+func TestRemoveDuplicatesAcrossCompanyNameVariants(t *testing.T) {
+	jobs := []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme, Inc."),
+		testJob("Remotive", "Go Developer", "ACME"),
+		testJob("WeWorkRemotely", "Go Developer", "Acme LLC"),
+		testJob("Remotive", "Go Developer", "Globex"),
+	}
+
+	unique := NewDeduplicator().RemoveDuplicates(jobs)
+	if len(unique) != 2 {
+		t.Fatalf("kept %d jobs, want 2", len(unique))
+	}
+	// The raw company name is kept on the model
+	if unique[0].Company != "Acme, Inc." || unique[1].Company != "Globex" {
+		t.Errorf("kept companies %q and %q, want Acme, Inc. and Globex", unique[0].Company, unique[1].Company)
+	}
+}
+
+func TestSeedMarksJobsSeen(t *testing.T) {
+	d := NewDeduplicator()
+	stored := []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("Remotive", "Go Developer", "Acme Inc."), // the same posting
+		testJob("Remotive", "Designer", "Globex"),
+	}
+
+	if added := d.Seed(stored); added != 2 {
+		t.Errorf("Seed = %d, want 2 newly seen jobs", added)
+	}
+	if !d.IsDuplicate(testJob("WeWorkRemotely", "Go Developer", "ACME")) {
+		t.Error("a copy of a seeded job is not a duplicate")
+	}
+	if d.IsDuplicate(testJob("RemoteOK", "Rust Developer", "Acme")) {
+		t.Error("a new job is a duplicate")
+	}
+	if added := d.Seed(stored); added != 0 {
+		t.Errorf("seeding again = %d, want 0", added)
+	}
+}
+
+func TestForgetKeepsNextCopies(t *testing.T) {
+	d := NewDeduplicator()
+	designer := testJob("RemoteOK", "Designer", "Globex")
+	d.RemoveDuplicates([]models.Job{testJob("RemoteOK", "Go Developer", "Acme"), designer})
+
+	d.Forget([]models.Job{designer})
+	if d.IsDuplicate(designer) {
+		t.Error("a forgotten job is still a duplicate")
+	}
+	if !d.IsDuplicate(testJob("RemoteOK", "Go Developer", "Acme")) {
+		t.Error("Forget forgot a job it was not given")
+	}
+	if unique := d.RemoveDuplicates([]models.Job{designer}); len(unique) != 1 {
+		t.Errorf("RemoveDuplicates kept %d copies of a forgotten job, want 1", len(unique))
+	}
+}
+
+func TestRemoveDuplicatesWithStatsSplitsBySource(t *testing.T) {
+	d := NewDeduplicator()
+
+	// Batches are deduplicated one source at a time, as in a scrape
+	_, first := d.RemoveDuplicatesWithStats([]models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Designer", "Globex"),
+	})
+	if want := (DuplicateStats{WithinSource: 1}); first != want {
+		t.Errorf("RemoteOK stats = %+v, want %+v", first, want)
+	}
+
+	unique, second := d.RemoveDuplicatesWithStats([]models.Job{
+		testJob("Remotive", "Go Developer", "Acme"),
+		testJob("Remotive", "Designer", "Globex"),
+		testJob("Remotive", "Writer", "Initech"),
+		testJob("Remotive", "Writer", "Initech"),
+	})
+	if want := (DuplicateStats{WithinSource: 1, CrossSource: 2}); second != want {
+		t.Errorf("Remotive stats = %+v, want %+v", second, want)
+	}
+	if second.Total() != 3 {
+		t.Errorf("Total = %d, want 3", second.Total())
+	}
+	if got := titles(unique); len(got) != 1 || got[0] != "Writer" {
+		t.Errorf("kept %v from Remotive, want [Writer]", got)
+	}
+}
+
+func TestResolveDuplicatesMergesDroppedCopies(t *testing.T) {
+	withSalary := testJob("RemoteOK", "Go Developer", "Acme")
+	withSalary.Salary = "$100k"
+	withDescription := testJob("Remotive", "Go Developer", "Acme")
+	withDescription.Description = "Build APIs"
+
+	tests := []struct {
+		name         string
+		merge        bool
+		wantEnriched int
+		want         models.Job
+	}{
+		{"dropped", false, 0, withSalary},
+		{"merged", true, 1, withSalary.Merge(withDescription)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeduplicator()
+			d.SetMerge(tt.merge)
+
+			kept := d.RemoveDuplicates([]models.Job{withSalary})
+			kept = append(kept, d.RemoveDuplicates([]models.Job{withDescription})...)
+			if len(kept) != 1 {
+				t.Fatalf("kept %d jobs, want 1", len(kept))
+			}
+
+			replaced, enriched := d.ResolveDuplicates(kept)
+			if replaced != 0 || enriched != tt.wantEnriched {
+				t.Errorf("replaced %d and enriched %d, want 0 and %d", replaced, enriched, tt.wantEnriched)
+			}
+			if !kept[0].Equal(tt.want) {
+				t.Errorf("kept %+v, want %+v", kept[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveSimilarJobsKeepsSameSourcePairs(t *testing.T) {
+	jobs := []models.Job{
+		testJob("RemoteOK", "Senior Go Developer", "Acme"),
+		testJob("RemoteOK", "Senior Golang Developer", "Acme"),
+		testJob("Remotive", "Senior Go Engineer", "Acme Inc"),
+	}
+
+	remaining, suppressed := NewDeduplicator().RemoveSimilarJobs(jobs, 0.7)
+
+	// Both RemoteOK postings are kept, and only the Remotive copy dropped
+	if got, want := titles(remaining), []string{"Senior Go Developer", "Senior Golang Developer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining = %q, want %q", got, want)
+	}
+	for _, job := range remaining {
+		if job.Source != "RemoteOK" {
+			t.Errorf("kept the %s copy, want only RemoteOK jobs", job.Source)
+		}
+	}
+	if len(suppressed) != 1 || suppressed[0].Job2.Source != "Remotive" {
+		t.Errorf("suppressed %+v, want only the Remotive copy", suppressed)
+	}
+}
+
+func TestRemoveSimilarJobsThresholds(t *testing.T) {
+	jobs := []models.Job{
+		testJob("RemoteOK", "Senior Backend Go Developer", "Globex"),
+		testJob("Remotive", "Senior Backend Go Engineer", "Globex"), // 0.8 similar
+		testJob("RemoteOK", "Senior Go Developer", "Acme"),
+		testJob("Remotive", "Senior Golang Developer", "Acme"), // 0.75 similar
+		testJob("RemoteOK", "Data Scientist", "Initech"),
+		testJob("Remotive", "Data Analyst", "Initech"), // about 0.67 similar
+	}
+
+	tests := []struct {
+		threshold      float64
+		wantSuppressed []string
+	}{
+		{0.6, []string{"Senior Backend Go Engineer", "Senior Golang Developer", "Data Analyst"}},
+		{0.7, []string{"Senior Backend Go Engineer", "Senior Golang Developer"}},
+		{0.78, []string{"Senior Backend Go Engineer"}},
+		{0.85, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.threshold), func(t *testing.T) {
+			remaining, suppressed := NewDeduplicator().RemoveSimilarJobs(jobs, tt.threshold)
+
+			var got []string
+			for _, pair := range suppressed {
+				got = append(got, pair.Job2.Title)
+			}
+			if !reflect.DeepEqual(got, tt.wantSuppressed) {
+				t.Errorf("suppressed %q, want %q", got, tt.wantSuppressed)
+			}
+			if len(remaining) != len(jobs)-len(tt.wantSuppressed) {
+				t.Errorf("kept %d jobs, want %d", len(remaining), len(jobs)-len(tt.wantSuppressed))
+			}
+		})
+	}
+}
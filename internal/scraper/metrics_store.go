@@ -0,0 +1,105 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxMetricsSnapshots is how many snapshots a MetricsStore keeps, dropping
+// the oldest
+const maxMetricsSnapshots = 100
+
+// MetricsSnapshot holds the scraper metrics at the end of a run
+type MetricsSnapshot struct {
+	Time    time.Time      `json:"time"`
+	Metrics ScraperMetrics `json:"metrics"`
+}
+
+// MetricsStore persists metrics snapshots to a JSON file, so that the
+// metrics of past runs survive restarts
+type MetricsStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewMetricsStore creates a metrics store writing to path. The file and its
+// directory are created on the first Save.
+func NewMetricsStore(path string) *MetricsStore {
+	return &MetricsStore{path: path}
+}
+
+// Save appends a snapshot of metrics taken at t, such as a copy returned by
+// GetMetrics, replacing the file atomically
+func (s *MetricsStore) Save(t time.Time, metrics *ScraperMetrics) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	snapshots = append(snapshots, &MetricsSnapshot{Time: t, Metrics: metrics.copy()})
+	if len(snapshots) > maxMetricsSnapshots {
+		snapshots = snapshots[len(snapshots)-maxMetricsSnapshots:]
+	}
+
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics snapshots: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to write metrics snapshots: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write metrics snapshots: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics snapshots: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics snapshots: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write metrics snapshots: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the most recent snapshot, or nil if none was saved yet
+func (s *MetricsStore) Latest() (*MetricsSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load()
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return snapshots[len(snapshots)-1], nil
+}
+
+// load reads the saved snapshots, oldest first. A missing file has none.
+func (s *MetricsStore) load() ([]*MetricsSnapshot, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics snapshots: %w", err)
+	}
+
+	var snapshots []*MetricsSnapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics snapshots %s: %w", s.path, err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,98 @@
+package scraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/storage"
+)
+
+func TestMetricsStoreSavesAndLoadsSnapshots(t *testing.T) {
+	store := NewMetricsStore(filepath.Join(t.TempDir(), "data", "metrics.json"))
+
+	latest, err := store.Latest()
+	if err != nil || latest != nil {
+		t.Fatalf("Latest before saving = %v, %v, want nil", latest, err)
+	}
+
+	first := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, total := range []int64{10, 25} {
+		metrics := ScraperMetrics{
+			TotalJobsScraped:  total,
+			TotalErrors:       int64(i),
+			SourcePerformance: map[string]SourceMetrics{"RemoteOK": {JobsScraped: total, LastError: "timeout"}},
+		}
+		if err := store.Save(first.Add(time.Duration(i)*time.Hour), &metrics); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	latest, err = store.Latest()
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if want := first.Add(time.Hour); !latest.Time.Equal(want) {
+		t.Errorf("latest snapshot taken at %v, want %v", latest.Time, want)
+	}
+	if latest.Metrics.TotalJobsScraped != 25 || latest.Metrics.TotalErrors != 1 {
+		t.Errorf("latest snapshot scraped %d with %d errors, want 25 and 1", latest.Metrics.TotalJobsScraped, latest.Metrics.TotalErrors)
+	}
+	if perf := latest.Metrics.SourcePerformance["RemoteOK"]; perf.JobsScraped != 25 || perf.LastError != "timeout" {
+		t.Errorf("RemoteOK snapshot = %+v, want 25 jobs and the last error", perf)
+	}
+}
+
+func TestMetricsStoreKeepsNewestSnapshots(t *testing.T) {
+	store := NewMetricsStore(filepath.Join(t.TempDir(), "metrics.json"))
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxMetricsSnapshots+5; i++ {
+		metrics := ScraperMetrics{TotalJobsScraped: int64(i)}
+		if err := store.Save(start.Add(time.Duration(i)*time.Minute), &metrics); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	snapshots, err := store.load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(snapshots) != maxMetricsSnapshots {
+		t.Fatalf("kept %d snapshots, want %d", len(snapshots), maxMetricsSnapshots)
+	}
+	if snapshots[0].Metrics.TotalJobsScraped != 5 {
+		t.Errorf("oldest kept snapshot is run %d, want 5", snapshots[0].Metrics.TotalJobsScraped)
+	}
+}
+
+func TestMetricsStoreRejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewMetricsStore(path).Latest(); err == nil {
+		t.Error("Latest of an invalid file succeeded, want an error")
+	}
+}
+
+func TestScrapeSavesMetricsSnapshot(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(), &fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 3)})
+	metricsStore := NewMetricsStore(filepath.Join(t.TempDir(), "metrics.json"))
+	ps.SetMetricsStore(metricsStore)
+
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+
+	latest, err := metricsStore.Latest()
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if latest == nil || latest.Metrics.TotalJobsScraped != 3 || latest.Metrics.TotalJobsSaved != 3 {
+		t.Errorf("snapshot after the run = %+v, want 3 jobs scraped and saved", latest)
+	}
+}
@@ -2,110 +2,586 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
 	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/notify"
 	"job-scraper-go/internal/scraper/sources"
 	"job-scraper-go/internal/storage"
 	"job-scraper-go/pkg/httpclient"
-	"log"
+	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 // PowerScraper is an enhanced scraper with concurrent processing and rate limiting
 type PowerScraper struct {
-	sourceManager *sources.SourceManager
-	storage       storage.Store
-	client        *httpclient.HttpClient
-	rateLimiter   *RateLimiter
-	deduplicator  *Deduplicator
-	retryConfig   RetryConfig
-	metrics       *ScraperMetrics
-	logger        *log.Logger
+	sourceManager    *sources.SourceManager
+	storage          storage.Store
+	client           *httpclient.HttpClient
+	rateLimiter      *RateLimiter
+	deduplicator     *Deduplicator
+	retryConfig      RetryConfig
+	saveRetryConfig  RetryConfig
+	saveConcurrency  int            // batch saves run at once, 1 or less saves sequentially
+	refetchMalformed bool           // fetch once more when a JSON response cannot be parsed
+	random           func() float64 // source of backoff jitter in [0, 1)
+	options          Options
+	validation       string
+	notifier         notify.Notifier
+	state            *ScrapeState
+	metricsStore     *MetricsStore
+	onJobsScraped    JobsScrapedFunc
+	unsavedSources   map[string]bool // sources whose last run left jobs unsaved
+	unsavedMu        sync.Mutex
+	metrics          *ScraperMetrics
+	logger           *logging.Logger
 }
 
+// ErrTooFewJobs is the error of a fetch with fewer jobs than the source's
+// MinExpectedJobs, when the source is configured to fail such runs
+var ErrTooFewJobs = errors.New("too few jobs fetched")
+
+// JobsScrapedFunc observes the unique jobs scraped from a source
+type JobsScrapedFunc func(source string, jobs []models.Job)
+
 // RetryConfig defines retry behavior
 type RetryConfig struct {
 	MaxRetries    int
 	InitialDelay  time.Duration
 	MaxDelay      time.Duration
 	BackoffFactor float64
+	Jitter        bool // randomize each delay between half and all of its value
+}
+
+// NewRetryConfig builds a RetryConfig from the scraper configuration
+func NewRetryConfig(cfg config.ScraperConfig) RetryConfig {
+	return RetryConfig{
+		MaxRetries:    cfg.RetryAttempts,
+		InitialDelay:  cfg.RetryDelay.Duration,
+		MaxDelay:      cfg.MaxRetryDelay.Duration,
+		BackoffFactor: cfg.BackoffFactor,
+		Jitter:        cfg.RetryJitter,
+	}
+}
+
+// NewSaveRetryConfig builds the RetryConfig for storage saves from the
+// scraper configuration, sharing the fetch retry delays
+func NewSaveRetryConfig(cfg config.ScraperConfig) RetryConfig {
+	retryConfig := NewRetryConfig(cfg)
+	retryConfig.MaxRetries = cfg.SaveRetryAttempts
+	return retryConfig
+}
+
+// NewHTTPClient builds the HTTP client used by sources from the scraper configuration
+func NewHTTPClient(cfg config.ScraperConfig) (*httpclient.HttpClient, error) {
+	client := httpclient.NewHttpClient(cfg.RequestTimeout.Duration)
+	client.SetMaxResponseBytes(int64(cfg.MaxResponseBytes))
+	client.SetConditionalRequests(cfg.ConditionalFetch)
+	client.SetTransportOptions(httpclient.TransportOptions{
+		MaxIdleConns:        cfg.Transport.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.Transport.MaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.Transport.IdleConnTimeout.Duration,
+		DisableKeepAlives:   cfg.Transport.DisableKeepAlives,
+	})
+	if err := client.SetProxy(cfg.ProxyURL); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// WithScrapeTimeout returns the context of a whole scraping run, cancelled
+// after timeout or, when timeout is zero, only along with ctx
+func WithScrapeTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Options holds optional scraping behavior
+type Options struct {
+	// SimilarityThreshold enables near-duplicate suppression across sources
+	// when greater than zero (0.0 to 1.0)
+	SimilarityThreshold float64
+
+	// MaxJobs caps the number of jobs kept from each source per run, applied
+	// before deduplication and saving. Zero means unlimited.
+	MaxJobs int
+
+	// PostedAfter drops jobs posted before this time when set
+	PostedAfter time.Time
+
+	// DropUndated drops jobs without a posted date when PostedAfter is set
+	DropUndated bool
+
+	// DryRun fetches, deduplicates and records metrics without saving jobs,
+	// sending notifications or advancing the scrape state
+	DryRun bool
+
+	// Sources restricts ScrapeAllSources and ScrapeByCategory to these
+	// sources, named as in the registry or by display name, e.g. "remoteok"
+	// or "RemoteOK". Like ScrapeSource, listed sources are scraped even when
+	// disabled. Empty means every enabled source.
+	Sources []string
 }
 
 // ScraperMetrics tracks scraper performance
 type ScraperMetrics struct {
-	TotalJobsScraped  int64
-	TotalJobsSaved    int64
-	TotalDuplicates   int64
-	TotalErrors       int64
-	ScrapingDuration  time.Duration
-	SourcePerformance map[string]SourceMetrics
-	mu                sync.RWMutex
+	TotalJobsScraped       int64
+	TotalJobsSaved         int64
+	TotalDuplicates        int64
+	WithinSourceDuplicates int64 // duplicates of a job already seen from the same source
+	CrossSourceDuplicates  int64 // duplicates of a job first seen from another source
+	TotalErrors            int64
+	SuspiciousRuns         int64 // successful fetches of fewer jobs than a source's MinExpectedJobs
+	ScrapingDuration       time.Duration
+	SourcePerformance      map[string]SourceMetrics
+	CategoryCounts         map[string]int64 // jobs found per category by ScrapeByCategory
+	mu                     sync.RWMutex
 }
 
 // SourceMetrics tracks performance per source
 type SourceMetrics struct {
-	JobsScraped  int64
-	JobsSaved    int64
-	Duplicates   int64
-	Errors       int64
-	ResponseTime time.Duration
-	LastScraped  time.Time
+	JobsScraped           int64
+	JobsSaved             int64
+	Duplicates            int64
+	CrossSourceDuplicates int64 // of Duplicates, those first seen from another source
+	Errors                int64
+	SuspiciousRuns        int64 // fetches of fewer jobs than MinExpectedJobs
+	ResponseTime          time.Duration
+	LastScraped           time.Time
+	LastError             string    // error of the last failed scrape, after retries
+	LastErrorTime         time.Time // when the last scrape failed
+
+	Requests            int64         // scrapes attempted, counting retries of one scrape once
+	Successes           int64         // scrapes that succeeded, possibly after retries
+	AverageResponseTime time.Duration // moving average of the scrape durations
+}
+
+// responseTimeWeight is the weight of the newest duration in the moving
+// average, so that a degrading source shows within a few runs
+const responseTimeWeight = 0.3
+
+// record adds the outcome of a scrape to the running totals
+func (m *SourceMetrics) record(duration time.Duration, success bool) {
+	if m.Requests == 0 {
+		m.AverageResponseTime = duration
+	} else {
+		m.AverageResponseTime = time.Duration(responseTimeWeight*float64(duration) +
+			(1-responseTimeWeight)*float64(m.AverageResponseTime))
+	}
+
+	m.Requests++
+	if success {
+		m.Successes++
+	}
+}
+
+// SuccessRate returns the fraction of scrapes that succeeded, or zero before
+// the first scrape
+func (m SourceMetrics) SuccessRate() float64 {
+	if m.Requests == 0 {
+		return 0
+	}
+	return float64(m.Successes) / float64(m.Requests)
+}
+
+// AvgResponseTime returns the moving average scrape duration
+func (m SourceMetrics) AvgResponseTime() time.Duration {
+	return m.AverageResponseTime
 }
 
 // NewPowerScraper creates a new enhanced scraper
-func NewPowerScraper(storage storage.Store, client *httpclient.HttpClient, logger *log.Logger) *PowerScraper {
+func NewPowerScraper(storage storage.Store, client *httpclient.HttpClient, logger *logging.Logger) *PowerScraper {
 	return &PowerScraper{
 		sourceManager: sources.NewSourceManager(),
 		storage:       storage,
 		client:        client,
 		rateLimiter:   NewRateLimiter(),
 		deduplicator:  NewDeduplicator(),
+		validation:    models.ValidationBasic,
 		retryConfig: RetryConfig{
 			MaxRetries:    3,
 			InitialDelay:  1 * time.Second,
 			MaxDelay:      30 * time.Second,
 			BackoffFactor: 2.0,
+			Jitter:        true,
 		},
+		saveRetryConfig: RetryConfig{
+			MaxRetries:    2,
+			InitialDelay:  1 * time.Second,
+			MaxDelay:      30 * time.Second,
+			BackoffFactor: 2.0,
+			Jitter:        true,
+		},
+		random:         rand.Float64,
+		unsavedSources: make(map[string]bool),
 		metrics: &ScraperMetrics{
 			SourcePerformance: make(map[string]SourceMetrics),
+			CategoryCounts:    make(map[string]int64),
 		},
 		logger: logger,
 	}
 }
 
-// InitializeSources sets up all available job sources
-func (ps *PowerScraper) InitializeSources() {
-	// Register RemoteOK
-	remoteOK := sources.NewRemoteOKSource(ps.client)
-	ps.sourceManager.RegisterSource(remoteOK, sources.JobSourceConfig{
-		Enabled:   true,
-		RateLimit: remoteOK.GetRateLimit(),
-	})
+// SetRetryConfig overrides the default retry behavior
+func (ps *PowerScraper) SetRetryConfig(retryConfig RetryConfig) {
+	ps.retryConfig = retryConfig
+}
+
+// SetSaveRetryConfig overrides how transient storage save failures are retried
+func (ps *PowerScraper) SetSaveRetryConfig(retryConfig RetryConfig) {
+	ps.saveRetryConfig = retryConfig
+}
+
+// SetSaveConcurrency sets how many batches of jobs are saved to storage at
+// once. The default of 1 saves them sequentially.
+func (ps *PowerScraper) SetSaveConcurrency(n int) {
+	ps.saveConcurrency = n
+}
+
+// SetRefetchMalformedJSON sets whether a source whose JSON response cannot be
+// parsed is fetched again at once, outside the retry backoff, before the
+// fetch counts as failed
+func (ps *PowerScraper) SetRefetchMalformedJSON(refetch bool) {
+	ps.refetchMalformed = refetch
+}
+
+// SetMergeDuplicates sets whether duplicates enrich the copy of the job kept,
+// filling its empty fields such as the salary or description, before it is
+// saved
+func (ps *PowerScraper) SetMergeDuplicates(merge bool) {
+	ps.deduplicator.SetMerge(merge)
+}
 
-	// Register Remotive
-	remotive := sources.NewRemotiveSource(ps.client)
-	ps.sourceManager.RegisterSource(remotive, sources.JobSourceConfig{
-		Enabled:   true,
-		RateLimit: remotive.GetRateLimit(),
+// SetSourcePriority sets the sources whose copy of a job found by several
+// sources is kept, highest priority first
+func (ps *PowerScraper) SetSourcePriority(sources []string) {
+	ps.deduplicator.SetSourcePriority(sources)
+}
+
+// SetValidationLevel sets how strictly jobs are validated before saving
+func (ps *PowerScraper) SetValidationLevel(level string) {
+	ps.validation = level
+}
+
+// SetOptions configures optional scraping behavior
+func (ps *PowerScraper) SetOptions(options Options) {
+	ps.options = options
+}
+
+// SetNotifier sets the notifier alerted about newly discovered jobs
+func (ps *PowerScraper) SetNotifier(notifier notify.Notifier) {
+	ps.notifier = notifier
+}
+
+// SetScrapeState enables incremental scraping: each run only processes jobs
+// posted after the source's last successful scrape recorded in the state
+func (ps *PowerScraper) SetScrapeState(state *ScrapeState) {
+	ps.state = state
+}
+
+// SetMetricsStore enables saving a snapshot of the metrics after each run,
+// except dry runs
+func (ps *PowerScraper) SetMetricsStore(store *MetricsStore) {
+	ps.metricsStore = store
+}
+
+// OnJobsScraped registers a callback invoked once per successfully scraped
+// source with its jobs after deduplication and before they are saved. It is
+// called from a single goroutine, so it need not be safe for concurrent use,
+// but it blocks the run and must not modify the jobs.
+func (ps *PowerScraper) OnJobsScraped(fn JobsScrapedFunc) {
+	ps.onJobsScraped = fn
+}
+
+// SeedDeduplicator marks every stored job as seen, so that postings saved by
+// earlier runs are dropped as duplicates instead of being saved again. This
+// loads the whole jobs table, so it is meant to run once at startup.
+func (ps *PowerScraper) SeedDeduplicator(ctx context.Context) error {
+	jobs, err := ps.storage.GetJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load stored jobs: %w", err)
+	}
+
+	seeded := ps.deduplicator.Seed(jobs)
+	ps.logger.Infof("Seeded deduplicator with %d stored jobs", seeded)
+	return nil
+}
+
+// InitializeSources sets up all registered job sources and configured feeds
+func (ps *PowerScraper) InitializeSources(sourcesConfig config.SourcesConfig) {
+	sourceConfigs := sourcesConfig.ByName()
+
+	for _, name := range sources.FactoryNames() {
+		sourceConfig := sourceConfigs[name]
+		source, err := NewSource(name, ps.client, sourceConfig)
+		if err != nil {
+			ps.logger.Errorf("Failed to create source %s: %v", name, err)
+			continue
+		}
+
+		if header, value := sourceConfig.Auth.HeaderValue(); header != "" {
+			ps.logger.Debugf("Source %s authenticates with %s: %s", name, header, config.MaskSecret(value))
+		}
+
+		// Fall back to the source's own rate limit when none is configured
+		rateLimit := sourceConfig.RateLimit
+		if rateLimit <= 0 {
+			rateLimit = source.GetRateLimit()
+		}
+
+		ps.sourceManager.RegisterSource(source, sources.JobSourceConfig{
+			Enabled:          sourceConfig.Enabled,
+			RateLimit:        rateLimit,
+			RateWindow:       sourceConfig.RateWindow.Duration,
+			Burst:            sourceConfig.Burst,
+			Timeout:          sourceConfig.Timeout.Duration,
+			RequestDelay:     sourceConfig.RequestDelay.Duration,
+			MinExpectedJobs:  sourceConfig.MinExpectedJobs,
+			FailBelowMinJobs: sourceConfig.FailBelowMinJobs,
+			SearchTerms:      sourceConfig.SearchTerms,
+			Locations:        sourceConfig.Locations,
+			JobTypes:         sourceConfig.JobTypes,
+		})
+	}
+
+	// Register generic RSS/Atom feeds
+	for _, feed := range sourcesConfig.Feeds {
+		feedSource := NewFeedSource(ps.client, feed)
+		ps.sourceManager.RegisterSource(feedSource, sources.JobSourceConfig{
+			Enabled:    feed.Enabled,
+			RateLimit:  feedSource.GetRateLimit(),
+			RateWindow: feed.RateWindow.Duration,
+			Burst:      feed.Burst,
+			Timeout:    feed.Timeout.Duration,
+		})
+	}
+
+	ps.logger.Infof("Initialized %d job sources", len(ps.sourceManager.GetEnabledSources()))
+}
+
+// NewSource builds a registered source and applies its configuration
+func NewSource(name string, client *httpclient.HttpClient, sourceConfig config.SourceConfig) (sources.JobSource, error) {
+	source, err := sources.BuildSource(name, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if searchable, ok := source.(sources.SearchConfigurable); ok {
+		searchable.SetSearchTerms(sourceConfig.SearchTerms, sourceConfig.SearchMode)
+	}
+	if locatable, ok := source.(sources.LocationConfigurable); ok {
+		locatable.SetLocations(sourceConfig.Locations)
+	}
+	if htmlSource, ok := source.(sources.HTMLConfigurable); ok {
+		htmlSource.SetPreserveHTML(sourceConfig.PreserveHTML)
+	}
+	if delayable, ok := source.(sources.DelayConfigurable); ok {
+		delayable.SetRequestDelay(sourceConfig.RequestDelay.Duration)
+	}
+	if authenticated, ok := source.(sources.AuthConfigurable); ok {
+		authenticated.SetAuth(sourceConfig.Auth.HeaderValue())
+	}
+
+	return source, nil
+}
+
+// NewFeedSource builds a generic RSS/Atom source from its configuration
+func NewFeedSource(client *httpclient.HttpClient, feed config.FeedConfig) *sources.FeedSource {
+	return sources.NewFeedSource(client, sources.FeedConfig{
+		Name:      feed.Name,
+		URL:       feed.URL,
+		RateLimit: feed.RateLimit,
+		Fields:    sources.FeedFieldMapping(feed.Fields),
 	})
+}
 
-	ps.logger.Printf("Initialized %d job sources", len(ps.sourceManager.GetEnabledSources()))
+// RunReport summarizes a scraping run across all enabled sources
+type RunReport struct {
+	Succeeded  []string
+	Failed     map[string]error
+	SavedCount int
+	Jobs       []models.Job // unique jobs from the successful sources
 }
 
-// ScrapeAllSources scrapes jobs from all enabled sources concurrently
-func (ps *PowerScraper) ScrapeAllSources(ctx context.Context) error {
+// Summary describes the run in a single line
+func (r RunReport) Summary() string {
+	summary := fmt.Sprintf("%d sources succeeded", len(r.Succeeded))
+	if len(r.Succeeded) > 0 {
+		summary += " (" + strings.Join(r.Succeeded, ", ") + ")"
+	}
+
+	summary += fmt.Sprintf(", %d failed", len(r.Failed))
+	if len(r.Failed) > 0 {
+		names := make([]string, 0, len(r.Failed))
+		for name := range r.Failed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		failures := make([]string, len(names))
+		for i, name := range names {
+			failures[i] = fmt.Sprintf("%s: %v", name, r.Failed[name])
+		}
+		summary += " (" + strings.Join(failures, "; ") + ")"
+	}
+
+	return summary + fmt.Sprintf(", %d jobs saved", r.SavedCount)
+}
+
+// ScrapeAllSources scrapes jobs from all enabled sources concurrently and
+// saves the jobs of the sources that succeeded even when others fail. The
+// report lists which sources succeeded and failed and the unique jobs found.
+func (ps *PowerScraper) ScrapeAllSources(ctx context.Context) (RunReport, error) {
+	return ps.runSources(ctx, ps.scrapeSource, true)
+}
+
+// ScrapeByCategory scrapes jobs of the given categories from all enabled
+// sources concurrently. Sources with category feeds are asked for each
+// category, and the jobs of other sources are filtered by their mapped job
+// category. The merged jobs are deduplicated and saved like ScrapeAllSources,
+// and the jobs found per category are recorded in the metrics.
+func (ps *PowerScraper) ScrapeByCategory(ctx context.Context, categories []string) (RunReport, error) {
+	if len(categories) == 0 {
+		return RunReport{Failed: make(map[string]error)}, fmt.Errorf("no categories given")
+	}
+
+	scrape := func(ctx context.Context, sourceName string, source sources.JobSource) ScraperResult {
+		sourceConfig, _ := ps.sourceManager.GetSourceConfig(sourceName)
+		// A category holds only part of a source's jobs, so the job count is not checked
+		return ps.scrapeSourceWith(ctx, sourceName, ps.categoryFetcher(source, categories, sourceConfig.RequestDelay), false)
+	}
+
+	// A category run only sees part of each source, so it must not advance
+	// the incremental scraping watermarks
+	return ps.runSources(ctx, scrape, false)
+}
+
+// categoryFetcher returns a fetch function collecting the jobs of the given
+// categories from a source, pausing for delay between its requests, and
+// recording how many were found per category
+func (ps *PowerScraper) categoryFetcher(source sources.JobSource, categories []string, delay time.Duration) fetchFunc {
+	return func(ctx context.Context) ([]models.Job, error) {
+		counts := make(map[string]int64)
+		var jobs, allJobs []models.Job
+		fetchedAll := false
+		requested := false
+
+		for _, category := range categories {
+			category = strings.TrimSpace(category)
+			var found []models.Job
+			var err error
+
+			categorySource, ok := source.(sources.CategorySource)
+			if ok {
+				if requested {
+					if err := sources.SleepContext(ctx, delay); err != nil {
+						return nil, err
+					}
+				}
+				requested = true
+				found, err = categorySource.FetchJobsByCategory(ctx, category)
+			}
+			if !ok || errors.Is(err, sources.ErrUnknownCategory) {
+				// Fall back to filtering the full feed by the mapped category
+				if !fetchedAll {
+					if allJobs, err = source.FetchJobs(ctx); err != nil {
+						return nil, err
+					}
+					fetchedAll = true
+				}
+				found, err = FilterByCategory(allJobs, category), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			counts[category] += int64(len(found))
+			jobs = append(jobs, found...)
+		}
+
+		ps.metrics.mu.Lock()
+		for category, count := range counts {
+			ps.metrics.CategoryCounts[category] += count
+		}
+		ps.metrics.mu.Unlock()
+
+		return jobs, nil
+	}
+}
+
+// FilterByCategory returns the jobs whose job category matches a category
+// name or slug, ignoring case and separators, so "devops" matches "DevOps",
+// "software-dev" matches "Software Dev" and "backend" matches "Backend Development"
+func FilterByCategory(jobs []models.Job, category string) []models.Job {
+	target := normalizeCategory(category)
+	if target == "" {
+		return nil
+	}
+
+	var matching []models.Job
+	for _, job := range jobs {
+		jobCategory := normalizeCategory(job.JobCategory)
+		if jobCategory == target || strings.HasPrefix(jobCategory, target+" ") {
+			matching = append(matching, job)
+		}
+	}
+	return matching
+}
+
+// normalizeCategory lowercases a category and turns separators into single spaces
+func normalizeCategory(category string) string {
+	category = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(category))
+	return strings.Join(strings.Fields(category), " ")
+}
+
+// runSourceSet returns the sources of a run: those listed in Options.Sources,
+// or else every enabled source
+func (ps *PowerScraper) runSourceSet() (map[string]sources.JobSource, error) {
+	if len(ps.options.Sources) == 0 {
+		enabledSources := ps.sourceManager.GetEnabledSources()
+		if len(enabledSources) == 0 {
+			return nil, fmt.Errorf("no enabled sources found")
+		}
+		return enabledSources, nil
+	}
+
+	selected := make(map[string]sources.JobSource)
+	for _, name := range ps.options.Sources {
+		sourceName, source, ok := ps.findSource(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown source: %s", name)
+		}
+		selected[sourceName] = source
+	}
+	return selected, nil
+}
+
+// runSources scrapes the sources of the run concurrently with the given scrape
+// function, then deduplicates, saves, and reports the merged jobs. The scrape
+// state is only updated when updateState is true.
+func (ps *PowerScraper) runSources(ctx context.Context, scrape func(context.Context, string, sources.JobSource) ScraperResult, updateState bool) (RunReport, error) {
+	report := RunReport{Failed: make(map[string]error)}
+
+	enabledSources, err := ps.runSourceSet()
+	if err != nil {
+		return report, err
+	}
+
 	startTime := time.Now()
 	defer func() {
 		ps.metrics.mu.Lock()
 		ps.metrics.ScrapingDuration = time.Since(startTime)
 		ps.metrics.mu.Unlock()
-	}()
 
-	enabledSources := ps.sourceManager.GetEnabledSources()
-	if len(enabledSources) == 0 {
-		return fmt.Errorf("no enabled sources found")
-	}
+		ps.saveMetricsSnapshot()
+	}()
 
 	// Channel to collect results from all sources
 	resultsChan := make(chan ScraperResult, len(enabledSources))
@@ -123,7 +599,7 @@ func (ps *PowerScraper) ScrapeAllSources(ctx context.Context) error {
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			result := ps.scrapeSource(ctx, sourceName, jobSource)
+			result := scrape(ctx, sourceName, jobSource)
 			resultsChan <- result
 		}(name, source)
 	}
@@ -138,69 +614,386 @@ func (ps *PowerScraper) ScrapeAllSources(ctx context.Context) error {
 	var allJobs []models.Job
 	for result := range resultsChan {
 		if result.Error != nil {
-			ps.metrics.mu.Lock()
-			ps.metrics.TotalErrors++
-			ps.metrics.mu.Unlock()
-			ps.logger.Printf("Error scraping %s: %v", result.Source, result.Error)
+			ps.recordError(result)
+			report.Failed[result.Source] = result.Error
 			continue
 		}
 
-		// Deduplicate jobs
-		uniqueJobs := ps.deduplicator.RemoveDuplicates(result.Jobs)
-		duplicates := len(result.Jobs) - len(uniqueJobs)
+		allJobs = append(allJobs, ps.processResult(result)...)
+		report.Succeeded = append(report.Succeeded, result.Source)
+	}
+	sort.Strings(report.Succeeded)
+	ps.resolveDuplicates(allJobs)
+
+	// Suppress near-duplicates across the merged job list
+	if ps.options.SimilarityThreshold > 0 {
+		allJobs = ps.suppressSimilarJobs(allJobs)
+	}
+
+	// Save all unique jobs to storage
+	var saveResult SaveResult
+	if len(allJobs) > 0 && ps.options.DryRun {
+		ps.logger.Infof("Dry run: would save %d jobs", len(allJobs))
+	} else if len(allJobs) > 0 {
+		saveResult, err = ps.saveJobs(ctx, allJobs)
+		ps.recordSaveResult(saveResult)
+		report.SavedCount = saveResult.Saved
+		if err != nil {
+			ps.recordUnsavedJobs(nil, allJobs)
+			return report, fmt.Errorf("failed to save jobs: %w", err)
+		}
+
+		ps.notifyNewJobs(ctx, saveResult.SavedJobs)
+	}
+	report.Jobs = allJobs
+
+	if updateState && !ps.options.DryRun {
+		ps.recordUnsavedJobs(report.Succeeded, saveResult.UnsavedJobs)
+		ps.updateScrapeState(report.Succeeded, startTime, saveResult.UnsavedJobs)
+	} else if !ps.options.DryRun {
+		// A category run only sees part of each source, so its sources may
+		// still hold jobs left unsaved by an earlier run
+		ps.recordUnsavedJobs(nil, saveResult.UnsavedJobs)
+	}
+
+	// Read a snapshot, since the metrics are shared with concurrent readers
+	metrics := ps.GetMetrics()
+	ps.logger.Infof("Scraping completed: %d total jobs, %d saved, %d duplicates (%d within sources, %d across sources) in %v",
+		metrics.TotalJobsScraped, metrics.TotalJobsSaved, metrics.TotalDuplicates,
+		metrics.WithinSourceDuplicates, metrics.CrossSourceDuplicates, time.Since(startTime))
+
+	return report, nil
+}
+
+// notifyNewJobs alerts the notifier about jobs newly inserted into storage
+func (ps *PowerScraper) notifyNewJobs(ctx context.Context, jobs []models.Job) {
+	if ps.notifier == nil || len(jobs) == 0 {
+		return
+	}
+
+	if err := ps.notifier.Notify(ctx, jobs); err != nil {
+		ps.logger.Errorf("Failed to send notification for %d new jobs: %v", len(jobs), err)
+		return
+	}
+	ps.logger.Infof("Sent notification for %d new jobs", len(jobs))
+}
 
-		allJobs = append(allJobs, uniqueJobs...)
+// ScrapeSource scrapes a single registered source by name (case-insensitive),
+// applying the same rate limiting, retries, deduplication, saving, and metrics
+// recording as ScrapeAllSources, and notifies about the new jobs. The returned
+// result holds the unique jobs found and how many of them were newly saved;
+// jobs already stored, rejected by validation or failing to save are not
+// counted.
+func (ps *PowerScraper) ScrapeSource(ctx context.Context, name string) (ScraperResult, error) {
+	sourceName, source, ok := ps.findSource(name)
+	if !ok {
+		return ScraperResult{Source: name}, fmt.Errorf("unknown source: %s", name)
+	}
 
-		// Update metrics
+	startTime := time.Now()
+	defer func() {
 		ps.metrics.mu.Lock()
-		ps.metrics.TotalJobsScraped += int64(len(result.Jobs))
-		ps.metrics.TotalDuplicates += int64(duplicates)
-
-		sourceMetric := ps.metrics.SourcePerformance[result.Source]
-		sourceMetric.JobsScraped = int64(len(result.Jobs))
-		sourceMetric.Duplicates = int64(duplicates)
-		sourceMetric.ResponseTime = result.Duration
-		sourceMetric.LastScraped = time.Now()
-		ps.metrics.SourcePerformance[result.Source] = sourceMetric
+		ps.metrics.ScrapingDuration = time.Since(startTime)
 		ps.metrics.mu.Unlock()
 
-		ps.logger.Printf("Scraped %d jobs from %s (%d unique, %d duplicates) in %v",
-			len(result.Jobs), result.Source, len(uniqueJobs), duplicates, result.Duration)
+		ps.saveMetricsSnapshot()
+	}()
+
+	result := ps.scrapeSource(ctx, sourceName, source)
+	if result.Error != nil {
+		ps.recordError(result)
+		return result, fmt.Errorf("failed to scrape %s: %w", sourceName, result.Error)
 	}
 
-	// Save all unique jobs to storage
-	if len(allJobs) > 0 {
-		if err := ps.saveJobs(ctx, allJobs); err != nil {
-			return fmt.Errorf("failed to save jobs: %w", err)
+	result.Jobs = ps.processResult(result)
+	ps.resolveDuplicates(result.Jobs)
+
+	if ps.options.DryRun {
+		ps.logger.Infof("Dry run: would save %d jobs from %s", len(result.Jobs), sourceName)
+		return result, nil
+	}
+
+	var saveResult SaveResult
+	if len(result.Jobs) > 0 {
+		var err error
+		saveResult, err = ps.saveJobs(ctx, result.Jobs)
+		ps.recordSaveResult(saveResult)
+		result.SavedCount = saveResult.Saved
+		if err != nil {
+			ps.recordUnsavedJobs(nil, result.Jobs)
+			return result, fmt.Errorf("failed to save jobs: %w", err)
 		}
 
-		ps.metrics.mu.Lock()
-		ps.metrics.TotalJobsSaved = int64(len(allJobs))
-		ps.metrics.mu.Unlock()
+		ps.notifyNewJobs(ctx, saveResult.SavedJobs)
 	}
 
-	ps.logger.Printf("Scraping completed: %d total jobs, %d saved, %d duplicates in %v",
-		ps.metrics.TotalJobsScraped, ps.metrics.TotalJobsSaved,
-		ps.metrics.TotalDuplicates, ps.metrics.ScrapingDuration)
+	ps.recordUnsavedJobs([]string{sourceName}, saveResult.UnsavedJobs)
+	ps.updateScrapeState([]string{sourceName}, startTime, saveResult.UnsavedJobs)
+
+	return result, nil
+}
 
+// resolveDuplicates replaces jobs by copies from higher-priority sources and
+// enriches them with the fields of their duplicates dropped during the run
+func (ps *PowerScraper) resolveDuplicates(jobs []models.Job) {
+	replaced, enriched := ps.deduplicator.ResolveDuplicates(jobs)
+	if replaced > 0 {
+		ps.logger.Infof("Kept the copy from a higher-priority source of %d jobs", replaced)
+	}
+	if enriched > 0 {
+		ps.logger.Infof("Filled in missing fields of %d jobs from their duplicates", enriched)
+	}
+}
+
+// recordUnsavedJobs makes the next run fetch and save again the jobs left
+// unsaved: the deduplicator forgets them, and the next fetch of their sources
+// skips the conditional cache, since an unchanged feed would answer 304 Not
+// Modified. The sources of sourceNames without unsaved jobs are fetched
+// conditionally again.
+func (ps *PowerScraper) recordUnsavedJobs(sourceNames []string, unsaved []models.Job) {
+	ps.deduplicator.Forget(unsaved)
+
+	ps.unsavedMu.Lock()
+	defer ps.unsavedMu.Unlock()
+
+	for _, name := range sourceNames {
+		delete(ps.unsavedSources, name)
+	}
+	for _, job := range unsaved {
+		ps.unsavedSources[job.Source] = true
+	}
+}
+
+// fetchContext returns the context to fetch a source with, which sends no
+// cache validators when the source's last run left jobs unsaved
+func (ps *PowerScraper) fetchContext(ctx context.Context, sourceName string) context.Context {
+	ps.unsavedMu.Lock()
+	defer ps.unsavedMu.Unlock()
+
+	if ps.unsavedSources[sourceName] {
+		return httpclient.Unconditional(ctx)
+	}
+	return ctx
+}
+
+// updateScrapeState records the last scrape time of the sources scraped
+// successfully during a run: the start of the run when every job of the source
+// was saved, or else just before its oldest unsaved job, so that the next run
+// fetches that job again
+func (ps *PowerScraper) updateScrapeState(sourceNames []string, runStart time.Time, unsaved []models.Job) {
+	if ps.state == nil || len(sourceNames) == 0 {
+		return
+	}
+
+	lastScrapeTimes := make(map[string]time.Time, len(sourceNames))
+	for _, name := range sourceNames {
+		lastScrapeTimes[name] = runStart
+	}
+	for _, job := range unsaved {
+		// Undated jobs are kept by every run, so they need no watermark
+		lastScrape, ok := lastScrapeTimes[job.Source]
+		if !ok || job.PostedDate == nil {
+			continue
+		}
+		// Only jobs posted strictly after the last scrape time are kept
+		if before := job.PostedDate.Add(-time.Nanosecond); before.Before(lastScrape) {
+			lastScrapeTimes[job.Source] = before
+		}
+	}
+
+	for name, lastScrape := range lastScrapeTimes {
+		ps.state.SetLastScrapeTime(name, lastScrape)
+	}
+	if err := ps.state.Save(); err != nil {
+		ps.logger.Errorf("Failed to save scrape state: %v", err)
+	}
+}
+
+// saveMetricsSnapshot saves the metrics at the end of a run
+func (ps *PowerScraper) saveMetricsSnapshot() {
+	if ps.metricsStore == nil || ps.options.DryRun {
+		return
+	}
+
+	metrics := ps.GetMetrics()
+	if err := ps.metricsStore.Save(time.Now(), &metrics); err != nil {
+		ps.logger.Errorf("Failed to save metrics snapshot: %v", err)
+	}
+}
+
+// SetSourceEnabled enables or disables a source by case-insensitive name
+// without restarting; the change applies from the next scraping run
+func (ps *PowerScraper) SetSourceEnabled(name string, enabled bool) error {
+	sourceName, _, ok := ps.findSource(name)
+	if !ok {
+		return fmt.Errorf("unknown source: %s", name)
+	}
+
+	if err := ps.sourceManager.SetEnabled(sourceName, enabled); err != nil {
+		return err
+	}
+	ps.logger.Infof("Source %s enabled: %t", sourceName, enabled)
 	return nil
 }
 
+// SourceStates reports whether each registered source is enabled
+func (ps *PowerScraper) SourceStates() map[string]bool {
+	states := make(map[string]bool)
+	for name := range ps.sourceManager.GetSources() {
+		states[name] = ps.sourceManager.IsEnabled(name)
+	}
+	return states
+}
+
+// findSource looks up a registered source by case-insensitive display name,
+// registry name or alias
+func (ps *PowerScraper) findSource(name string) (string, sources.JobSource, bool) {
+	// Registry names and aliases such as "wework" map to the display name
+	if source, err := sources.BuildSource(name, nil); err == nil {
+		name = source.GetName()
+	}
+
+	for sourceName, source := range ps.sourceManager.GetSources() {
+		if strings.EqualFold(sourceName, name) {
+			return sourceName, source, true
+		}
+	}
+	return "", nil, false
+}
+
+// recordError records a failed scrape result in the metrics
+func (ps *PowerScraper) recordError(result ScraperResult) {
+	ps.metrics.mu.Lock()
+	ps.metrics.TotalErrors++
+	ps.metrics.mu.Unlock()
+	ps.logger.Errorf("Error scraping %s: %v", result.Source, result.Error)
+}
+
+// processResult deduplicates a successful scrape result, records its metrics,
+// passes the unique jobs to the OnJobsScraped callback and returns them
+func (ps *PowerScraper) processResult(result ScraperResult) []models.Job {
+	if !ps.options.PostedAfter.IsZero() {
+		recent := FilterPostedAfter(result.Jobs, ps.options.PostedAfter, !ps.options.DropUndated)
+		ps.logger.Debugf("Kept %d of %d jobs from %s posted after %s",
+			len(recent), len(result.Jobs), result.Source, ps.options.PostedAfter.Format(time.RFC3339))
+		result.Jobs = recent
+	}
+
+	if ps.state != nil {
+		if lastScrape, ok := ps.state.LastScrapeTime(result.Source); ok {
+			// Undated jobs are kept since their age is unknown
+			newJobs := FilterPostedAfter(result.Jobs, lastScrape, true)
+			ps.logger.Debugf("Kept %d of %d jobs from %s posted since the last scrape at %s",
+				len(newJobs), len(result.Jobs), result.Source, lastScrape.Format(time.RFC3339))
+			result.Jobs = newJobs
+		}
+	}
+
+	if ps.options.MaxJobs > 0 && len(result.Jobs) > ps.options.MaxJobs {
+		ps.logger.Infof("Limiting %s to %d of %d jobs", result.Source, ps.options.MaxJobs, len(result.Jobs))
+		result.Jobs = result.Jobs[:ps.options.MaxJobs]
+	}
+
+	uniqueJobs, stats := ps.deduplicator.RemoveDuplicatesWithStats(result.Jobs)
+	duplicates := stats.Total()
+
+	ps.metrics.mu.Lock()
+	ps.metrics.TotalJobsScraped += int64(len(result.Jobs))
+	ps.metrics.TotalDuplicates += int64(duplicates)
+	ps.metrics.WithinSourceDuplicates += int64(stats.WithinSource)
+	ps.metrics.CrossSourceDuplicates += int64(stats.CrossSource)
+
+	sourceMetric := ps.metrics.SourcePerformance[result.Source]
+	sourceMetric.JobsScraped = int64(len(result.Jobs))
+	sourceMetric.Duplicates = int64(duplicates)
+	sourceMetric.CrossSourceDuplicates = int64(stats.CrossSource)
+	sourceMetric.ResponseTime = result.Duration
+	sourceMetric.LastScraped = time.Now()
+	ps.metrics.SourcePerformance[result.Source] = sourceMetric
+	ps.metrics.mu.Unlock()
+
+	ps.logger.Infof("Scraped %d jobs from %s (%d unique, %d duplicates, %d of them from other sources) in %v",
+		len(result.Jobs), result.Source, len(uniqueJobs), duplicates, stats.CrossSource, result.Duration)
+
+	if ps.onJobsScraped != nil {
+		ps.onJobsScraped(result.Source, uniqueJobs)
+	}
+
+	return uniqueJobs
+}
+
+// FilterPostedAfter returns the jobs posted after the cutoff. Jobs without a
+// posted date are kept only when keepUndated is true.
+func FilterPostedAfter(jobs []models.Job, cutoff time.Time, keepUndated bool) []models.Job {
+	var recent []models.Job
+	for _, job := range jobs {
+		if job.PostedDate == nil {
+			if keepUndated {
+				recent = append(recent, job)
+			}
+			continue
+		}
+		if job.PostedDate.After(cutoff) {
+			recent = append(recent, job)
+		}
+	}
+	return recent
+}
+
+// suppressSimilarJobs removes near-duplicate jobs of different sources and
+// records them as cross-source duplicates
+func (ps *PowerScraper) suppressSimilarJobs(jobs []models.Job) []models.Job {
+	remaining, suppressed := ps.deduplicator.RemoveSimilarJobs(jobs, ps.options.SimilarityThreshold)
+	if len(suppressed) == 0 {
+		return jobs
+	}
+
+	ps.metrics.mu.Lock()
+	ps.metrics.TotalDuplicates += int64(len(suppressed))
+	ps.metrics.CrossSourceDuplicates += int64(len(suppressed))
+	for _, pair := range suppressed {
+		sourceMetric := ps.metrics.SourcePerformance[pair.Job2.Source]
+		sourceMetric.Duplicates++
+		sourceMetric.CrossSourceDuplicates++
+		ps.metrics.SourcePerformance[pair.Job2.Source] = sourceMetric
+	}
+	ps.metrics.mu.Unlock()
+
+	for _, pair := range suppressed {
+		ps.logger.Infof("Suppressed near-duplicate %q at %s (%s): %.2f similar to %q at %s (%s)",
+			pair.Job2.Title, pair.Job2.Company, pair.Job2.Source, pair.Similarity,
+			pair.Job1.Title, pair.Job1.Company, pair.Job1.Source)
+	}
+
+	return remaining
+}
+
 // ScraperResult holds the result from scraping a single source
 type ScraperResult struct {
-	Source   string
-	Jobs     []models.Job
-	Error    error
-	Duration time.Duration
+	Source     string
+	Jobs       []models.Job
+	Error      error
+	Duration   time.Duration
+	SavedCount int // jobs newly inserted into storage, set by ScrapeSource
 }
 
 // scrapeSource scrapes jobs from a single source with rate limiting and retries
 func (ps *PowerScraper) scrapeSource(ctx context.Context, sourceName string, source sources.JobSource) ScraperResult {
+	return ps.scrapeSourceWith(ctx, sourceName, source.FetchJobs, true)
+}
+
+// fetchFunc fetches jobs from a source
+type fetchFunc func(ctx context.Context) ([]models.Job, error)
+
+// scrapeSourceWith fetches jobs from a source with rate limiting and retries
+// using the given fetch function. With checkCount, a fetch of fewer jobs than
+// the source's MinExpectedJobs is flagged as suspicious.
+func (ps *PowerScraper) scrapeSourceWith(ctx context.Context, sourceName string, fetch fetchFunc, checkCount bool) ScraperResult {
 	startTime := time.Now()
 
 	// Apply rate limiting
 	config, _ := ps.sourceManager.GetSourceConfig(sourceName)
-	if err := ps.rateLimiter.Wait(ctx, sourceName, config.RateLimit); err != nil {
+	if err := ps.rateLimiter.WaitWithBurst(ctx, sourceName, config.RateLimit, config.RateWindow, config.Burst); err != nil {
 		return ScraperResult{
 			Source:   sourceName,
 			Error:    fmt.Errorf("rate limit error: %w", err),
@@ -211,11 +1004,13 @@ func (ps *PowerScraper) scrapeSource(ctx context.Context, sourceName string, sou
 	// Attempt scraping with retries
 	var jobs []models.Job
 	var lastError error
+	refetched := false
+	fetchCtx := ps.fetchContext(ctx, sourceName)
 
 	for attempt := 0; attempt <= ps.retryConfig.MaxRetries; attempt++ {
 		if attempt > 0 {
-			delay := ps.calculateBackoffDelay(attempt)
-			ps.logger.Printf("Retrying %s (attempt %d/%d) after %v",
+			delay := ps.retryConfig.backoffDelay(attempt, ps.random)
+			ps.logger.Warnf("Retrying %s (attempt %d/%d) after %v",
 				sourceName, attempt+1, ps.retryConfig.MaxRetries+1, delay)
 
 			select {
@@ -229,76 +1024,406 @@ func (ps *PowerScraper) scrapeSource(ctx context.Context, sourceName string, sou
 			}
 		}
 
-		jobs, lastError = source.FetchJobs()
+		jobs, lastError = ps.fetchJobs(fetchCtx, fetch, config.Timeout)
+		if errors.Is(lastError, sources.ErrMalformedJSON) && ps.refetchMalformed && !refetched {
+			// A truncated body usually parses when fetched again. The fresh
+			// fetch skips the conditional cache, which has already recorded
+			// the broken response's validators.
+			refetched = true
+			ps.logger.Warnf("Fetching %s again after a malformed response: %v", sourceName, lastError)
+			jobs, lastError = ps.fetchJobs(httpclient.Unconditional(ctx), fetch, config.Timeout)
+		}
 		if lastError == nil {
+			ps.validateJobTypes(sourceName, jobs)
 			break
 		}
 
-		ps.logger.Printf("Attempt %d failed for %s: %v", attempt+1, sourceName, lastError)
+		ps.logger.Warnf("Attempt %d failed for %s: %v", attempt+1, sourceName, lastError)
+		if !sources.IsRetryableError(lastError) {
+			break // a permanent failure, such as a 404, would only fail again
+		}
+	}
+
+	duration := time.Since(startTime)
+
+	suspicious := false
+	if lastError == nil && checkCount {
+		suspicious, lastError = ps.checkJobCount(sourceName, config, len(jobs))
 	}
 
+	ps.metrics.mu.Lock()
+	sourceMetric := ps.metrics.SourcePerformance[sourceName]
+	if suspicious {
+		sourceMetric.SuspiciousRuns++
+		ps.metrics.SuspiciousRuns++
+	}
+	sourceMetric.record(duration, lastError == nil)
 	if lastError != nil {
-		ps.metrics.mu.Lock()
-		sourceMetric := ps.metrics.SourcePerformance[sourceName]
 		sourceMetric.Errors++
-		ps.metrics.SourcePerformance[sourceName] = sourceMetric
-		ps.metrics.mu.Unlock()
+		sourceMetric.LastError = lastError.Error()
+		sourceMetric.LastErrorTime = time.Now()
 	}
+	ps.metrics.SourcePerformance[sourceName] = sourceMetric
+	ps.metrics.mu.Unlock()
 
 	return ScraperResult{
 		Source:   sourceName,
 		Jobs:     jobs,
 		Error:    lastError,
-		Duration: time.Since(startTime),
+		Duration: duration,
+	}
+}
+
+// checkJobCount reports whether a successful fetch of count jobs is suspicious,
+// having fewer than the source's MinExpectedJobs, which is a sign of being
+// blocked or of an API change. The run fails with ErrTooFewJobs when the
+// source is configured to treat it as an error. An empty fetch is not
+// suspicious with conditional requests, since an unchanged feed yields no jobs.
+func (ps *PowerScraper) checkJobCount(sourceName string, config sources.JobSourceConfig, count int) (bool, error) {
+	if count >= config.MinExpectedJobs || (count == 0 && ps.client.ConditionalRequests()) {
+		return false, nil
+	}
+
+	ps.logger.Warnf("Suspicious run for %s: fetched %d jobs, expected at least %d; the source may be blocking requests or have changed its API",
+		sourceName, count, config.MinExpectedJobs)
+	if config.FailBelowMinJobs {
+		return true, fmt.Errorf("%w: %d, expected at least %d", ErrTooFewJobs, count, config.MinExpectedJobs)
+	}
+	return true, nil
+}
+
+// fetchJobs fetches jobs from a source, giving up after the source timeout
+// or, when it is unset, the HTTP client request timeout. The timeout also
+// cuts off a response body that arrives too slowly.
+func (ps *PowerScraper) fetchJobs(ctx context.Context, fetch fetchFunc, timeout time.Duration) ([]models.Job, error) {
+	if timeout <= 0 {
+		timeout = ps.client.Timeout()
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	jobs, err := fetch(ctx)
+	if err != nil && (ctx.Err() == context.DeadlineExceeded || errors.Is(err, httpclient.ErrReadTimeout)) {
+		return nil, fmt.Errorf("timed out after %v: %w", timeout, err)
+	}
+	return jobs, err
+}
+
+// validateJobTypes clears job types that are not one of the standardized values
+func (ps *PowerScraper) validateJobTypes(sourceName string, jobs []models.Job) {
+	for i := range jobs {
+		if jobs[i].JobType != "" && !models.ValidJobType(jobs[i].JobType) {
+			ps.logger.Warnf("Unknown job type %q for %s at %s from %s, clearing it",
+				jobs[i].JobType, jobs[i].Title, jobs[i].Company, sourceName)
+			jobs[i].JobType = ""
+		}
 	}
 }
 
-// calculateBackoffDelay calculates exponential backoff delay
-func (ps *PowerScraper) calculateBackoffDelay(attempt int) time.Duration {
-	delay := time.Duration(float64(ps.retryConfig.InitialDelay) *
-		float64(attempt) * ps.retryConfig.BackoffFactor)
+// backoffDelay calculates exponential backoff delay. With jitter the delay is
+// drawn from [delay/2, delay) using random, so that sources failing together
+// do not retry in lockstep.
+func (rc RetryConfig) backoffDelay(attempt int, random func() float64) time.Duration {
+	delay := time.Duration(float64(rc.InitialDelay) *
+		float64(attempt) * rc.BackoffFactor)
+
+	if rc.MaxDelay > 0 && delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
 
-	if delay > ps.retryConfig.MaxDelay {
-		delay = ps.retryConfig.MaxDelay
+	if rc.Jitter {
+		delay = delay/2 + time.Duration(random()*float64(delay/2))
 	}
 
 	return delay
 }
 
-// saveJobs saves jobs to storage with batch processing
-func (ps *PowerScraper) saveJobs(ctx context.Context, jobs []models.Job) error {
+// saveWithRetry runs a storage save, retrying transient failures with backoff
+func (ps *PowerScraper) saveWithRetry(ctx context.Context, what string, save func() error) error {
+	var err error
+	for attempt := 0; attempt <= ps.saveRetryConfig.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := ps.saveRetryConfig.backoffDelay(attempt, ps.random)
+			ps.logger.Warnf("Retrying save of %s (attempt %d/%d) after %v: %v",
+				what, attempt+1, ps.saveRetryConfig.MaxRetries+1, delay, err)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		err = save()
+		if err == nil || ctx.Err() != nil || !storage.IsTransientError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// SaveResult counts the outcome of saving a set of jobs
+type SaveResult struct {
+	Saved         int
+	Skipped       int // already stored
+	Invalid       int // rejected by validation
+	Failed        int
+	SavedBySource map[string]int
+	SavedJobs     []models.Job // inserted by this save
+	UnsavedJobs   []models.Job // failed or rejected by validation
+}
+
+// add records the outcome for a single job
+func (r *SaveResult) add(job models.Job, saved bool) {
+	if !saved {
+		r.Failed++
+		r.UnsavedJobs = append(r.UnsavedJobs, job)
+		return
+	}
+	r.Saved++
+	r.SavedJobs = append(r.SavedJobs, job)
+	if r.SavedBySource == nil {
+		r.SavedBySource = make(map[string]int)
+	}
+	r.SavedBySource[job.Source]++
+}
+
+// merge adds the counts of other to r
+func (r *SaveResult) merge(other SaveResult) {
+	r.Saved += other.Saved
+	r.Skipped += other.Skipped
+	r.Invalid += other.Invalid
+	r.Failed += other.Failed
+	r.SavedJobs = append(r.SavedJobs, other.SavedJobs...)
+	r.UnsavedJobs = append(r.UnsavedJobs, other.UnsavedJobs...)
+	for source, saved := range other.SavedBySource {
+		if r.SavedBySource == nil {
+			r.SavedBySource = make(map[string]int)
+		}
+		r.SavedBySource[source] += saved
+	}
+}
+
+// saveJobs saves jobs to storage in batches, running up to saveConcurrency
+// batch saves at once, and reports how many jobs were saved, skipped, and
+// failed
+func (ps *PowerScraper) saveJobs(ctx context.Context, jobs []models.Job) (SaveResult, error) {
 	const batchSize = 50
 
+	var result SaveResult
+	jobs, result.UnsavedJobs = ps.validJobs(jobs)
+	result.Invalid = len(result.UnsavedJobs)
+
+	var batches [][]models.Job
 	for i := 0; i < len(jobs); i += batchSize {
 		end := i + batchSize
 		if end > len(jobs) {
 			end = len(jobs)
 		}
+		batches = append(batches, jobs[i:end])
+	}
+
+	workers := ps.saveConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(batches) {
+		workers = len(batches)
+	}
 
-		batch := jobs[i:end]
+	// Workers take the batches in order, so a single worker saves them
+	// sequentially
+	batchChan := make(chan []models.Job)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batchChan {
+				batchResult := ps.saveBatch(ctx, batch)
 
-		// Try batch save first for better performance
-		if err := ps.storage.SaveJobs(batch); err != nil {
-			ps.logger.Printf("Batch save failed, falling back to individual saves: %v", err)
-			// Fall back to individual saves if batch fails
-			for _, job := range batch {
-				if err := ps.storage.SaveJob(&job); err != nil {
-					ps.logger.Printf("Failed to save job %s at %s: %v", job.Title, job.Company, err)
-					// Continue with other jobs instead of failing completely
-					continue
-				}
+				mu.Lock()
+				result.merge(batchResult)
+				mu.Unlock()
 			}
-		}
+		}()
+	}
 
-		// Check if context was cancelled
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			break
+		}
 		select {
+		case batchChan <- batch:
 		case <-ctx.Done():
-			return ctx.Err()
-		default:
 		}
 	}
+	close(batchChan)
+	wg.Wait()
 
-	return nil
+	// Check if context was cancelled
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	if result.Failed > 0 || result.Invalid > 0 {
+		ps.logger.Warnf("Saved %d jobs, %d failed, %d invalid, %d already stored",
+			result.Saved, result.Failed, result.Invalid, result.Skipped)
+	}
+	return result, nil
+}
+
+// saveBatch saves a batch of jobs, skipping those already stored and falling
+// back to individual saves when the batch fails. A batch cancelled with ctx is
+// not counted.
+func (ps *PowerScraper) saveBatch(ctx context.Context, batch []models.Job) SaveResult {
+	var result SaveResult
+
+	batch, result.Skipped = ps.unstoredJobs(ctx, batch)
+	if len(batch) == 0 {
+		return result
+	}
+
+	// Try batch save first for better performance
+	err := ps.saveWithRetry(ctx, fmt.Sprintf("batch of %d jobs", len(batch)), func() error {
+		return ps.storage.SaveJobs(ctx, batch)
+	})
+	if err == nil {
+		for _, job := range batch {
+			result.add(job, true)
+		}
+		return result
+	}
+
+	// A cancelled save must not fall back to saving each job
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// The storage is still failing after the retries, so saving each
+	// job would only fail again, more slowly
+	if storage.IsTransientError(err) {
+		ps.logger.Errorf("Failed to save batch of %d jobs: %v", len(batch), err)
+		for _, job := range batch {
+			result.add(job, false)
+		}
+		return result
+	}
+
+	ps.logger.Warnf("Batch save failed, falling back to individual saves: %v", err)
+	// Fall back to individual saves if batch fails
+	for j := range batch {
+		job := &batch[j]
+
+		// A common cause of batch failures is a job that is already stored
+		if job.URL != "" {
+			if existing, err := ps.storage.GetJobByURL(ctx, job.URL); err == nil && existing != nil {
+				ps.logger.Debugf("Skipping already stored job %s at %s", job.Title, job.Company)
+				result.Skipped++
+				continue
+			}
+		}
+		err := ps.saveWithRetry(ctx, fmt.Sprintf("job %s at %s", job.Title, job.Company), func() error {
+			return ps.storage.SaveJob(ctx, job)
+		})
+		if err != nil {
+			ps.logger.Errorf("Failed to save job %s at %s: %v", job.Title, job.Company, err)
+			// Continue with other jobs instead of failing completely
+			result.add(*job, false)
+			continue
+		}
+		result.add(*job, true)
+	}
+	return result
+}
+
+// unstoredJobs drops the jobs whose URL is already stored, returning the
+// remaining jobs and the number dropped. If the lookup fails every job is
+// kept, and saving falls back to individual saves should the batch fail.
+func (ps *PowerScraper) unstoredJobs(ctx context.Context, jobs []models.Job) ([]models.Job, int) {
+	var urls []string
+	for _, job := range jobs {
+		if job.URL != "" {
+			urls = append(urls, job.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return jobs, 0
+	}
+
+	stored, err := ps.storage.StoredURLs(ctx, urls)
+	if err != nil {
+		ps.logger.Warnf("Failed to look up stored jobs, saving all %d: %v", len(jobs), err)
+		return jobs, 0
+	}
+	if len(stored) == 0 {
+		return jobs, 0
+	}
+
+	unstored := make([]models.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if stored[job.URL] {
+			ps.logger.Debugf("Skipping already stored job %s at %s", job.Title, job.Company)
+			continue
+		}
+		unstored = append(unstored, job)
+	}
+	return unstored, len(jobs) - len(unstored)
+}
+
+// validJobs splits the jobs into those that pass validation and those rejected
+func (ps *PowerScraper) validJobs(jobs []models.Job) ([]models.Job, []models.Job) {
+	if ps.validation == models.ValidationOff {
+		return jobs, nil
+	}
+
+	valid := make([]models.Job, 0, len(jobs))
+	var invalid []models.Job
+	for _, job := range jobs {
+		if err := job.ValidateLevel(ps.validation); err != nil {
+			ps.logger.Debugf("Skipping invalid job %q at %s from %s: %v", job.Title, job.Company, job.Source, err)
+			invalid = append(invalid, job)
+			continue
+		}
+		valid = append(valid, job)
+	}
+	return valid, invalid
+}
+
+// recordSaveResult adds a save result to the metrics
+func (ps *PowerScraper) recordSaveResult(result SaveResult) {
+	ps.metrics.mu.Lock()
+	defer ps.metrics.mu.Unlock()
+
+	ps.metrics.TotalJobsSaved += int64(result.Saved)
+	ps.metrics.TotalErrors += int64(result.Failed)
+	for source, saved := range result.SavedBySource {
+		sourceMetric := ps.metrics.SourcePerformance[source]
+		sourceMetric.JobsSaved = int64(saved)
+		ps.metrics.SourcePerformance[source] = sourceMetric
+	}
+}
+
+// ResetMetrics zeroes the counters and clears the per-source and per-category
+// metrics, e.g. to report totals per period in a long-running daemon
+func (ps *PowerScraper) ResetMetrics() {
+	ps.metrics.mu.Lock()
+	defer ps.metrics.mu.Unlock()
+
+	ps.metrics.TotalJobsScraped = 0
+	ps.metrics.TotalJobsSaved = 0
+	ps.metrics.TotalDuplicates = 0
+	ps.metrics.WithinSourceDuplicates = 0
+	ps.metrics.CrossSourceDuplicates = 0
+	ps.metrics.TotalErrors = 0
+	ps.metrics.SuspiciousRuns = 0
+	ps.metrics.ScrapingDuration = 0
+	ps.metrics.SourcePerformance = make(map[string]SourceMetrics)
+	ps.metrics.CategoryCounts = make(map[string]int64)
 }
 
 // GetMetrics returns current scraper metrics
@@ -306,18 +1431,32 @@ func (ps *PowerScraper) GetMetrics() ScraperMetrics {
 	ps.metrics.mu.RLock()
 	defer ps.metrics.mu.RUnlock()
 
-	// Create a copy to avoid race conditions - without copying the mutex
+	// Create a copy to avoid race conditions
+	return ps.metrics.copy()
+}
+
+// copy returns a copy of the metrics without the mutex. The caller must hold
+// the lock if the metrics are shared.
+func (m *ScraperMetrics) copy() ScraperMetrics {
 	sourcePerformance := make(map[string]SourceMetrics)
-	for k, v := range ps.metrics.SourcePerformance {
+	for k, v := range m.SourcePerformance {
 		sourcePerformance[k] = v
 	}
+	categoryCounts := make(map[string]int64)
+	for k, v := range m.CategoryCounts {
+		categoryCounts[k] = v
+	}
 
 	return ScraperMetrics{
-		TotalJobsScraped:  ps.metrics.TotalJobsScraped,
-		TotalJobsSaved:    ps.metrics.TotalJobsSaved,
-		TotalDuplicates:   ps.metrics.TotalDuplicates,
-		TotalErrors:       ps.metrics.TotalErrors,
-		ScrapingDuration:  ps.metrics.ScrapingDuration,
-		SourcePerformance: sourcePerformance,
+		TotalJobsScraped:       m.TotalJobsScraped,
+		TotalJobsSaved:         m.TotalJobsSaved,
+		TotalDuplicates:        m.TotalDuplicates,
+		WithinSourceDuplicates: m.WithinSourceDuplicates,
+		CrossSourceDuplicates:  m.CrossSourceDuplicates,
+		TotalErrors:            m.TotalErrors,
+		SuspiciousRuns:         m.SuspiciousRuns,
+		ScrapingDuration:       m.ScrapingDuration,
+		SourcePerformance:      sourcePerformance,
+		CategoryCounts:         categoryCounts,
 	}
 }
@@ -0,0 +1,1986 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	postgrest "github.com/nedpals/supabase-go/postgrest/pkg"
+
+	"job-scraper-go/internal/config"
+	"job-scraper-go/internal/logging"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/notify"
+	"job-scraper-go/internal/scraper/sources"
+	"job-scraper-go/internal/storage"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// fakeSource is a JobSource returning fixed jobs, or an error
+type fakeSource struct {
+	name string
+	jobs []models.Job
+	err  error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeSource) GetName() string                       { return f.name }
+func (f *fakeSource) GetRateLimit() int                     { return 0 }
+func (f *fakeSource) SupportsSearch() bool                  { return false }
+func (f *fakeSource) GetBaseURL() string                    { return "https://example.com" }
+func (f *fakeSource) HealthCheck(ctx context.Context) error { return f.err }
+
+func (f *fakeSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return append([]models.Job(nil), f.jobs...), nil
+}
+
+// fetchCount returns how many times FetchJobs was called
+func (f *fakeSource) fetchCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+// newTestScraper returns a scraper saving to store, with the given sources
+// registered and enabled, that retries without delay
+func newTestScraper(t *testing.T, store storage.Store, srcs ...sources.JobSource) *PowerScraper {
+	t.Helper()
+
+	ps := NewPowerScraper(store, httpclient.NewHttpClient(5*time.Second), logging.New(io.Discard, "", 0, logging.LevelError))
+	ps.SetRetryConfig(RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1})
+	ps.SetSaveRetryConfig(RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1})
+	for _, src := range srcs {
+		ps.sourceManager.RegisterSource(src, sources.JobSourceConfig{Enabled: true})
+	}
+	return ps
+}
+
+// testJob returns a valid job from source with a URL derived from its title
+// and company
+func testJob(source, title, company string) models.Job {
+	slug := strings.ToLower(strings.NewReplacer(" ", "-").Replace(title + " " + company))
+	return models.Job{
+		Title:    title,
+		Company:  company,
+		Location: "Remote",
+		URL:      "https://example.com/" + strings.ToLower(source) + "/" + slug,
+		Source:   source,
+	}
+}
+
+// titles returns the titles of jobs in order
+func titles(jobs []models.Job) []string {
+	var result []string
+	for _, job := range jobs {
+		result = append(result, job.Title)
+	}
+	return result
+}
+
+func TestScrapeAllSourcesSuppressesSimilarJobs(t *testing.T) {
+	remoteOK := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Senior Go Developer", "Acme"),
+		testJob("RemoteOK", "Product Designer", "Globex"),
+	}}
+	remotive := &fakeSource{name: "Remotive", jobs: []models.Job{
+		testJob("Remotive", "Senior Golang Developer", "Acme Inc"),
+	}}
+
+	tests := []struct {
+		name           string
+		threshold      float64
+		wantSaved      int
+		wantDuplicates int64
+	}{
+		{"disabled", 0, 3, 0},
+		{"below similarity", 0.9, 3, 0},
+		{"above similarity", 0.7, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStore()
+			ps := newTestScraper(t, store, remoteOK, remotive)
+			ps.SetOptions(Options{SimilarityThreshold: tt.threshold})
+
+			report, err := ps.ScrapeAllSources(context.Background())
+			if err != nil {
+				t.Fatalf("ScrapeAllSources: %v", err)
+			}
+
+			if report.SavedCount != tt.wantSaved {
+				t.Errorf("saved %d jobs, want %d: %v", report.SavedCount, tt.wantSaved, titles(report.Jobs))
+			}
+			metrics := ps.GetMetrics()
+			if metrics.TotalDuplicates != tt.wantDuplicates {
+				t.Errorf("TotalDuplicates = %d, want %d", metrics.TotalDuplicates, tt.wantDuplicates)
+			}
+			if tt.wantDuplicates > 0 && metrics.CrossSourceDuplicates != tt.wantDuplicates {
+				t.Errorf("CrossSourceDuplicates = %d, want %d", metrics.CrossSourceDuplicates, tt.wantDuplicates)
+			}
+
+			stored, err := store.GetJobs(context.Background())
+			if err != nil {
+				t.Fatalf("GetJobs: %v", err)
+			}
+			designers := 0
+			for _, job := range stored {
+				if job.Title == "Product Designer" {
+					designers++
+				}
+			}
+			if designers != 1 {
+				t.Errorf("stored %d copies of the unrelated job, want 1", designers)
+			}
+		})
+	}
+}
+
+func TestRetryConfigFromConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"scraper": {"retry_attempts": 2, "retry_delay": "1ms", "max_retry_delay": "4ms", "backoff_factor": 3, "retry_jitter": false}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	retryConfig := NewRetryConfig(cfg.Scraper)
+	want := RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond, BackoffFactor: 3}
+	if retryConfig != want {
+		t.Fatalf("NewRetryConfig = %+v, want %+v", retryConfig, want)
+	}
+	if delay := retryConfig.backoffDelay(1, nil); delay != 3*time.Millisecond {
+		t.Errorf("first retry delay = %v, want 3ms", delay)
+	}
+	if delay := retryConfig.backoffDelay(2, nil); delay != 4*time.Millisecond {
+		t.Errorf("second retry delay = %v, want the 4ms maximum", delay)
+	}
+
+	// The scraper makes the configured number of attempts
+	source := &fakeSource{name: "RemoteOK", err: errors.New("connection reset")}
+	ps := newTestScraper(t, storage.NewMemoryStore(), source)
+	ps.SetRetryConfig(retryConfig)
+
+	result := ps.scrapeSource(context.Background(), "RemoteOK", source)
+	if result.Error == nil {
+		t.Fatal("scrapeSource succeeded, want the source's error")
+	}
+	if calls := source.fetchCount(); calls != 3 {
+		t.Errorf("fetched %d times, want 3 (one attempt and 2 retries)", calls)
+	}
+}
+
+func TestScrapeRetriesOnlyRetryableErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int
+	}{
+		{http.StatusNotFound, 1},
+		{http.StatusUnauthorized, 1},
+		{http.StatusTooManyRequests, 3},
+		{http.StatusServiceUnavailable, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			source := &fakeSource{name: "RemoteOK", err: &sources.StatusError{Source: "RemoteOK API", StatusCode: tt.status}}
+			ps := newTestScraper(t, storage.NewMemoryStore(), source)
+			ps.SetRetryConfig(RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1})
+
+			result := ps.scrapeSource(context.Background(), "RemoteOK", source)
+			if result.Error == nil {
+				t.Fatal("scrapeSource succeeded, want the source's error")
+			}
+			if calls := source.fetchCount(); calls != tt.want {
+				t.Errorf("fetched %d times, want %d", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithScrapeTimeout(t *testing.T) {
+	const timeout = 10 * time.Minute
+
+	start := time.Now()
+	ctx, cancel := WithScrapeTimeout(context.Background(), timeout)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("context has no deadline, want one 10m away")
+	}
+	if got := deadline.Sub(start); got < timeout || got > timeout+time.Second {
+		t.Errorf("deadline is %v away, want %v", got, timeout)
+	}
+
+	ctx, cancel = WithScrapeTimeout(context.Background(), 0)
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("context with a zero timeout has a deadline, want none")
+	}
+	cancel()
+	if ctx.Err() == nil {
+		t.Error("cancel did not cancel the context without a deadline")
+	}
+}
+
+// malformedSource is a fakeSource whose first fetches fail with a malformed
+// JSON response
+type malformedSource struct {
+	fakeSource
+	failures int
+}
+
+func (m *malformedSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	jobs, err := m.fakeSource.FetchJobs(ctx)
+	if m.fetchCount() <= m.failures {
+		return nil, fmt.Errorf("%w: unexpected end of JSON input", sources.ErrMalformedJSON)
+	}
+	return jobs, err
+}
+
+func TestScrapeRefetchesMalformedJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		refetch   bool
+		failures  int
+		wantJobs  int
+		wantCalls int
+	}{
+		{"refetch succeeds", true, 1, 2, 2},
+		{"refetch fails too", true, 2, 0, 2},
+		{"disabled", false, 1, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &malformedSource{fakeSource: fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 2)}, failures: tt.failures}
+			ps := newTestScraper(t, storage.NewMemoryStore(), source)
+			ps.SetRetryConfig(RetryConfig{MaxRetries: 0})
+			ps.SetRefetchMalformedJSON(tt.refetch)
+
+			result := ps.scrapeSource(context.Background(), "RemoteOK", source)
+			if tt.wantJobs > 0 && result.Error != nil {
+				t.Fatalf("scrapeSource: %v", result.Error)
+			}
+			if tt.wantJobs == 0 && !errors.Is(result.Error, sources.ErrMalformedJSON) {
+				t.Errorf("scrapeSource error = %v, want %v", result.Error, sources.ErrMalformedJSON)
+			}
+			if len(result.Jobs) != tt.wantJobs {
+				t.Errorf("scraped %d jobs, want %d", len(result.Jobs), tt.wantJobs)
+			}
+			if calls := source.fetchCount(); calls != tt.wantCalls {
+				t.Errorf("fetched %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestScrapeByCategoryForOneSourceReportsRealMetrics(t *testing.T) {
+	devops := func(title, company string) models.Job {
+		job := testJob("RemoteOK", title, company)
+		job.JobCategory = "DevOps"
+		return job
+	}
+	stored := devops("Platform Engineer", "Acme")
+	remoteOK := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		devops("Site Reliability Engineer", "Globex"),
+		devops("Site Reliability Engineer", "Globex"), // duplicate
+		stored,
+		testJob("RemoteOK", "Product Designer", "Initech"), // another category
+	}}
+	remotive := &fakeSource{name: "Remotive", jobs: []models.Job{devops("DevOps Engineer", "Umbrella")}}
+
+	store := storage.NewMemoryStore()
+	if err := store.SaveJob(context.Background(), &stored); err != nil {
+		t.Fatal(err)
+	}
+	ps := newTestScraper(t, store, remoteOK, remotive)
+	ps.SetOptions(Options{Sources: []string{"remoteok"}})
+
+	report, err := ps.ScrapeByCategory(context.Background(), []string{"devops"})
+	if err != nil {
+		t.Fatalf("ScrapeByCategory: %v", err)
+	}
+
+	if remotive.fetchCount() != 0 {
+		t.Error("fetched Remotive, which was not selected")
+	}
+	if report.SavedCount != 1 {
+		t.Errorf("SavedCount = %d, want 1 (the stored job is skipped)", report.SavedCount)
+	}
+
+	metrics := ps.GetMetrics()
+	if metrics.TotalJobsScraped != 3 || metrics.TotalDuplicates != 1 || metrics.TotalJobsSaved != 1 {
+		t.Errorf("scraped %d, duplicates %d, saved %d; want 3, 1 and 1",
+			metrics.TotalJobsScraped, metrics.TotalDuplicates, metrics.TotalJobsSaved)
+	}
+	if metrics.CategoryCounts["devops"] != 3 {
+		t.Errorf("CategoryCounts[devops] = %d, want 3", metrics.CategoryCounts["devops"])
+	}
+	if metrics.ScrapingDuration <= 0 {
+		t.Error("ScrapingDuration was not recorded")
+	}
+	if source := metrics.SourcePerformance["RemoteOK"]; source.Requests != 1 || source.JobsSaved != 1 {
+		t.Errorf("RemoteOK requests %d, saved %d; want 1 and 1", source.Requests, source.JobsSaved)
+	}
+}
+
+func TestScrapeClearsUnknownJobTypes(t *testing.T) {
+	intern := testJob("Remotive", "Data Intern", "Acme")
+	intern.JobType = models.JobTypeInternship
+	temp := testJob("Remotive", "Support Agent", "Globex")
+	temp.JobType = "temporary"
+	source := &fakeSource{name: "Remotive", jobs: []models.Job{intern, temp}}
+
+	ps := newTestScraper(t, storage.NewMemoryStore(), source)
+	result := ps.scrapeSource(context.Background(), "Remotive", source)
+	if result.Error != nil {
+		t.Fatalf("scrapeSource: %v", result.Error)
+	}
+
+	if got := result.Jobs[0].JobType; got != models.JobTypeInternship {
+		t.Errorf("internship job type = %q, want it kept", got)
+	}
+	if got := result.Jobs[1].JobType; got != "" {
+		t.Errorf("unknown job type = %q, want it cleared", got)
+	}
+}
+
+// searchRecorder is a source recording the search terms it is configured with
+type searchRecorder struct {
+	fakeSource
+	terms []string
+	mode  string
+}
+
+func (s *searchRecorder) SetSearchTerms(terms []string, mode string) {
+	s.terms = terms
+	s.mode = mode
+}
+
+var registerSearchRecorder sync.Once
+
+func TestNewSourceAppliesSearchTerms(t *testing.T) {
+	registerSearchRecorder.Do(func() {
+		sources.RegisterFactory("search_recorder", func(client *httpclient.HttpClient) sources.JobSource {
+			return &searchRecorder{fakeSource: fakeSource{name: "SearchRecorder"}}
+		})
+	})
+
+	source, err := NewSource("search_recorder", httpclient.NewHttpClient(time.Second), config.SourceConfig{
+		SearchTerms: []string{"golang", "rust"},
+		SearchMode:  sources.SearchModeAll,
+	})
+	if err != nil {
+		t.Fatalf("NewSource: %v", err)
+	}
+
+	recorder := source.(*searchRecorder)
+	if len(recorder.terms) != 2 || recorder.terms[0] != "golang" || recorder.terms[1] != "rust" || recorder.mode != sources.SearchModeAll {
+		t.Errorf("source configured with terms %q in mode %q, want [golang rust] in mode all", recorder.terms, recorder.mode)
+	}
+}
+
+func TestScrapeSourceUpdatesOnlyThatSource(t *testing.T) {
+	remoteOK := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+	remotive := &fakeSource{name: "Remotive", jobs: []models.Job{testJob("Remotive", "Writer", "Initech")}}
+	store := storage.NewMemoryStore()
+	ps := newTestScraper(t, store, remoteOK, remotive)
+
+	result, err := ps.ScrapeSource(context.Background(), "remoteok")
+	if err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+
+	if result.Source != "RemoteOK" || len(result.Jobs) != 2 {
+		t.Errorf("result from %s with %d jobs, want RemoteOK with 2 unique jobs", result.Source, len(result.Jobs))
+	}
+	if remotive.fetchCount() != 0 {
+		t.Error("fetched Remotive, which was not requested")
+	}
+	if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != 2 {
+		t.Errorf("stored %d jobs, want 2", count)
+	}
+
+	metrics := ps.GetMetrics()
+	if len(metrics.SourcePerformance) != 1 {
+		t.Errorf("metrics for %d sources, want only RemoteOK", len(metrics.SourcePerformance))
+	}
+	source := metrics.SourcePerformance["RemoteOK"]
+	if source.JobsScraped != 3 || source.Duplicates != 1 || source.JobsSaved != 2 || source.Requests != 1 {
+		t.Errorf("RemoteOK scraped %d, duplicates %d, saved %d, requests %d; want 3, 1, 2 and 1",
+			source.JobsScraped, source.Duplicates, source.JobsSaved, source.Requests)
+	}
+	if metrics.ScrapingDuration <= 0 {
+		t.Error("ScrapingDuration was not recorded")
+	}
+}
+
+func TestScrapeSourceRecordsDurationWithoutJobs(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(), &fakeSource{name: "RemoteOK"})
+
+	if _, err := ps.ScrapeSource(context.Background(), "RemoteOK"); err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+	if ps.GetMetrics().ScrapingDuration <= 0 {
+		t.Error("ScrapingDuration was not recorded for a run without jobs")
+	}
+}
+
+func TestScrapeSourceRejectsUnknownSource(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(), &fakeSource{name: "RemoteOK"})
+
+	if _, err := ps.ScrapeSource(context.Background(), "nosuchboard"); err == nil || !strings.Contains(err.Error(), "unknown source") {
+		t.Errorf("ScrapeSource(nosuchboard) error = %v, want unknown source", err)
+	}
+}
+
+func TestScrapeAllSourcesNotifiesOnlyNewJobs(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []notify.WebhookPayload
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload notify.WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decoding webhook payload: %v", err)
+		}
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	known := testJob("RemoteOK", "Go Developer", "Acme")
+	store := storage.NewMemoryStore()
+	if err := store.SaveJob(context.Background(), &known); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		known,
+		testJob("RemoteOK", "Designer", "Globex"),
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+	ps := newTestScraper(t, store, source)
+	ps.SetNotifier(notify.NewWebhookNotifier(httpclient.NewHttpClient(5*time.Second), webhook.URL))
+
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	// A second run finds nothing new and must not notify again
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("second ScrapeAllSources: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(payloads) != 1 {
+		t.Fatalf("received %d notifications, want 1", len(payloads))
+	}
+	if got := titles(payloads[0].Jobs); payloads[0].Count != 1 || len(got) != 1 || got[0] != "Designer" {
+		t.Errorf("notified about %q (count %d), want only the new Designer job", got, payloads[0].Count)
+	}
+}
+
+func TestScrapeSourceCountsAndNotifiesNewJobs(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []notify.WebhookPayload
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload notify.WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decoding webhook payload: %v", err)
+		}
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	known := testJob("RemoteOK", "Go Developer", "Acme")
+	store := &rejectingStore{MemoryStore: storage.NewMemoryStore(), reject: map[string]bool{"Data Analyst": true}}
+	if err := store.MemoryStore.SaveJob(context.Background(), &known); err != nil {
+		t.Fatal(err)
+	}
+	invalid := testJob("RemoteOK", "Writer", "Hooli")
+	invalid.URL = ""
+
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		known,
+		testJob("RemoteOK", "Designer", "Globex"),
+		testJob("RemoteOK", "Data Analyst", "Initech"),
+		invalid,
+	}}
+	ps := newTestScraper(t, store, source)
+	ps.SetNotifier(notify.NewWebhookNotifier(httpclient.NewHttpClient(5*time.Second), webhook.URL))
+
+	result, err := ps.ScrapeSource(context.Background(), "RemoteOK")
+	if err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+	// The stored, rejected and invalid jobs are found but not saved
+	if len(result.Jobs) != 4 || result.SavedCount != 1 {
+		t.Errorf("found %d jobs and saved %d, want 4 and 1", len(result.Jobs), result.SavedCount)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(payloads) != 1 {
+		t.Fatalf("received %d notifications, want 1", len(payloads))
+	}
+	if got := titles(payloads[0].Jobs); len(got) != 1 || got[0] != "Designer" {
+		t.Errorf("notified about %q, want only the new Designer job", got)
+	}
+}
+
+// numberedJobs returns n distinct jobs from source, none of which duplicate
+// the jobs of another source
+func numberedJobs(source string, n int) []models.Job {
+	jobs := make([]models.Job, n)
+	for i := range jobs {
+		jobs[i] = testJob(source, fmt.Sprintf("Developer %d", i+1), source+" Corp")
+	}
+	return jobs
+}
+
+func TestMaxJobsCapsSavedJobsPerSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxJobs   int
+		wantSaved int
+	}{
+		{"unlimited", 0, 8},
+		{"below both sources", 2, 4},
+		{"between sources", 4, 7},
+		{"above both sources", 10, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStore()
+			ps := newTestScraper(t, store,
+				&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 5)},
+				&fakeSource{name: "Remotive", jobs: numberedJobs("Remotive", 3)})
+			ps.SetOptions(Options{MaxJobs: tt.maxJobs})
+
+			report, err := ps.ScrapeAllSources(context.Background())
+			if err != nil {
+				t.Fatalf("ScrapeAllSources: %v", err)
+			}
+
+			if report.SavedCount != tt.wantSaved {
+				t.Errorf("saved %d jobs, want %d", report.SavedCount, tt.wantSaved)
+			}
+			for _, source := range []string{"RemoteOK", "Remotive"} {
+				count, err := store.Count(context.Background(), storage.JobFilter{Source: source})
+				if err != nil {
+					t.Fatalf("Count: %v", err)
+				}
+				if tt.maxJobs > 0 && count > int64(tt.maxJobs) {
+					t.Errorf("stored %d %s jobs, want at most %d", count, source, tt.maxJobs)
+				}
+			}
+		})
+	}
+}
+
+func TestMaxJobsAppliesToScrapeSource(t *testing.T) {
+	store := storage.NewMemoryStore()
+	ps := newTestScraper(t, store, &fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 5)})
+	ps.SetOptions(Options{MaxJobs: 2})
+
+	result, err := ps.ScrapeSource(context.Background(), "RemoteOK")
+	if err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+	if got := titles(result.Jobs); len(got) != 2 || got[0] != "Developer 1" || got[1] != "Developer 2" {
+		t.Errorf("kept %q, want the first two jobs", got)
+	}
+	if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != 2 {
+		t.Errorf("stored %d jobs, want 2", count)
+	}
+}
+
+// postedJob returns a job from source posted at the given time, or undated when nil
+func postedJob(source, title string, posted *time.Time) models.Job {
+	job := testJob(source, title, "Acme")
+	job.PostedDate = posted
+	return job
+}
+
+func TestFilterPostedAfter(t *testing.T) {
+	cutoff := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) *time.Time {
+		date := time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	jobs := []models.Job{
+		postedJob("RemoteOK", "Old", day(time.April, 20)),
+		postedJob("RemoteOK", "Recent", day(time.April, 30)),
+		postedJob("RemoteOK", "Undated", nil),
+		postedJob("RemoteOK", "Newest", day(time.May, 3)),
+		postedJob("RemoteOK", "At cutoff", day(time.May, 1)),
+	}
+
+	tests := []struct {
+		name        string
+		cutoff      time.Time
+		keepUndated bool
+		want        []string
+	}{
+		{"keep undated", cutoff, true, []string{"Undated", "Newest"}},
+		{"drop undated", cutoff, false, []string{"Newest"}},
+		{"earlier cutoff", cutoff.AddDate(0, 0, -5), false, []string{"Recent", "Newest", "At cutoff"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titles(FilterPostedAfter(jobs, tt.cutoff, tt.keepUndated))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FilterPostedAfter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostedAfterFiltersBeforeSaving(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) *time.Time {
+		date := now.AddDate(0, 0, -days)
+		return &date
+	}
+	jobs := []models.Job{
+		postedJob("RemoteOK", "Today", daysAgo(0)),
+		postedJob("RemoteOK", "Two days ago", daysAgo(2)),
+		postedJob("RemoteOK", "Last week", daysAgo(7)),
+		postedJob("RemoteOK", "Last month", daysAgo(30)),
+		postedJob("RemoteOK", "Undated", nil),
+	}
+
+	tests := []struct {
+		name        string
+		dropUndated bool
+		want        []string
+	}{
+		{"keeps undated by default", false, []string{"Today", "Two days ago", "Undated"}},
+		{"drops undated", true, []string{"Today", "Two days ago"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStore()
+			ps := newTestScraper(t, store, &fakeSource{name: "RemoteOK", jobs: jobs})
+			ps.SetOptions(Options{PostedAfter: now.Add(-72 * time.Hour), DropUndated: tt.dropUndated})
+
+			report, err := ps.ScrapeAllSources(context.Background())
+			if err != nil {
+				t.Fatalf("ScrapeAllSources: %v", err)
+			}
+			if got := titles(report.Jobs); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("kept %q, want %q", got, tt.want)
+			}
+
+			stored, err := store.GetJobs(context.Background())
+			if err != nil {
+				t.Fatalf("GetJobs: %v", err)
+			}
+			if len(stored) != len(tt.want) {
+				t.Errorf("stored %d jobs, want %d", len(stored), len(tt.want))
+			}
+		})
+	}
+}
+
+var registerFakeBoard sync.Once
+
+// fakeBoard is the source built by the fake_board factory
+var fakeBoard *fakeSource
+
+func TestRegisteredFactoryIsBuiltAndScraped(t *testing.T) {
+	board := &fakeSource{name: "FakeBoard", jobs: []models.Job{
+		testJob("FakeBoard", "Go Developer", "Acme"),
+		testJob("FakeBoard", "Designer", "Globex"),
+	}}
+	// The factory is registered once, so repeated runs swap in a fresh board
+	fakeBoard = board
+	registerFakeBoard.Do(func() {
+		sources.RegisterFactory("fake_board", func(client *httpclient.HttpClient) sources.JobSource {
+			return fakeBoard
+		})
+	})
+
+	store := storage.NewMemoryStore()
+	ps := newTestScraper(t, store)
+	ps.InitializeSources(config.SourcesConfig{})
+
+	if _, registered := ps.SourceStates()["FakeBoard"]; !registered {
+		t.Fatalf("InitializeSources registered %v, want FakeBoard among them", ps.SourceStates())
+	}
+
+	result, err := ps.ScrapeSource(context.Background(), "fake_board")
+	if err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+	if result.Source != "FakeBoard" || len(result.Jobs) != 2 {
+		t.Errorf("scraped %d jobs from %s, want 2 from FakeBoard", len(result.Jobs), result.Source)
+	}
+	if board.fetchCount() != 1 {
+		t.Errorf("fetched FakeBoard %d times, want once", board.fetchCount())
+	}
+	if count, _ := store.Count(context.Background(), storage.JobFilter{Source: "FakeBoard"}); count != 2 {
+		t.Errorf("stored %d FakeBoard jobs, want 2", count)
+	}
+}
+
+func TestInitializeSourcesAppliesSourceConfig(t *testing.T) {
+	sourcesConfig := config.DefaultConfig().Sources
+	sourcesConfig.Remotive.Enabled = false
+	sourcesConfig.RemoteOK.RateLimit = 12
+	sourcesConfig.RemoteOK.SearchTerms = []string{"rust"}
+	sourcesConfig.Indeed.Enabled = true
+	sourcesConfig.Indeed.RateLimit = 0
+
+	ps := newTestScraper(t, storage.NewMemoryStore())
+	ps.InitializeSources(sourcesConfig)
+
+	enabled := ps.sourceManager.GetEnabledSources()
+	if _, ok := enabled["Remotive"]; ok {
+		t.Error("Remotive is enabled, want it disabled by the config")
+	}
+	if _, ok := enabled["RemoteOK"]; !ok {
+		t.Error("RemoteOK is not enabled")
+	}
+	if ps.SourceStates()["Remotive"] {
+		t.Error("SourceStates reports Remotive enabled")
+	}
+
+	remoteOK, _ := ps.sourceManager.GetSourceConfig("RemoteOK")
+	if remoteOK.RateLimit != 12 || len(remoteOK.SearchTerms) != 1 || remoteOK.SearchTerms[0] != "rust" {
+		t.Errorf("RemoteOK config = %+v, want rate limit 12 and search term rust", remoteOK)
+	}
+
+	// Without a configured rate limit the source's own applies
+	indeed, _ := ps.sourceManager.GetSourceConfig("Indeed")
+	if want := enabled["Indeed"].GetRateLimit(); !indeed.Enabled || indeed.RateLimit != want {
+		t.Errorf("Indeed config = %+v, want enabled with its own rate limit %d", indeed, want)
+	}
+}
+
+// lookupStore is a memory store recording the jobs saved in batches, whose
+// stored URL lookup can be made to fail
+type lookupStore struct {
+	*storage.MemoryStore
+	lookupErr error
+
+	mu    sync.Mutex
+	saved []models.Job
+}
+
+func (s *lookupStore) SaveJobs(ctx context.Context, jobs []models.Job) error {
+	s.mu.Lock()
+	s.saved = append(s.saved, jobs...)
+	s.mu.Unlock()
+	return s.MemoryStore.SaveJobs(ctx, jobs)
+}
+
+func (s *lookupStore) StoredURLs(ctx context.Context, urls []string) (map[string]bool, error) {
+	if s.lookupErr != nil {
+		return nil, s.lookupErr
+	}
+	return s.MemoryStore.StoredURLs(ctx, urls)
+}
+
+func TestSaveJobsSkipsStoredURLs(t *testing.T) {
+	known := testJob("RemoteOK", "Go Developer", "Acme")
+	fresh := testJob("RemoteOK", "Designer", "Globex")
+
+	tests := []struct {
+		name        string
+		lookupErr   error
+		wantSaved   []string
+		wantSkipped int
+	}{
+		{"skips stored", nil, []string{"Designer"}, 1},
+		// Without the lookup every job goes to the batch save
+		{"lookup fails", errors.New("lookup failed"), []string{"Go Developer", "Designer"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &lookupStore{MemoryStore: storage.NewMemoryStore(), lookupErr: tt.lookupErr}
+			if err := store.SaveJob(context.Background(), &known); err != nil {
+				t.Fatal(err)
+			}
+			ps := newTestScraper(t, store)
+
+			result, err := ps.saveJobs(context.Background(), []models.Job{known, fresh})
+			if err != nil {
+				t.Fatalf("saveJobs: %v", err)
+			}
+			if result.Skipped != tt.wantSkipped {
+				t.Errorf("Skipped = %d, want %d", result.Skipped, tt.wantSkipped)
+			}
+			if got := titles(store.saved); !reflect.DeepEqual(got, tt.wantSaved) {
+				t.Errorf("batch saved %q, want %q", got, tt.wantSaved)
+			}
+		})
+	}
+}
+
+// rejectingStore is a memory store refusing to save the jobs with the given
+// titles, failing every batch that contains one
+type rejectingStore struct {
+	*storage.MemoryStore
+	reject map[string]bool
+}
+
+// errRejected is a permanent storage error, such as a constraint violation
+var errRejected = &postgrest.RequestError{Message: "violates check constraint", HTTPStatusCode: http.StatusBadRequest}
+
+func (s *rejectingStore) SaveJob(ctx context.Context, job *models.Job) error {
+	if s.reject[job.Title] {
+		return errRejected
+	}
+	return s.MemoryStore.SaveJob(ctx, job)
+}
+
+func (s *rejectingStore) SaveJobs(ctx context.Context, jobs []models.Job) error {
+	for _, job := range jobs {
+		if s.reject[job.Title] {
+			return errRejected
+		}
+	}
+	return s.MemoryStore.SaveJobs(ctx, jobs)
+}
+
+func TestSaveJobsCountsPerJobFailures(t *testing.T) {
+	store := &rejectingStore{
+		MemoryStore: storage.NewMemoryStore(),
+		reject:      map[string]bool{"Designer": true, "Data Analyst": true},
+	}
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Designer", "Globex"),
+		testJob("RemoteOK", "Rust Developer", "Initech"),
+		testJob("RemoteOK", "Data Analyst", "Hooli"),
+	}}
+	ps := newTestScraper(t, store, source)
+
+	result, err := ps.saveJobs(context.Background(), source.jobs)
+	if err != nil {
+		t.Fatalf("saveJobs: %v", err)
+	}
+	if result.Saved != 2 || result.Failed != 2 {
+		t.Errorf("saved %d and failed %d, want 2 and 2", result.Saved, result.Failed)
+	}
+	if got, want := titles(result.SavedJobs), []string{"Go Developer", "Rust Developer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SavedJobs = %q, want %q", got, want)
+	}
+	if got, want := titles(result.UnsavedJobs), []string{"Designer", "Data Analyst"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnsavedJobs = %q, want %q", got, want)
+	}
+
+	// Each saved job is a distinct stored row, not the last one repeated
+	stored, err := store.GetJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := titles(stored), []string{"Go Developer", "Rust Developer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stored %q, want %q", got, want)
+	}
+}
+
+func TestScrapeCountsSaveFailuresAsErrors(t *testing.T) {
+	store := &rejectingStore{MemoryStore: storage.NewMemoryStore(), reject: map[string]bool{"Designer": true}}
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+	ps := newTestScraper(t, store, source)
+
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	metrics := ps.GetMetrics()
+	if metrics.TotalJobsSaved != 1 || metrics.TotalErrors != 1 {
+		t.Errorf("TotalJobsSaved = %d and TotalErrors = %d, want 1 and 1", metrics.TotalJobsSaved, metrics.TotalErrors)
+	}
+}
+
+// feedServer serves a JSON list of jobs with an ETag of its version,
+// answering 304 to requests that carry it. It records whether each request
+// was conditional.
+type feedServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	version     int
+	jobs        []models.Job
+	conditional []bool
+}
+
+func newFeedServer(t *testing.T, jobs ...models.Job) *feedServer {
+	t.Helper()
+
+	server := &feedServer{version: 1, jobs: jobs}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		etag := fmt.Sprintf(`"v%d"`, server.version)
+		server.conditional = append(server.conditional, r.Header.Get("If-None-Match") != "")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		json.NewEncoder(w).Encode(server.jobs)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// publish adds jobs to the feed as a new version
+func (s *feedServer) publish(jobs ...models.Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version++
+	s.jobs = append(s.jobs, jobs...)
+}
+
+// lastConditional reports whether the last request carried If-None-Match
+func (s *feedServer) lastConditional() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conditional[len(s.conditional)-1]
+}
+
+// feedSource is a JobSource fetching a feedServer, taking an unchanged feed
+// as no jobs like the real sources
+type feedSource struct {
+	fakeSource
+	client *httpclient.HttpClient
+	url    string
+}
+
+func (f *feedSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	resp, err := f.client.GetWithContext(ctx, f.url)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []models.Job
+	return jobs, json.Unmarshal(body, &jobs)
+}
+
+func TestUnsavedJobsSurviveUnchangedFeed(t *testing.T) {
+	posted := time.Now().Add(-time.Hour)
+	job := func(title, company string) models.Job {
+		job := testJob("RemoteOK", title, company)
+		job.PostedDate = &posted
+		return job
+	}
+	server := newFeedServer(t, job("Go Developer", "Acme"), job("Designer", "Globex"))
+
+	state, err := LoadScrapeState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &rejectingStore{MemoryStore: storage.NewMemoryStore(), reject: map[string]bool{"Designer": true}}
+	ps := newTestScraper(t, store)
+	ps.client.SetConditionalRequests(true)
+	ps.sourceManager.RegisterSource(&feedSource{fakeSource: fakeSource{name: "RemoteOK"}, client: ps.client, url: server.URL}, sources.JobSourceConfig{Enabled: true})
+	ps.SetScrapeState(state)
+
+	scrape := func() {
+		t.Helper()
+		if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+			t.Fatalf("ScrapeAllSources: %v", err)
+		}
+	}
+
+	// The Designer job fails to save, so the unchanged feed is fetched in
+	// full on the next poll rather than answered with 304
+	scrape()
+	scrape()
+	if server.lastConditional() {
+		t.Error("fetched a source with unsaved jobs conditionally")
+	}
+
+	// Once the storage recovers and the feed changes, the job is saved
+	delete(store.reject, "Designer")
+	later := time.Now()
+	rust := testJob("RemoteOK", "Rust Developer", "Initech")
+	rust.PostedDate = &later
+	server.publish(rust)
+	scrape()
+
+	stored, err := store.GetJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := titles(stored)
+	sort.Strings(got)
+	if want := []string{"Designer", "Go Developer", "Rust Developer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stored %q, want %q", got, want)
+	}
+
+	// With every job saved, polls are conditional again
+	scrape()
+	if !server.lastConditional() {
+		t.Error("fetched a source without unsaved jobs unconditionally")
+	}
+}
+
+// hangingSource is a JobSource whose fetches block until their context ends
+type hangingSource struct {
+	fakeSource
+}
+
+func (h *hangingSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestSourceTimeoutDoesNotBlockOtherSources(t *testing.T) {
+	hanging := &hangingSource{fakeSource{name: "Hanging"}}
+	fast := &fakeSource{name: "RemoteOK", jobs: []models.Job{testJob("RemoteOK", "Go Developer", "Acme")}}
+
+	// The client timeout of newTestScraper is far longer than the source's
+	ps := newTestScraper(t, storage.NewMemoryStore(), fast)
+	ps.sourceManager.RegisterSource(hanging, sources.JobSourceConfig{Enabled: true, Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	report, err := ps.ScrapeAllSources(context.Background())
+	if err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("run took %v, want the hanging source cut off after its timeout", elapsed)
+	}
+
+	if err := report.Failed["Hanging"]; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Hanging failed with %v, want a deadline exceeded error", err)
+	}
+	if !reflect.DeepEqual(report.Succeeded, []string{"RemoteOK"}) || report.SavedCount != 1 {
+		t.Errorf("succeeded %q saving %d jobs, want RemoteOK saving 1", report.Succeeded, report.SavedCount)
+	}
+}
+
+func TestScrapeAllSourcesSavesDespiteFailedSource(t *testing.T) {
+	store := storage.NewMemoryStore()
+	failing := &fakeSource{name: "Remotive", err: errors.New("service unavailable")}
+	working := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+	ps := newTestScraper(t, store, failing, working)
+
+	report, err := ps.ScrapeAllSources(context.Background())
+	if err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+
+	if !reflect.DeepEqual(report.Succeeded, []string{"RemoteOK"}) {
+		t.Errorf("Succeeded = %q, want [RemoteOK]", report.Succeeded)
+	}
+	if len(report.Failed) != 1 || report.Failed["Remotive"] == nil {
+		t.Errorf("Failed = %v, want only Remotive", report.Failed)
+	}
+	if report.SavedCount != 2 {
+		t.Errorf("SavedCount = %d, want 2", report.SavedCount)
+	}
+	if count, _ := store.Count(context.Background(), storage.JobFilter{Source: "RemoteOK"}); count != 2 {
+		t.Errorf("stored %d RemoteOK jobs, want 2", count)
+	}
+}
+
+func TestRunReportSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		report RunReport
+		want   string
+	}{
+		{"empty", RunReport{}, "0 sources succeeded, 0 failed, 0 jobs saved"},
+		{
+			"mixed",
+			RunReport{
+				Succeeded:  []string{"RemoteOK", "Remotive"},
+				Failed:     map[string]error{"Indeed": errors.New("forbidden"), "Glassdoor": errors.New("timed out")},
+				SavedCount: 12,
+			},
+			"2 sources succeeded (RemoteOK, Remotive), 2 failed (Glassdoor: timed out; Indeed: forbidden), 12 jobs saved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.report.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveJobsSkipsInvalidJobs(t *testing.T) {
+	noCompany := testJob("RemoteOK", "Designer", "")
+	noURL := testJob("RemoteOK", "Data Analyst", "Hooli")
+	noURL.URL = ""
+	jobs := []models.Job{testJob("RemoteOK", "Go Developer", "Acme"), noCompany, noURL}
+
+	tests := []struct {
+		level       string
+		wantSaved   int
+		wantInvalid []string
+	}{
+		{models.ValidationOff, 3, nil},
+		{models.ValidationBasic, 2, []string{"Data Analyst"}},
+		{models.ValidationStrict, 1, []string{"Designer", "Data Analyst"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			store := storage.NewMemoryStore()
+			ps := newTestScraper(t, store)
+			ps.SetValidationLevel(tt.level)
+
+			result, err := ps.saveJobs(context.Background(), jobs)
+			if err != nil {
+				t.Fatalf("saveJobs: %v", err)
+			}
+			if result.Saved != tt.wantSaved || result.Invalid != len(tt.wantInvalid) {
+				t.Errorf("saved %d with %d invalid, want %d with %d", result.Saved, result.Invalid, tt.wantSaved, len(tt.wantInvalid))
+			}
+			if got := titles(result.UnsavedJobs); !reflect.DeepEqual(got, tt.wantInvalid) {
+				t.Errorf("UnsavedJobs = %q, want %q", got, tt.wantInvalid)
+			}
+			if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != int64(tt.wantSaved) {
+				t.Errorf("stored %d jobs, want %d", count, tt.wantSaved)
+			}
+		})
+	}
+}
+
+// categorySource is a fakeSource with category feeds, recording the
+// categories requested
+type categorySource struct {
+	fakeSource
+	categories map[string][]models.Job
+	requested  []string
+}
+
+func (c *categorySource) FetchJobsByCategory(ctx context.Context, category string) ([]models.Job, error) {
+	c.requested = append(c.requested, category)
+	jobs, ok := c.categories[category]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", sources.ErrUnknownCategory, category)
+	}
+	return jobs, nil
+}
+
+func TestScrapeByCategoryUsesCategoryFeeds(t *testing.T) {
+	other := testJob("WeWorkRemotely", "DevOps Engineer", "Globex")
+	other.JobCategory = "DevOps"
+	source := &categorySource{
+		fakeSource: fakeSource{name: "WeWorkRemotely", jobs: []models.Job{other, testJob("WeWorkRemotely", "Writer", "Initech")}},
+		categories: map[string][]models.Job{"programming": {testJob("WeWorkRemotely", "Go Developer", "Acme")}},
+	}
+	ps := newTestScraper(t, storage.NewMemoryStore(), source)
+
+	report, err := ps.ScrapeByCategory(context.Background(), []string{"programming", "devops"})
+	if err != nil {
+		t.Fatalf("ScrapeByCategory: %v", err)
+	}
+
+	if want := []string{"programming", "devops"}; !reflect.DeepEqual(source.requested, want) {
+		t.Errorf("requested categories %q, want %q", source.requested, want)
+	}
+	// The unknown category falls back to filtering the full feed
+	if source.fetchCount() != 1 {
+		t.Errorf("fetched the full feed %d times, want once", source.fetchCount())
+	}
+	if got, want := titles(report.Jobs), []string{"Go Developer", "DevOps Engineer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scraped %q, want %q", got, want)
+	}
+}
+
+// categorizedJob returns a job from source in the given category
+func categorizedJob(source, title, company, category string) models.Job {
+	job := testJob(source, title, company)
+	job.JobCategory = category
+	return job
+}
+
+func TestScrapeByCategorySavesOnlyMatchingJobs(t *testing.T) {
+	remoteOK := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		categorizedJob("RemoteOK", "Site Reliability Engineer", "Acme", "DevOps"),
+		categorizedJob("RemoteOK", "Product Designer", "Globex", "Design"),
+	}}
+	remotive := &fakeSource{name: "Remotive", jobs: []models.Job{
+		categorizedJob("Remotive", "Data Analyst", "Initech", "Data Science"),
+		categorizedJob("Remotive", "Platform Engineer", "Hooli", "DevOps"),
+		categorizedJob("Remotive", "Go Developer", "Umbrella", "Backend Development"),
+	}}
+	store := storage.NewMemoryStore()
+	ps := newTestScraper(t, store, remoteOK, remotive)
+
+	report, err := ps.ScrapeByCategory(context.Background(), []string{"devops", "data-science"})
+	if err != nil {
+		t.Fatalf("ScrapeByCategory: %v", err)
+	}
+	if report.SavedCount != 3 {
+		t.Errorf("SavedCount = %d, want 3", report.SavedCount)
+	}
+
+	stored, err := store.GetJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := titles(stored)
+	sort.Strings(got)
+	if want := []string{"Data Analyst", "Platform Engineer", "Site Reliability Engineer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stored %q, want %q", got, want)
+	}
+
+	counts := ps.GetMetrics().CategoryCounts
+	if counts["devops"] != 2 || counts["data-science"] != 1 {
+		t.Errorf("CategoryCounts = %v, want devops 2 and data-science 1", counts)
+	}
+}
+
+func TestFilterByCategory(t *testing.T) {
+	jobs := []models.Job{
+		categorizedJob("RemoteOK", "Go Developer", "Acme", "Backend Development"),
+		categorizedJob("RemoteOK", "SRE", "Globex", "DevOps"),
+		categorizedJob("RemoteOK", "Engineer", "Initech", "Software Dev"),
+	}
+
+	tests := []struct {
+		category string
+		want     []string
+	}{
+		{"backend", []string{"Go Developer"}},
+		{"Backend Development", []string{"Go Developer"}},
+		{"DEVOPS", []string{"SRE"}},
+		{"software-dev", []string{"Engineer"}},
+		{"design", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := titles(FilterByCategory(jobs, tt.category)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FilterByCategory(%q) = %q, want %q", tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestSeedDeduplicatorSkipsStoredPostings(t *testing.T) {
+	stored := testJob("Remotive", "Go Developer", "Acme")
+	store := storage.NewMemoryStore()
+	if err := store.SaveJob(context.Background(), &stored); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same posting on another board has another URL, so only the
+	// seeded deduplicator recognizes it
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+	ps := newTestScraper(t, store, source)
+	if err := ps.SeedDeduplicator(context.Background()); err != nil {
+		t.Fatalf("SeedDeduplicator: %v", err)
+	}
+
+	report, err := ps.ScrapeAllSources(context.Background())
+	if err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	if got := titles(report.Jobs); !reflect.DeepEqual(got, []string{"Designer"}) {
+		t.Errorf("scraped %q, want only the new Designer job", got)
+	}
+	if metrics := ps.GetMetrics(); metrics.TotalDuplicates != 1 || metrics.TotalJobsSaved != 1 {
+		t.Errorf("duplicates %d and saved %d, want 1 and 1", metrics.TotalDuplicates, metrics.TotalJobsSaved)
+	}
+}
+
+func TestOnJobsScrapedCalledOncePerSource(t *testing.T) {
+	remoteOK := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Go Developer", "Acme"), // duplicate
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+	remotive := &fakeSource{name: "Remotive", jobs: []models.Job{testJob("Remotive", "Data Analyst", "Initech")}}
+	failing := &fakeSource{name: "Indeed", err: errors.New("forbidden")}
+	ps := newTestScraper(t, storage.NewMemoryStore(), remoteOK, remotive, failing)
+
+	calls := make(map[string][]string)
+	ps.OnJobsScraped(func(source string, jobs []models.Job) {
+		if _, ok := calls[source]; ok {
+			t.Errorf("callback invoked again for %s", source)
+		}
+		calls[source] = titles(jobs)
+	})
+
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+
+	// The callback sees the jobs after deduplication, and not failed sources
+	want := map[string][]string{
+		"RemoteOK": {"Go Developer", "Designer"},
+		"Remotive": {"Data Analyst"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("callback calls = %v, want %v", calls, want)
+	}
+}
+
+func TestOnJobsScrapedCalledForScrapeSource(t *testing.T) {
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{testJob("RemoteOK", "Go Developer", "Acme")}}
+	store := storage.NewMemoryStore()
+	ps := newTestScraper(t, store, source)
+
+	var calls int
+	ps.OnJobsScraped(func(name string, jobs []models.Job) {
+		calls++
+		if name != "RemoteOK" || len(jobs) != 1 {
+			t.Errorf("callback got %d jobs from %s, want 1 from RemoteOK", len(jobs), name)
+		}
+		// The callback runs before the jobs are saved
+		if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != 0 {
+			t.Errorf("%d jobs stored when the callback ran, want 0", count)
+		}
+	})
+
+	if _, err := ps.ScrapeSource(context.Background(), "RemoteOK"); err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("callback invoked %d times, want once", calls)
+	}
+}
+
+// blockingStore is a memory store whose saves block until their context ends
+type blockingStore struct {
+	*storage.MemoryStore
+	saves atomic.Int32
+}
+
+func (s *blockingStore) SaveJobs(ctx context.Context, jobs []models.Job) error {
+	s.saves.Add(1)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *blockingStore) SaveJob(ctx context.Context, job *models.Job) error {
+	s.saves.Add(1)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestSaveJobsReturnsPromptlyWhenCancelled(t *testing.T) {
+	store := &blockingStore{MemoryStore: storage.NewMemoryStore()}
+	ps := newTestScraper(t, store)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := ps.saveJobs(ctx, numberedJobs("RemoteOK", 120))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("saveJobs = %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("saveJobs returned after %v, want promptly after the context ended", elapsed)
+	}
+	// A cancelled batch neither falls back to single saves nor starts the next batches
+	if saves := store.saves.Load(); saves != 1 {
+		t.Errorf("made %d saves, want only the first batch", saves)
+	}
+}
+
+// flakyStore is a memory store whose batch saves fail with err until they
+// have been attempted failures times
+type flakyStore struct {
+	*storage.MemoryStore
+	failures int
+	err      error
+
+	attempts int
+}
+
+func (s *flakyStore) SaveJobs(ctx context.Context, jobs []models.Job) error {
+	s.attempts++
+	if s.attempts <= s.failures {
+		return s.err
+	}
+	return s.MemoryStore.SaveJobs(ctx, jobs)
+}
+
+func TestSaveJobsRetriesTransientFailures(t *testing.T) {
+	unavailable := &postgrest.RequestError{Message: "service unavailable", HTTPStatusCode: http.StatusServiceUnavailable}
+
+	tests := []struct {
+		name         string
+		maxRetries   int
+		err          error
+		wantSaved    int
+		wantAttempts int
+	}{
+		{"succeeds on the third attempt", 2, unavailable, 2, 3},
+		{"network error", 2, errors.New("connection reset by peer"), 2, 3},
+		// A still failing store is not asked to save each job on its own
+		{"retries exhausted", 1, unavailable, 0, 2},
+		// The individual saves of the memory store succeed
+		{"permanent failure is not retried", 2, errRejected, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &flakyStore{MemoryStore: storage.NewMemoryStore(), failures: 2, err: tt.err}
+			ps := newTestScraper(t, store)
+			ps.SetSaveRetryConfig(RetryConfig{MaxRetries: tt.maxRetries, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1})
+
+			jobs := []models.Job{testJob("RemoteOK", "Go Developer", "Acme"), testJob("RemoteOK", "Designer", "Globex")}
+			result, err := ps.saveJobs(context.Background(), jobs)
+			if err != nil {
+				t.Fatalf("saveJobs: %v", err)
+			}
+			if result.Saved != tt.wantSaved || result.Failed != len(jobs)-tt.wantSaved {
+				t.Errorf("saved %d and failed %d, want %d and %d", result.Saved, result.Failed, tt.wantSaved, len(jobs)-tt.wantSaved)
+			}
+			if store.attempts != tt.wantAttempts {
+				t.Errorf("attempted the batch %d times, want %d", store.attempts, tt.wantAttempts)
+			}
+			if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != int64(tt.wantSaved) {
+				t.Errorf("stored %d jobs, want %d", count, tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestNewSaveRetryConfig(t *testing.T) {
+	cfg := config.DefaultConfig().Scraper
+	cfg.RetryAttempts = 5
+	cfg.SaveRetryAttempts = 1
+
+	retryConfig := NewSaveRetryConfig(cfg)
+	if retryConfig.MaxRetries != 1 {
+		t.Errorf("MaxRetries = %d, want the save retry attempts 1", retryConfig.MaxRetries)
+	}
+	if retryConfig.InitialDelay != cfg.RetryDelay.Duration || retryConfig.BackoffFactor != cfg.BackoffFactor {
+		t.Errorf("delays %v x%v, want the fetch retry delays %v x%v", retryConfig.InitialDelay, retryConfig.BackoffFactor, cfg.RetryDelay.Duration, cfg.BackoffFactor)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	rc := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: 450 * time.Millisecond, BackoffFactor: 2}
+	never := func() float64 {
+		t.Error("random drawn without jitter")
+		return 0
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 200 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{3, 450 * time.Millisecond}, // capped at MaxDelay
+	}
+	for _, tt := range tests {
+		if got := rc.backoffDelay(tt.attempt, never); got != tt.want {
+			t.Errorf("backoffDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffDelayJitterBounds(t *testing.T) {
+	rc := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, BackoffFactor: 2, Jitter: true}
+
+	// The extremes of the random source give the bounds of the jitter
+	if got := rc.backoffDelay(2, func() float64 { return 0 }); got != 200*time.Millisecond {
+		t.Errorf("backoffDelay with random 0 = %v, want half the delay, 200ms", got)
+	}
+	if got := rc.backoffDelay(2, func() float64 { return 0.5 }); got != 300*time.Millisecond {
+		t.Errorf("backoffDelay with random 0.5 = %v, want 300ms", got)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 1000; i++ {
+		attempt := i%5 + 1
+		computed := rc.backoffDelay(attempt, func() float64 { return 1 })
+		got := rc.backoffDelay(attempt, random.Float64)
+		if got < computed/2 || got > computed {
+			t.Fatalf("backoffDelay(%d) = %v, want within [%v, %v]", attempt, got, computed/2, computed)
+		}
+		seen[got] = true
+	}
+	// Sources failing together must not retry in lockstep
+	if len(seen) < 100 {
+		t.Errorf("1000 jittered delays took only %d values", len(seen))
+	}
+}
+
+func TestDryRunDoesNotSave(t *testing.T) {
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		testJob("RemoteOK", "Go Developer", "Acme"),
+		testJob("RemoteOK", "Go Developer", "Acme"), // duplicate
+		testJob("RemoteOK", "Designer", "Globex"),
+	}}
+
+	tests := []struct {
+		name   string
+		scrape func(ps *PowerScraper) error
+	}{
+		{"ScrapeAllSources", func(ps *PowerScraper) error { _, err := ps.ScrapeAllSources(context.Background()); return err }},
+		{"ScrapeSource", func(ps *PowerScraper) error { _, err := ps.ScrapeSource(context.Background(), "RemoteOK"); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := LoadScrapeState(filepath.Join(t.TempDir(), "state.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := &lookupStore{MemoryStore: storage.NewMemoryStore()}
+			ps := newTestScraper(t, store, source)
+			ps.SetOptions(Options{DryRun: true})
+			ps.SetScrapeState(state)
+
+			if err := tt.scrape(ps); err != nil {
+				t.Fatalf("scrape: %v", err)
+			}
+
+			if len(store.saved) != 0 {
+				t.Errorf("saved %q in a dry run, want nothing", titles(store.saved))
+			}
+			if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != 0 {
+				t.Errorf("stored %d jobs in a dry run, want none", count)
+			}
+			metrics := ps.GetMetrics()
+			if metrics.TotalJobsScraped != 3 || metrics.TotalDuplicates != 1 || metrics.TotalJobsSaved != 0 {
+				t.Errorf("scraped %d, duplicates %d, saved %d; want 3, 1 and 0",
+					metrics.TotalJobsScraped, metrics.TotalDuplicates, metrics.TotalJobsSaved)
+			}
+			// A dry run must not skip the jobs in the next real run
+			if _, ok := state.LastScrapeTime("RemoteOK"); ok {
+				t.Error("a dry run advanced the scrape state")
+			}
+		})
+	}
+}
+
+// Run with -race to check metrics are only touched under the lock
+func TestGetMetricsDuringScrape(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 20)},
+		&fakeSource{name: "Remotive", jobs: numberedJobs("Remotive", 20)},
+		&fakeSource{name: "Indeed", err: errors.New("service unavailable")},
+	)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				ps.GetMetrics()
+			}
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+			t.Fatalf("ScrapeAllSources: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if got := ps.GetMetrics().TotalJobsScraped; got != 120 {
+		t.Errorf("TotalJobsScraped = %d, want 120", got)
+	}
+}
+
+func TestResetMetrics(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 5)},
+		&fakeSource{name: "Remotive", err: errors.New("service unavailable")},
+	)
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	before := ps.GetMetrics()
+	if before.TotalJobsScraped == 0 || before.TotalErrors == 0 {
+		t.Fatalf("before reset: TotalJobsScraped = %d, TotalErrors = %d, want both counted", before.TotalJobsScraped, before.TotalErrors)
+	}
+
+	ps.ResetMetrics()
+
+	got := ps.GetMetrics()
+	if got.TotalJobsScraped != 0 || got.TotalJobsSaved != 0 || got.TotalDuplicates != 0 ||
+		got.TotalErrors != 0 || got.SuspiciousRuns != 0 || got.ScrapingDuration != 0 {
+		t.Errorf("after reset: scraped %d, saved %d, duplicates %d, errors %d, suspicious %d, duration %v, want all zero",
+			got.TotalJobsScraped, got.TotalJobsSaved, got.TotalDuplicates, got.TotalErrors, got.SuspiciousRuns, got.ScrapingDuration)
+	}
+	if len(got.SourcePerformance) != 0 {
+		t.Errorf("SourcePerformance after reset = %v, want empty", got.SourcePerformance)
+	}
+	if before.TotalJobsScraped != 5 || len(before.SourcePerformance) != 2 {
+		t.Errorf("copy taken before reset changed: scraped %d, sources %d", before.TotalJobsScraped, len(before.SourcePerformance))
+	}
+}
+
+// Run with -race to check the reset takes the lock
+func TestResetMetricsDuringRead(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 5)},
+	)
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ps.ResetMetrics()
+		}()
+		go func() {
+			defer wg.Done()
+			if metrics := ps.GetMetrics(); metrics.TotalJobsScraped != 0 && metrics.TotalJobsScraped != 5 {
+				t.Errorf("TotalJobsScraped = %d, want 0 or 5", metrics.TotalJobsScraped)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := ps.GetMetrics().TotalJobsScraped; got != 0 {
+		t.Errorf("TotalJobsScraped = %d, want 0", got)
+	}
+}
+
+func TestScrapeRecordsLastError(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "Remotive", err: errors.New("service unavailable")},
+		&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 2)},
+	)
+
+	start := time.Now()
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+
+	metrics := ps.GetMetrics()
+	failed := metrics.SourcePerformance["Remotive"]
+	if !strings.Contains(failed.LastError, "service unavailable") {
+		t.Errorf("LastError = %q, want it to contain %q", failed.LastError, "service unavailable")
+	}
+	if failed.LastErrorTime.Before(start) {
+		t.Errorf("LastErrorTime = %v, want after %v", failed.LastErrorTime, start)
+	}
+	if ok := metrics.SourcePerformance["RemoteOK"]; ok.LastError != "" || !ok.LastErrorTime.IsZero() {
+		t.Errorf("RemoteOK LastError = %q at %v, want none", ok.LastError, ok.LastErrorTime)
+	}
+}
+
+func TestSourceMetricsAverages(t *testing.T) {
+	var m SourceMetrics
+	if m.SuccessRate() != 0 || m.AvgResponseTime() != 0 {
+		t.Fatalf("empty metrics: SuccessRate = %v, AvgResponseTime = %v, want zero", m.SuccessRate(), m.AvgResponseTime())
+	}
+
+	// The first duration seeds the average; each later one has weight 0.3
+	m.record(100*time.Millisecond, true)
+	m.record(200*time.Millisecond, true)
+	m.record(300*time.Millisecond, false)
+	m.record(100*time.Millisecond, true)
+
+	if m.Requests != 4 || m.Successes != 3 {
+		t.Errorf("Requests = %d, Successes = %d, want 4 and 3", m.Requests, m.Successes)
+	}
+	if got := m.SuccessRate(); got != 0.75 {
+		t.Errorf("SuccessRate = %v, want 0.75", got)
+	}
+
+	// 100 -> 130 -> 181 -> 156.7
+	want := 156700 * time.Microsecond
+	if got := m.AvgResponseTime(); got < want-time.Microsecond || got > want+time.Microsecond {
+		t.Errorf("AvgResponseTime = %v, want %v", got, want)
+	}
+}
+
+func TestScrapeRecordsSuccessRate(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "Remotive", err: errors.New("service unavailable")},
+		&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 2)},
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+			t.Fatalf("ScrapeAllSources: %v", err)
+		}
+	}
+
+	metrics := ps.GetMetrics()
+	tests := []struct {
+		source string
+		want   float64
+	}{
+		{source: "RemoteOK", want: 1},
+		{source: "Remotive", want: 0},
+	}
+	for _, tt := range tests {
+		perf := metrics.SourcePerformance[tt.source]
+		if perf.Requests != 2 {
+			t.Errorf("%s Requests = %d, want 2", tt.source, perf.Requests)
+		}
+		if got := perf.SuccessRate(); got != tt.want {
+			t.Errorf("%s SuccessRate = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeAllSourcesSplitsDuplicates(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "RemoteOK", jobs: []models.Job{
+			testJob("RemoteOK", "Go Developer", "Acme"),
+			testJob("RemoteOK", "Designer", "Globex"),
+			testJob("RemoteOK", "Designer", "Globex"),
+		}},
+		&fakeSource{name: "Remotive", jobs: []models.Job{
+			testJob("Remotive", "Go Developer", "Acme"),
+			testJob("Remotive", "Writer", "Initech"),
+		}},
+	)
+
+	report, err := ps.ScrapeAllSources(context.Background())
+	if err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	if report.SavedCount != 3 {
+		t.Errorf("saved %d jobs, want 3", report.SavedCount)
+	}
+
+	// Sources run concurrently, so either may see the shared posting first
+	metrics := ps.GetMetrics()
+	if metrics.WithinSourceDuplicates != 1 || metrics.CrossSourceDuplicates != 1 || metrics.TotalDuplicates != 2 {
+		t.Errorf("duplicates: within %d, cross %d, total %d, want 1, 1 and 2",
+			metrics.WithinSourceDuplicates, metrics.CrossSourceDuplicates, metrics.TotalDuplicates)
+	}
+	cross := metrics.SourcePerformance["RemoteOK"].CrossSourceDuplicates + metrics.SourcePerformance["Remotive"].CrossSourceDuplicates
+	if cross != 1 {
+		t.Errorf("per-source cross-source duplicates add up to %d, want 1", cross)
+	}
+}
+
+// Run with -race to check concurrent batch saves share the result safely
+func TestSaveJobsConcurrentBatches(t *testing.T) {
+	jobs := numberedJobs("RemoteOK", 230) // five batches, the last one partial
+	reject := map[string]bool{"Developer 10": true, "Developer 120": true, "Developer 229": true}
+
+	for _, concurrency := range []int{1, 4, 10} {
+		t.Run(fmt.Sprint(concurrency), func(t *testing.T) {
+			store := &rejectingStore{MemoryStore: storage.NewMemoryStore(), reject: reject}
+			ps := newTestScraper(t, store)
+			ps.SetSaveConcurrency(concurrency)
+
+			result, err := ps.saveJobs(context.Background(), jobs)
+			if err != nil {
+				t.Fatalf("saveJobs: %v", err)
+			}
+			if result.Saved != 227 || result.Failed != 3 {
+				t.Errorf("saved %d and failed %d, want 227 and 3", result.Saved, result.Failed)
+			}
+			if len(result.SavedJobs) != 227 || len(result.UnsavedJobs) != 3 {
+				t.Errorf("%d SavedJobs and %d UnsavedJobs, want 227 and 3", len(result.SavedJobs), len(result.UnsavedJobs))
+			}
+			if count, _ := store.Count(context.Background(), storage.JobFilter{}); count != 227 {
+				t.Errorf("stored %d jobs, want 227", count)
+			}
+		})
+	}
+}
+
+func TestSourcePriorityKeepsPreferredCopy(t *testing.T) {
+	tests := []struct {
+		name       string
+		priority   []string
+		wantSource string
+	}{
+		{"remotive first", []string{"Remotive", "RemoteOK"}, "Remotive"},
+		{"remoteok first", []string{"remoteok"}, "RemoteOK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStore()
+			ps := newTestScraper(t, store,
+				&fakeSource{name: "RemoteOK", jobs: []models.Job{testJob("RemoteOK", "Go Developer", "Acme")}},
+				&fakeSource{name: "Remotive", jobs: []models.Job{testJob("Remotive", "Go Developer", "Acme")}},
+			)
+			ps.SetSourcePriority(tt.priority)
+
+			if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+				t.Fatalf("ScrapeAllSources: %v", err)
+			}
+
+			stored, err := store.GetJobs(context.Background())
+			if err != nil {
+				t.Fatalf("GetJobs: %v", err)
+			}
+			if len(stored) != 1 || stored[0].Source != tt.wantSource {
+				t.Errorf("stored %+v, want only the %s copy", stored, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestScrapeAllSourcesScrapesListedSources(t *testing.T) {
+	newSources := func() []*fakeSource {
+		return []*fakeSource{
+			{name: "RemoteOK", jobs: numberedJobs("RemoteOK", 1)},
+			{name: "Remotive", jobs: numberedJobs("Remotive", 1)},
+			{name: "WeWorkRemotely", jobs: numberedJobs("WeWorkRemotely", 1)},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		sources     []string
+		wantFetched []int
+		wantErr     bool
+	}{
+		{name: "all enabled", wantFetched: []int{1, 1, 1}},
+		{name: "subset", sources: []string{"remoteok", "WEWORKREMOTELY"}, wantFetched: []int{1, 0, 1}},
+		{name: "unknown source", sources: []string{"remotive", "monster"}, wantFetched: []int{0, 0, 0}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcs := newSources()
+			ps := newTestScraper(t, storage.NewMemoryStore(), srcs[0], srcs[1], srcs[2])
+			ps.SetOptions(Options{Sources: tt.sources})
+
+			_, err := ps.ScrapeAllSources(context.Background())
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "monster") {
+					t.Errorf("ScrapeAllSources error = %v, want one naming the unknown source", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ScrapeAllSources: %v", err)
+			}
+
+			for i, src := range srcs {
+				if got := src.fetchCount(); got != tt.wantFetched[i] {
+					t.Errorf("fetched %s %d times, want %d", src.name, got, tt.wantFetched[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScrapeFlagsSuspiciousRuns(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobs           int
+		failBelowMin   bool
+		wantSuspicious int64
+		wantErr        bool
+	}{
+		{name: "enough jobs", jobs: 5},
+		{name: "too few jobs", jobs: 2, wantSuspicious: 1},
+		{name: "no jobs", jobs: 0, wantSuspicious: 1},
+		{name: "too few jobs as error", jobs: 2, failBelowMin: true, wantSuspicious: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			ps := newTestScraper(t, storage.NewMemoryStore())
+			ps.logger = logging.New(&logs, "", 0, logging.LevelWarn)
+			ps.sourceManager.RegisterSource(&fakeSource{name: "RemoteOK", jobs: numberedJobs("RemoteOK", tt.jobs)},
+				sources.JobSourceConfig{Enabled: true, MinExpectedJobs: 5, FailBelowMinJobs: tt.failBelowMin})
+
+			_, err := ps.ScrapeSource(context.Background(), "RemoteOK")
+			if gotErr := errors.Is(err, ErrTooFewJobs); gotErr != tt.wantErr || (err != nil && !tt.wantErr) {
+				t.Errorf("ScrapeSource error = %v, want ErrTooFewJobs: %v", err, tt.wantErr)
+			}
+
+			metrics := ps.GetMetrics()
+			if metrics.SuspiciousRuns != tt.wantSuspicious || metrics.SourcePerformance["RemoteOK"].SuspiciousRuns != tt.wantSuspicious {
+				t.Errorf("SuspiciousRuns = %d (RemoteOK %d), want %d",
+					metrics.SuspiciousRuns, metrics.SourcePerformance["RemoteOK"].SuspiciousRuns, tt.wantSuspicious)
+			}
+			if warned := strings.Contains(logs.String(), "Suspicious run for RemoteOK"); warned != (tt.wantSuspicious > 0) {
+				t.Errorf("logged %q, want a suspicious run warning: %v", logs.String(), tt.wantSuspicious > 0)
+			}
+		})
+	}
+}
+
+func TestInitializeSourcesMasksCredentials(t *testing.T) {
+	var logs bytes.Buffer
+	ps := newTestScraper(t, storage.NewMemoryStore())
+	ps.logger = logging.New(&logs, "", 0, logging.LevelDebug)
+
+	sourcesConfig := config.DefaultConfig().Sources
+	sourcesConfig.Remotive.Auth = config.AuthConfig{BearerToken: "sk-live-abcdef123456"}
+	ps.InitializeSources(sourcesConfig)
+
+	if strings.Contains(logs.String(), "abcdef") {
+		t.Errorf("logs contain the token:\n%s", logs.String())
+	}
+	if want := "Source remotive authenticates with Authorization: Bear***3456"; !strings.Contains(logs.String(), want) {
+		t.Errorf("logs = %q, want %q", logs.String(), want)
+	}
+}
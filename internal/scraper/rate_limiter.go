@@ -2,22 +2,29 @@ package scraper
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 )
 
-// RateLimiter manages rate limiting for different sources
+// RateLimiter manages rate limiting for different sources. Sources are keyed
+// by their trimmed, lowercased name, so "RemoteOK" and "remoteok" share one
+// bucket however a caller spells the name.
 type RateLimiter struct {
 	limiters map[string]*sourceLimiter
 	mu       sync.RWMutex
 }
 
-// sourceLimiter handles rate limiting for a specific source
+// sourceLimiter is a token bucket for a specific source. It holds up to burst
+// tokens, refilled continuously at limit tokens per window, and each request
+// takes one token.
 type sourceLimiter struct {
-	tokens   chan struct{}
-	refill   *time.Ticker
 	limit    int
 	duration time.Duration
+	burst    int
+	rate     float64 // tokens per second
+	tokens   float64
+	last     time.Time
 	mu       sync.Mutex
 }
 
@@ -30,23 +37,49 @@ func NewRateLimiter() *RateLimiter {
 
 // Wait waits for permission to make a request to the specified source
 func (rl *RateLimiter) Wait(ctx context.Context, source string, requestsPerMinute int) error {
-	limiter := rl.getLimiter(source, requestsPerMinute)
+	return rl.WaitWithWindow(ctx, source, requestsPerMinute, time.Minute)
+}
+
+// WaitWithWindow waits for permission to make a request to the specified source,
+// allowing up to limit requests per window with no bursts
+func (rl *RateLimiter) WaitWithWindow(ctx context.Context, source string, limit int, window time.Duration) error {
+	return rl.WaitWithBurst(ctx, source, limit, window, 1)
+}
 
-	select {
-	case <-ctx.Done():
+// WaitWithBurst waits for permission to make a request to the specified source,
+// allowing up to limit requests per window and up to burst requests at once
+// after the source has been idle. A zero window defaults to one minute, a burst
+// of zero or less to one, and a limit of zero or less means the source is not
+// rate limited.
+func (rl *RateLimiter) WaitWithBurst(ctx context.Context, source string, limit int, window time.Duration, burst int) error {
+	if limit <= 0 {
 		return ctx.Err()
-	case <-limiter.tokens:
-		return nil
 	}
+
+	if window <= 0 {
+		window = time.Minute
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return rl.getLimiter(source, limit, window, burst).wait(ctx)
+}
+
+// limiterKey normalizes a source name into its rate limiter key
+func limiterKey(source string) string {
+	return strings.ToLower(strings.TrimSpace(source))
 }
 
 // getLimiter gets or creates a rate limiter for a source
-func (rl *RateLimiter) getLimiter(source string, requestsPerMinute int) *sourceLimiter {
+func (rl *RateLimiter) getLimiter(source string, limit int, window time.Duration, burst int) *sourceLimiter {
+	source = limiterKey(source)
+
 	rl.mu.RLock()
 	limiter, exists := rl.limiters[source]
 	rl.mu.RUnlock()
 
-	if exists && limiter.limit == requestsPerMinute {
+	if exists && limiter.matches(limit, window, burst) {
 		return limiter
 	}
 
@@ -54,57 +87,78 @@ func (rl *RateLimiter) getLimiter(source string, requestsPerMinute int) *sourceL
 	defer rl.mu.Unlock()
 
 	// Double-check after acquiring write lock
-	if limiter, exists := rl.limiters[source]; exists && limiter.limit == requestsPerMinute {
+	if limiter, exists := rl.limiters[source]; exists && limiter.matches(limit, window, burst) {
 		return limiter
 	}
 
-	// Clean up existing limiter if it exists
-	if limiter, exists := rl.limiters[source]; exists {
-		limiter.refill.Stop()
-	}
-
-	// Create new limiter
-	duration := time.Minute
-	tokens := make(chan struct{}, requestsPerMinute)
-
-	// Fill initial tokens
-	for i := 0; i < requestsPerMinute; i++ {
-		tokens <- struct{}{}
-	}
-
-	refillTicker := time.NewTicker(duration / time.Duration(requestsPerMinute))
-
+	// Start with a full bucket
 	limiter = &sourceLimiter{
-		tokens:   tokens,
-		refill:   refillTicker,
-		limit:    requestsPerMinute,
-		duration: duration,
+		limit:    limit,
+		duration: window,
+		burst:    burst,
+		rate:     float64(limit) / window.Seconds(),
+		tokens:   float64(burst),
+		last:     time.Now(),
 	}
 
-	// Start refill goroutine
-	go limiter.startRefill()
-
 	rl.limiters[source] = limiter
 	return limiter
 }
 
-// startRefill continuously refills tokens
-func (sl *sourceLimiter) startRefill() {
-	for range sl.refill.C {
+// matches reports whether the limiter was created with the given settings
+func (sl *sourceLimiter) matches(limit int, window time.Duration, burst int) bool {
+	return sl.limit == limit && sl.duration == window && sl.burst == burst
+}
+
+// wait takes a token, sleeping until one is available or ctx is done
+func (sl *sourceLimiter) wait(ctx context.Context) error {
+	for {
+		delay := sl.reserve(time.Now())
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
 		select {
-		case sl.tokens <- struct{}{}:
-		default:
-			// Channel full, skip this refill
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
 		}
 	}
 }
 
-// Stop stops all rate limiters
+// reserve takes a token if one is available and returns zero, or returns how
+// long until the next token is added
+func (sl *sourceLimiter) reserve(now time.Time) time.Duration {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	if elapsed := now.Sub(sl.last); elapsed > 0 {
+		sl.tokens += elapsed.Seconds() * sl.rate
+		if sl.tokens > float64(sl.burst) {
+			sl.tokens = float64(sl.burst)
+		}
+		sl.last = now
+	}
+
+	if sl.tokens >= 1 {
+		sl.tokens--
+		return 0
+	}
+
+	delay := time.Duration((1 - sl.tokens) / sl.rate * float64(time.Second))
+	if delay <= 0 {
+		delay = time.Nanosecond
+	}
+	return delay
+}
+
+// Stop releases the rate limiters. Buckets are refilled lazily, so no
+// background work remains; later waits start from full buckets.
 func (rl *RateLimiter) Stop() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	for _, limiter := range rl.limiters {
-		limiter.refill.Stop()
-	}
+	rl.limiters = make(map[string]*sourceLimiter)
 }
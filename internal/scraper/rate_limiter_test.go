@@ -0,0 +1,247 @@
+package scraper
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterDoesNotLeakGoroutinesWhenLimitsChange(t *testing.T) {
+	rl := NewRateLimiter()
+	ctx := context.Background()
+
+	if err := rl.Wait(ctx, "RemoteOK", 60); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	// Every new limit replaces the source's limiter
+	for limit := 61; limit < 561; limit++ {
+		if err := rl.Wait(ctx, "RemoteOK", limit); err != nil {
+			t.Fatalf("Wait with limit %d: %v", limit, err)
+		}
+	}
+	rl.Stop()
+
+	runtime.GC()
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines grew from %d to %d after replacing the limiter 500 times", before, after)
+	}
+}
+
+func TestRateLimiterStopResetsLimiters(t *testing.T) {
+	rl := NewRateLimiter()
+	if err := rl.Wait(context.Background(), "RemoteOK", 1); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	rl.Stop()
+
+	// The bucket starts full again, so the next request is not delayed
+	start := time.Now()
+	if err := rl.Wait(context.Background(), "RemoteOK", 1); err != nil {
+		t.Fatalf("Wait after Stop: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Wait after Stop took %v, want no delay", elapsed)
+	}
+}
+
+func TestSourceLimiterRefillsOverWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		window   time.Duration
+		interval time.Duration // between tokens
+	}{
+		{"per minute", 60, time.Minute, time.Second},
+		{"per ten seconds", 10, 10 * time.Second, time.Second},
+		{"per second", 4, time.Second, 250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter().getLimiter("source", tt.limit, tt.window, 1)
+			start := limiter.last
+
+			if delay := limiter.reserve(start); delay != 0 {
+				t.Fatalf("first request delayed %v, want none", delay)
+			}
+			if delay := limiter.reserve(start); delay != tt.interval {
+				t.Errorf("second request delayed %v, want %v", delay, tt.interval)
+			}
+			if delay := limiter.reserve(start.Add(tt.interval / 2)); delay != tt.interval/2 {
+				t.Errorf("request halfway to the next token delayed %v, want %v", delay, tt.interval/2)
+			}
+			if delay := limiter.reserve(start.Add(tt.interval)); delay != 0 {
+				t.Errorf("request after the refill interval delayed %v, want none", delay)
+			}
+		})
+	}
+}
+
+func TestWaitWithWindowPacesRequests(t *testing.T) {
+	rl := NewRateLimiter()
+	ctx := context.Background()
+
+	// 10 requests per 100ms is one every 10ms after the first
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := rl.WaitWithWindow(ctx, "RemoteOK", 10, 100*time.Millisecond); err != nil {
+			t.Fatalf("WaitWithWindow: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Errorf("6 requests took %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestWaitWithWindowDefaultsToMinute(t *testing.T) {
+	rl := NewRateLimiter()
+	if err := rl.WaitWithWindow(context.Background(), "RemoteOK", 60, 0); err != nil {
+		t.Fatalf("WaitWithWindow: %v", err)
+	}
+
+	limiter, ok := rl.limiters["remoteok"]
+	if !ok {
+		t.Fatal("no limiter was created for RemoteOK")
+	}
+	if limiter.duration != time.Minute || limiter.rate != 1 {
+		t.Errorf("limiter window %v at %v tokens/s, want a minute at 1", limiter.duration, limiter.rate)
+	}
+}
+
+func TestWaitWithoutLimitReturnsImmediately(t *testing.T) {
+	rl := NewRateLimiter()
+	defer rl.Stop()
+
+	for _, limit := range []int{0, -1} {
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			if err := rl.Wait(context.Background(), "RemoteOK", limit); err != nil {
+				t.Fatalf("Wait with limit %d: %v", limit, err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("100 waits with limit %d took %v, want no delay", limit, elapsed)
+		}
+	}
+	if len(rl.limiters) != 0 {
+		t.Errorf("created %d limiters for unlimited sources, want none", len(rl.limiters))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := rl.Wait(ctx, "RemoteOK", 0); err != context.Canceled {
+		t.Errorf("Wait with a canceled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSourceLimiterCapsBurst(t *testing.T) {
+	// 60 requests per minute is one token a second, with up to 3 at once
+	limiter := NewRateLimiter().getLimiter("source", 60, time.Minute, 3)
+	start := limiter.last
+
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(start); delay != 0 {
+			t.Fatalf("request %d of the burst delayed %v, want none", i+1, delay)
+		}
+	}
+	if delay := limiter.reserve(start); delay != time.Second {
+		t.Errorf("request after the burst delayed %v, want 1s", delay)
+	}
+
+	// Idling refills the bucket only up to the burst
+	idle := start.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(idle); delay != 0 {
+			t.Fatalf("request %d after idling delayed %v, want none", i+1, delay)
+		}
+	}
+	if delay := limiter.reserve(idle); delay != time.Second {
+		t.Errorf("request after the refilled burst delayed %v, want 1s", delay)
+	}
+}
+
+func TestSourceLimiterSteadyRate(t *testing.T) {
+	limiter := NewRateLimiter().getLimiter("source", 60, time.Minute, 5)
+	now := limiter.last
+
+	// Drain the burst, then requests are paced at the rate
+	for i := 0; i < 5; i++ {
+		limiter.reserve(now)
+	}
+	for i := 0; i < 10; i++ {
+		delay := limiter.reserve(now)
+		if delay != time.Second {
+			t.Fatalf("request %d delayed %v, want 1s", i+1, delay)
+		}
+		now = now.Add(delay)
+		if delay := limiter.reserve(now); delay != 0 {
+			t.Fatalf("request %d after waiting delayed %v, want none", i+1, delay)
+		}
+	}
+}
+
+func TestWaitWithBurstDefaultsToOne(t *testing.T) {
+	rl := NewRateLimiter()
+	for _, burst := range []int{0, -2} {
+		if err := rl.WaitWithBurst(context.Background(), "RemoteOK", 60, time.Minute, burst); err != nil {
+			t.Fatalf("WaitWithBurst: %v", err)
+		}
+		if limiter := rl.limiters["remoteok"]; limiter.burst != 1 {
+			t.Errorf("burst %d gave a bucket of %d, want 1", burst, limiter.burst)
+		}
+	}
+}
+
+func TestWaitWithBurstAllowsBurstThenPaces(t *testing.T) {
+	rl := NewRateLimiter()
+	ctx := context.Background()
+
+	// 10 requests per 100ms with a burst of 3: three at once, then one every 10ms
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := rl.WaitWithBurst(ctx, "RemoteOK", 10, 100*time.Millisecond, 3); err != nil {
+			t.Fatalf("WaitWithBurst: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("the burst took %v, want no delay", elapsed)
+	}
+	for i := 0; i < 5; i++ {
+		if err := rl.WaitWithBurst(ctx, "RemoteOK", 10, 100*time.Millisecond, 3); err != nil {
+			t.Fatalf("WaitWithBurst: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Errorf("8 requests took %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestSourceNamesShareOneBucket(t *testing.T) {
+	rl := NewRateLimiter()
+	ctx := context.Background()
+
+	// One request per 200ms: the first spelling takes the only token
+	start := time.Now()
+	for _, name := range []string{"RemoteOK", "remoteok", " REMOTEOK "} {
+		if err := rl.WaitWithWindow(ctx, name, 1, 200*time.Millisecond); err != nil {
+			t.Fatalf("WaitWithWindow(%q): %v", name, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("3 requests took %v, want the spellings paced together over at least 400ms", elapsed)
+	}
+
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	if len(rl.limiters) != 1 {
+		t.Errorf("created %d limiters, want one shared by every spelling", len(rl.limiters))
+	}
+	if _, ok := rl.limiters["remoteok"]; !ok {
+		t.Errorf("limiters = %v, want one keyed remoteok", rl.limiters)
+	}
+}
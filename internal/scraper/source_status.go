@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SourceStatus describes a registered source and, when checked, whether it is reachable
+type SourceStatus struct {
+	Name         string        `json:"name"`
+	Enabled      bool          `json:"enabled"`
+	RateLimit    int           `json:"rate_limit"`
+	RateWindow   time.Duration `json:"rate_window"`
+	BaseURL      string        `json:"base_url"`
+	Checked      bool          `json:"checked"` // whether a health check ran
+	Healthy      bool          `json:"healthy"`
+	Error        string        `json:"error,omitempty"`
+	ResponseTime time.Duration `json:"response_time"` // of the health check if checked, otherwise of the last scrape
+}
+
+// SourceStatuses reports every registered source sorted by name. With check
+// set, the sources are health checked concurrently.
+func (ps *PowerScraper) SourceStatuses(ctx context.Context, check bool) []SourceStatus {
+	registered := ps.sourceManager.GetSources()
+	metrics := ps.GetMetrics()
+
+	statuses := make([]SourceStatus, 0, len(registered))
+	for name, source := range registered {
+		config, _ := ps.sourceManager.GetSourceConfig(name)
+		window := config.RateWindow
+		if window <= 0 {
+			window = time.Minute
+		}
+
+		statuses = append(statuses, SourceStatus{
+			Name:         name,
+			Enabled:      config.Enabled,
+			RateLimit:    config.RateLimit,
+			RateWindow:   window,
+			BaseURL:      source.GetBaseURL(),
+			ResponseTime: metrics.SourcePerformance[name].ResponseTime,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	if !check {
+		return statuses
+	}
+
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func(status *SourceStatus) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := registered[status.Name].HealthCheck(ctx)
+			status.Checked = true
+			status.ResponseTime = time.Since(start)
+			status.Healthy = err == nil
+			if err != nil {
+				status.Error = err.Error()
+			}
+		}(&statuses[i])
+	}
+	wg.Wait()
+
+	return statuses
+}
@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/storage"
+)
+
+func TestSourceStatuses(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(),
+		&fakeSource{name: "Remotive"},
+		&fakeSource{name: "RemoteOK", err: errors.New("connection refused")},
+		&fakeSource{name: "Indeed"},
+	)
+	if err := ps.SetSourceEnabled("Indeed", false); err != nil {
+		t.Fatalf("SetSourceEnabled: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		check bool
+		want  []SourceStatus
+	}{
+		{
+			name: "unchecked",
+			want: []SourceStatus{
+				{Name: "Indeed", Enabled: false, RateWindow: time.Minute, BaseURL: "https://example.com"},
+				{Name: "RemoteOK", Enabled: true, RateWindow: time.Minute, BaseURL: "https://example.com"},
+				{Name: "Remotive", Enabled: true, RateWindow: time.Minute, BaseURL: "https://example.com"},
+			},
+		},
+		{
+			name:  "checked",
+			check: true,
+			want: []SourceStatus{
+				{Name: "Indeed", Enabled: false, RateWindow: time.Minute, BaseURL: "https://example.com", Checked: true, Healthy: true},
+				{Name: "RemoteOK", Enabled: true, RateWindow: time.Minute, BaseURL: "https://example.com", Checked: true, Error: "connection refused"},
+				{Name: "Remotive", Enabled: true, RateWindow: time.Minute, BaseURL: "https://example.com", Checked: true, Healthy: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ps.SourceStatuses(context.Background(), tt.check)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d statuses, want %d", len(got), len(tt.want))
+			}
+			for i, status := range got {
+				status.ResponseTime = 0 // varies with the health check
+				if status != tt.want[i] {
+					t.Errorf("status %d = %+v, want %+v", i, status, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSourceStatusesReportLastResponseTime(t *testing.T) {
+	ps := newTestScraper(t, storage.NewMemoryStore(), &fakeSource{name: "Remotive"})
+	if _, err := ps.ScrapeSource(context.Background(), "Remotive"); err != nil {
+		t.Fatalf("ScrapeSource: %v", err)
+	}
+
+	statuses := ps.SourceStatuses(context.Background(), false)
+	want := ps.GetMetrics().SourcePerformance["Remotive"].ResponseTime
+	if len(statuses) != 1 || statuses[0].ResponseTime != want || statuses[0].Checked {
+		t.Errorf("statuses = %+v, want Remotive unchecked with the scrape's response time %v", statuses, want)
+	}
+}
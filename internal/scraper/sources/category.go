@@ -0,0 +1,125 @@
+package sources
+
+import "strings"
+
+// Canonical job categories shared by all sources
+const (
+	CategoryBackend         = "Backend Development"
+	CategoryFrontend        = "Frontend Development"
+	CategoryFullStack       = "Full Stack Development"
+	CategoryMobile          = "Mobile Development"
+	CategoryDevOps          = "DevOps"
+	CategoryMachineLearning = "Machine Learning"
+	CategoryDataScience     = "Data Science"
+	CategoryQA              = "QA"
+	CategoryDesign          = "Design"
+	CategoryProduct         = "Product"
+	CategoryMarketing       = "Marketing"
+	CategorySales           = "Sales"
+	CategoryCustomerSupport = "Customer Support"
+	CategoryTechnology      = "Technology" // default for jobs without a recognized hint
+)
+
+// categoryKeyword maps words or phrases to a canonical category
+type categoryKeyword struct {
+	category string
+	keywords []string
+}
+
+// roleKeywords name the kind of work directly. They are checked in order so
+// that e.g. "Full Stack Engineer (Backend)" is full stack and "ML Data Engineer"
+// machine learning.
+var roleKeywords = []categoryKeyword{
+	{CategoryFullStack, []string{"fullstack", "full stack"}},
+	{CategoryFrontend, []string{"frontend", "front end"}},
+	{CategoryBackend, []string{"backend", "back end"}},
+	{CategoryMobile, []string{"mobile", "ios", "android"}},
+	{CategoryDevOps, []string{"devops", "sre", "sysadmin", "site reliability", "infrastructure", "platform engineer"}},
+	{CategoryMachineLearning, []string{"machine learning", "ml", "ai", "artificial intelligence"}},
+	{CategoryDataScience, []string{"data", "analyst", "analytics"}},
+	{CategoryQA, []string{"qa", "quality assurance", "test automation"}},
+	{CategoryDesign, []string{"design", "designer", "ux", "ui"}},
+	{CategoryProduct, []string{"product manager", "product owner", "product"}},
+	{CategoryMarketing, []string{"marketing", "seo", "growth"}},
+	{CategorySales, []string{"sales", "account executive", "business development"}},
+	{CategoryCustomerSupport, []string{"customer support", "customer service", "customer success", "support"}},
+}
+
+// techKeywords infer a category from technologies when no role keyword matches
+var techKeywords = []categoryKeyword{
+	{CategoryBackend, []string{"golang", "go", "python", "java", "ruby", "php", "rust", "elixir", "scala"}},
+	{CategoryFrontend, []string{"javascript", "typescript", "react", "vue", "angular", "svelte"}},
+	{CategoryDevOps, []string{"kubernetes", "terraform", "aws"}},
+}
+
+// ClassifyCategory maps a job onto the canonical category taxonomy so that
+// the same posting is categorized alike whatever its source. A source's raw
+// category is most telling, then role keywords in the tags and title, then
+// the technologies they mention.
+func ClassifyCategory(title string, tags []string, rawCategory string) string {
+	if category, ok := matchCategory(roleKeywords, rawCategory); ok {
+		return category
+	}
+
+	texts := append(append([]string{}, tags...), title)
+	for _, keywords := range [][]categoryKeyword{roleKeywords, techKeywords} {
+		for _, text := range texts {
+			if category, ok := matchCategory(keywords, text); ok {
+				return category
+			}
+		}
+	}
+
+	return CategoryTechnology
+}
+
+// ResolveCategory maps a category name such as "Data Science" or a keyword
+// such as "backend" or "devops" to its canonical category
+func ResolveCategory(category string) (string, bool) {
+	for _, keywords := range [][]categoryKeyword{roleKeywords, techKeywords} {
+		for _, keyword := range keywords {
+			if strings.EqualFold(strings.TrimSpace(category), keyword.category) {
+				return keyword.category, true
+			}
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(category), CategoryTechnology) {
+		return CategoryTechnology, true
+	}
+
+	normalized := categoryText(category)
+	for _, keywords := range [][]categoryKeyword{roleKeywords, techKeywords} {
+		for _, keyword := range keywords {
+			for _, word := range keyword.keywords {
+				if normalized == word {
+					return keyword.category, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// matchCategory returns the category of the first keyword found in text
+func matchCategory(keywords []categoryKeyword, text string) (string, bool) {
+	padded := " " + categoryText(text) + " "
+	if padded == "  " {
+		return "", false
+	}
+
+	for _, keyword := range keywords {
+		for _, word := range keyword.keywords {
+			if strings.Contains(padded, " "+word+" ") {
+				return keyword.category, true
+			}
+		}
+	}
+	return "", false
+}
+
+// categoryText lowercases text and splits it into space-separated words,
+// treating hyphens and punctuation as separators
+func categoryText(text string) string {
+	words := searchWords(strings.NewReplacer("-", " ", "/", " ", "_", " ").Replace(text))
+	return strings.Join(words, " ")
+}
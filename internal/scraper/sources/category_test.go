@@ -0,0 +1,80 @@
+package sources
+
+import "testing"
+
+func TestClassifyCategory(t *testing.T) {
+	tests := []struct {
+		title       string
+		tags        []string
+		rawCategory string
+		want        string
+	}{
+		// The raw category of a source wins
+		{"Engineer", nil, "DevOps / Sysadmin", CategoryDevOps},
+		// Role keywords in the tags and title
+		{"Full Stack Engineer (Backend)", nil, "", CategoryFullStack},
+		{"ML Data Engineer", nil, "", CategoryMachineLearning},
+		{"Engineer", []string{"front-end"}, "", CategoryFrontend},
+		{"Site Reliability Engineer", nil, "", CategoryDevOps},
+		{"Customer Success Manager", nil, "", CategoryCustomerSupport},
+		// Technologies when no role is named
+		{"Engineer", []string{"golang"}, "", CategoryBackend},
+		{"Engineer", []string{"react"}, "", CategoryFrontend},
+		{"Engineer", []string{"kubernetes"}, "", CategoryDevOps},
+		{"Go Developer", nil, "Software Development", CategoryBackend},
+		// Keywords must be whole words
+		{"Aide to the CEO", nil, "", CategoryTechnology},
+		{"Engineer", nil, "", CategoryTechnology},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyCategory(tt.title, tt.tags, tt.rawCategory); got != tt.want {
+			t.Errorf("ClassifyCategory(%q, %q, %q) = %q, want %q", tt.title, tt.tags, tt.rawCategory, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyCategoryConsistentAcrossSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		remoteOK string // RemoteOK has tags but no category
+		remotive string // Remotive has a category but no tags
+		want     string
+	}{
+		{"backend", ClassifyCategory("Senior Go Developer", []string{"golang", "backend"}, ""), ClassifyCategory("Senior Go Developer", nil, "Backend"), CategoryBackend},
+		{"devops", ClassifyCategory("SRE", []string{"devops", "aws"}, ""), ClassifyCategory("SRE", nil, "DevOps / Sysadmin"), CategoryDevOps},
+		{"design", ClassifyCategory("Product Designer", []string{"figma"}, ""), ClassifyCategory("Product Designer", nil, "Design"), CategoryDesign},
+		{"data", ClassifyCategory("Data Analyst", []string{"sql"}, ""), ClassifyCategory("Data Analyst", nil, "Data"), CategoryDataScience},
+	}
+
+	for _, tt := range tests {
+		if tt.remoteOK != tt.want || tt.remotive != tt.want {
+			t.Errorf("%s: RemoteOK category %q and Remotive category %q, want both %q", tt.name, tt.remoteOK, tt.remotive, tt.want)
+		}
+	}
+}
+
+func TestResolveCategory(t *testing.T) {
+	tests := []struct {
+		category string
+		want     string
+		ok       bool
+	}{
+		{"Data Science", CategoryDataScience, true},
+		{"data science", CategoryDataScience, true},
+		{"backend", CategoryBackend, true},
+		{"back-end", CategoryBackend, true},
+		{"devops", CategoryDevOps, true},
+		{"golang", CategoryBackend, true},
+		{"Technology", CategoryTechnology, true},
+		{"astronomy", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ResolveCategory(tt.category)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ResolveCategory(%q) = %q, %t; want %q, %t", tt.category, got, ok, tt.want, tt.ok)
+		}
+	}
+}
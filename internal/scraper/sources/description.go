@@ -0,0 +1,71 @@
+package sources
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockElements are tags that should be separated from surrounding text by a line break
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"tr": true, "table": true, "blockquote": true, "pre": true, "hr": true,
+}
+
+// cleanDescription strips HTML tags from a job description, unescapes entities,
+// and collapses whitespace while keeping paragraph and list item breaks
+func cleanDescription(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	var builder strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(raw))
+	skipDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		switch tokenType {
+		case html.TextToken:
+			if skipDepth == 0 {
+				builder.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+
+			// Drop the contents of script and style elements entirely
+			if tag == "script" || tag == "style" {
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				} else if tokenType == html.EndTagToken && skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+
+			if blockElements[tag] {
+				builder.WriteString("\n")
+			}
+			if tag == "li" && tokenType == html.StartTagToken {
+				builder.WriteString("- ")
+			}
+		}
+	}
+
+	// Collapse whitespace within lines and drop empty lines
+	var lines []string
+	for _, line := range strings.Split(builder.String(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" && line != "-" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
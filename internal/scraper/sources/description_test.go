@@ -0,0 +1,70 @@
+package sources
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCleanDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"empty", "", ""},
+		{"plain text", "Build APIs in Go.", "Build APIs in Go."},
+		{
+			"paragraphs",
+			"<p>We are <strong>hiring</strong>.</p><p>Join us!</p>",
+			"We are hiring.\nJoin us!",
+		},
+		{
+			"list",
+			"<p>You have:</p><ul><li>Go</li><li>  Postgres\n</li></ul>",
+			"You have:\n- Go\n- Postgres",
+		},
+		{"entities", "<p>R&amp;D &lt;team&gt; &quot;remote&quot; &#8211; caf&eacute;</p>", `R&D <team> "remote" – café`},
+		{"line breaks", "First line<br>Second line<br/>Third", "First line\nSecond line\nThird"},
+		{"whitespace", "<div>\n\n   Lots   of\t space  \n\n</div>", "Lots of space"},
+		{"script and style", "<style>p { color: red }</style><p>Text</p><script>alert(1)</script>", "Text"},
+		{"empty list items", "<ul><li></li><li>Only item</li></ul>", "- Only item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanDescription(tt.html); got != tt.want {
+				t.Errorf("cleanDescription(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemotivePreservesHTMLWhenConfigured(t *testing.T) {
+	body := `{"jobs": [{"url": "https://remotive.com/1", "title": "Go Developer", "company_name": "Acme", "description": "<p>Write <b>Go</b></p>"}]}`
+
+	for _, preserve := range []bool{false, true} {
+		source := newTestRemotive(t, body)
+		source.SetPreserveHTML(preserve)
+
+		jobs, err := source.FetchJobs(context.Background())
+		if err != nil {
+			t.Fatalf("FetchJobs: %v", err)
+		}
+
+		want := "Write Go"
+		if preserve {
+			want = "<p>Write <b>Go</b></p>"
+		}
+		if len(jobs) != 1 || jobs[0].Description != want {
+			t.Errorf("preserveHTML %t: description %q, want %q", preserve, jobs[0].Description, want)
+		}
+
+		jobs, err = source.FetchJobsByCategory(context.Background(), "software-dev")
+		if err != nil {
+			t.Fatalf("FetchJobsByCategory: %v", err)
+		}
+		if len(jobs) != 1 || jobs[0].Description != want {
+			t.Errorf("preserveHTML %t: category description %q, want %q", preserve, jobs[0].Description, want)
+		}
+	}
+}
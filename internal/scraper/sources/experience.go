@@ -0,0 +1,33 @@
+package sources
+
+import "job-scraper-go/internal/models"
+
+// experienceKeywords maps title words to experience levels, checked in order so
+// that e.g. "Senior Tech Lead" is a lead and "Junior Intern" an intern
+var experienceKeywords = []struct {
+	level string
+	words []string
+}{
+	{models.ExperienceIntern, []string{"intern", "internship", "trainee", "apprentice"}},
+	{models.ExperienceLead, []string{"lead", "principal", "staff", "head", "architect"}},
+	{models.ExperienceSenior, []string{"senior", "sr", "snr"}},
+	{models.ExperienceJunior, []string{"junior", "jr", "entry", "graduate", "associate"}},
+}
+
+// inferExperienceLevel infers the experience level from keywords in a job
+// title, defaulting to mid-level when the title has no seniority hint
+func inferExperienceLevel(title string) string {
+	words := make(map[string]bool)
+	for _, word := range searchWords(title) {
+		words[word] = true
+	}
+
+	for _, keyword := range experienceKeywords {
+		for _, word := range keyword.words {
+			if words[word] {
+				return keyword.level
+			}
+		}
+	}
+	return models.ExperienceMid
+}
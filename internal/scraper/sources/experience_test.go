@@ -0,0 +1,66 @@
+package sources
+
+import (
+	"context"
+	"testing"
+
+	"job-scraper-go/internal/models"
+)
+
+func TestInferExperienceLevel(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Senior Go Developer", models.ExperienceSenior},
+		{"Sr. Backend Engineer", models.ExperienceSenior},
+		{"Junior Frontend Developer", models.ExperienceJunior},
+		{"Entry Level Data Analyst", models.ExperienceJunior},
+		{"Software Engineering Intern", models.ExperienceIntern},
+		{"Principal Engineer", models.ExperienceLead},
+		{"Lead Designer", models.ExperienceLead},
+		// The highest-ranked keyword wins
+		{"Senior Tech Lead", models.ExperienceLead},
+		{"Junior Intern", models.ExperienceIntern},
+		// Keywords must be whole words
+		{"Leadership Coach", models.ExperienceMid},
+		{"Seniority Analyst", models.ExperienceMid},
+		// Titles without a hint default to mid-level
+		{"Go Developer", models.ExperienceMid},
+		{"", models.ExperienceMid},
+	}
+
+	for _, tt := range tests {
+		if got := inferExperienceLevel(tt.title); got != tt.want {
+			t.Errorf("inferExperienceLevel(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestMappersSetExperienceLevel(t *testing.T) {
+	remoteOKJobs, err := newTestRemoteOK(t, remoteOKFeed).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("RemoteOK FetchJobs: %v", err)
+	}
+	remotiveJobs, err := newTestRemotive(t, `{"jobs": [
+		{"url": "https://remotive.com/1", "title": "Junior Go Developer", "company_name": "Acme"}
+	]}`).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("Remotive FetchJobs: %v", err)
+	}
+
+	tests := []struct {
+		job  models.Job
+		want string
+	}{
+		{remoteOKJobs[0], models.ExperienceSenior},
+		{remoteOKJobs[1], models.ExperienceMid},
+		{remoteOKJobs[2], models.ExperienceIntern},
+		{remotiveJobs[0], models.ExperienceJunior},
+	}
+	for _, tt := range tests {
+		if tt.job.ExperienceLevel != tt.want {
+			t.Errorf("%s from %s experience level = %q, want %q", tt.job.Title, tt.job.Source, tt.job.ExperienceLevel, tt.want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package sources
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FeedConfig describes a generic RSS/Atom job feed
+type FeedConfig struct {
+	Name      string
+	URL       string
+	RateLimit int // requests per minute, defaults to 30
+	Fields    FeedFieldMapping
+}
+
+// FeedFieldMapping names the feed item elements mapped onto job fields.
+// Empty fields fall back to the standard RSS/Atom element names.
+type FeedFieldMapping struct {
+	Title       string
+	URL         string
+	PostedDate  string
+	Description string
+	Company     string
+	Location    string
+	Category    string
+	Salary      string
+}
+
+// defaultFeedFields lists the standard RSS and Atom elements for each job field
+var defaultFeedFields = struct {
+	title, url, postedDate, description, category []string
+}{
+	title:       []string{"title"},
+	url:         []string{"link", "guid"},
+	postedDate:  []string{"pubDate", "published", "updated", "date"},
+	description: []string{"description", "summary", "content", "encoded"},
+	category:    []string{"category"},
+}
+
+// feedDateFormats lists the date formats used by RSS and Atom feeds
+var feedDateFormats = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// FeedSource implements JobSource for any RSS 2.0 or Atom job feed
+type FeedSource struct {
+	client *httpclient.HttpClient
+	config FeedConfig
+}
+
+// NewFeedSource creates a new feed source
+func NewFeedSource(client *httpclient.HttpClient, config FeedConfig) *FeedSource {
+	return &FeedSource{
+		client: client,
+		config: config,
+	}
+}
+
+func (f *FeedSource) GetName() string {
+	return f.config.Name
+}
+
+func (f *FeedSource) GetRateLimit() int {
+	if f.config.RateLimit > 0 {
+		return f.config.RateLimit
+	}
+	return 30 // 30 requests per minute
+}
+
+func (f *FeedSource) SupportsSearch() bool {
+	return false
+}
+
+func (f *FeedSource) GetBaseURL() string {
+	return f.config.URL
+}
+
+// HealthCheck verifies that the feed base URL is reachable
+func (f *FeedSource) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, f.client, f.config.URL, nil)
+}
+
+func (f *FeedSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	resp, err := f.client.GetWithContext(ctx, f.config.URL)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil // feed unchanged since the last fetch
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed %s: %w", f.config.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Source: "feed " + f.config.Name, StatusCode: resp.StatusCode}
+	}
+
+	items, err := parseFeed(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed %s: %w", f.config.Name, err)
+	}
+
+	var jobs []models.Job
+	for _, item := range items {
+		job := f.toJob(item)
+		if job.Title == "" || job.URL == "" {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// toJob maps a feed item onto a job using the configured field mapping
+func (f *FeedSource) toJob(item feedItem) models.Job {
+	fields := f.config.Fields
+
+	location := item.first(fieldNames(fields.Location, nil))
+	if location == "" {
+		location = "Remote"
+	}
+
+	job := models.Job{
+		Title:       item.first(fieldNames(fields.Title, defaultFeedFields.title)),
+		Company:     item.first(fieldNames(fields.Company, nil)),
+		Location:    location,
+		URL:         item.first(fieldNames(fields.URL, defaultFeedFields.url)),
+		Description: cleanDescription(item.first(fieldNames(fields.Description, defaultFeedFields.description))),
+		Salary:      item.first(fieldNames(fields.Salary, nil)),
+		PostedDate:  parseFeedDate(item.first(fieldNames(fields.PostedDate, defaultFeedFields.postedDate))),
+		Source:      f.GetName(),
+		JobCategory: item.first(fieldNames(fields.Category, defaultFeedFields.category)),
+		Tags:        normalizeTags(item.all(fieldNames(fields.Category, defaultFeedFields.category))),
+	}
+	job.WorkMode = classifyWorkMode(job.Location, job.Tags)
+	job.ExperienceLevel = inferExperienceLevel(job.Title)
+	job.Hash = models.JobHash(job)
+	applySalary(&job, job.Salary)
+
+	return job
+}
+
+// fieldNames returns the configured element name, or the defaults when unset
+func fieldNames(configured string, defaults []string) []string {
+	if configured != "" {
+		return []string{configured}
+	}
+	return defaults
+}
+
+// parseFeedDate parses an RSS or Atom date, returning nil when it is missing or unknown
+func parseFeedDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	for _, format := range feedDateFormats {
+		if parsed, err := time.Parse(format, value); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
+
+// feedItem holds the text values of an RSS item or Atom entry keyed by element name
+type feedItem map[string][]string
+
+// first returns the first non-empty value among the given element names
+func (item feedItem) first(names []string) string {
+	for _, name := range names {
+		for _, value := range item[name] {
+			if value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// all returns every non-empty value for the given element names
+func (item feedItem) all(names []string) []string {
+	var values []string
+	for _, name := range names {
+		for _, value := range item[name] {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// feedElement is a generic XML element with its attributes and children
+type feedElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Text     string        `xml:",chardata"`
+	Children []feedElement `xml:",any"`
+}
+
+// text returns the element text, falling back to the first child with text
+// (e.g. Atom <author><name>) and to the href attribute (e.g. Atom <link>)
+func (e feedElement) text() string {
+	if text := strings.TrimSpace(e.Text); text != "" {
+		return text
+	}
+	for _, child := range e.Children {
+		if text := child.text(); text != "" {
+			return text
+		}
+	}
+	return e.attr("href")
+}
+
+// attr returns the value of an attribute by local name
+func (e feedElement) attr(name string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return strings.TrimSpace(attr.Value)
+		}
+	}
+	return ""
+}
+
+// parseFeed extracts the items of an RSS 2.0 feed or the entries of an Atom feed
+func parseFeed(r io.Reader) ([]feedItem, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var items []feedItem
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || (start.Name.Local != "item" && start.Name.Local != "entry") {
+			continue
+		}
+
+		var element feedElement
+		if err := decoder.DecodeElement(&element, &start); err != nil {
+			return nil, err
+		}
+
+		item := make(feedItem)
+		for _, child := range element.Children {
+			// Atom entries may link to several related resources; keep the main one
+			if child.XMLName.Local == "link" {
+				if rel := child.attr("rel"); rel != "" && rel != "alternate" {
+					continue
+				}
+			}
+			item[child.XMLName.Local] = append(item[child.XMLName.Local], child.text())
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,107 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"job-scraper-go/pkg/httpclient"
+)
+
+// newTestFeed returns a feed source reading the named fixture from a test server
+func newTestFeed(t *testing.T, fixture string, fields FeedFieldMapping) *FeedSource {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := serveBody(t, "application/xml", string(body))
+	return NewFeedSource(httpclient.NewHttpClient(5*time.Second), FeedConfig{Name: "Example", URL: server.URL, Fields: fields})
+}
+
+func TestFeedSourceParsesRSS(t *testing.T) {
+	source := newTestFeed(t, "feed.rss", FeedFieldMapping{Company: "creator"})
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	// The item without a link or guid is skipped
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.Title != "Senior Go Developer" || first.URL != "https://jobs.example.com/1" || first.Company != "Acme" {
+		t.Errorf("first job = %q at %q by %q, want Senior Go Developer at https://jobs.example.com/1 by Acme", first.Title, first.URL, first.Company)
+	}
+	if first.Source != "Example" || first.Location != "Remote" {
+		t.Errorf("first job source %q and location %q, want Example and Remote", first.Source, first.Location)
+	}
+	if want := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC); first.PostedDate == nil || !first.PostedDate.Equal(want) {
+		t.Errorf("first job posted %v, want %v", first.PostedDate, want)
+	}
+	if first.Description != "Build APIs in Go." {
+		t.Errorf("first job description = %q, want the HTML stripped", first.Description)
+	}
+	if first.JobCategory != "Backend" || len(first.Tags) != 2 {
+		t.Errorf("first job category %q and tags %q, want Backend and both categories", first.JobCategory, first.Tags)
+	}
+	if first.Hash == "" {
+		t.Error("first job has no hash")
+	}
+
+	second := jobs[1]
+	if second.URL != "https://jobs.example.com/guid/2" {
+		t.Errorf("second job URL = %q, want the guid when there is no link", second.URL)
+	}
+	if second.PostedDate != nil {
+		t.Errorf("second job posted %v, want nil for an unparseable date", second.PostedDate)
+	}
+}
+
+func TestFeedSourceParsesAtom(t *testing.T) {
+	source := newTestFeed(t, "feed.atom", FeedFieldMapping{Company: "author"})
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.Title != "Platform Engineer" || first.URL != "https://careers.example.com/jobs/10" || first.Company != "Globex" {
+		t.Errorf("first job = %q at %q by %q, want Platform Engineer at the alternate link by Globex", first.Title, first.URL, first.Company)
+	}
+	if want := time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC); first.PostedDate == nil || !first.PostedDate.Equal(want) {
+		t.Errorf("first job posted %v, want the published date %v", first.PostedDate, want)
+	}
+	if first.Description != "Run Kubernetes clusters" {
+		t.Errorf("first job description = %q, want the summary", first.Description)
+	}
+
+	second := jobs[1]
+	if want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC); second.PostedDate == nil || !second.PostedDate.Equal(want) {
+		t.Errorf("second job posted %v, want the updated date %v", second.PostedDate, want)
+	}
+	if second.Description != "SQL and dashboards" {
+		t.Errorf("second job description = %q, want the content without HTML", second.Description)
+	}
+}
+
+func TestFeedSourceReportsBadStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	source := NewFeedSource(httpclient.NewHttpClient(5*time.Second), FeedConfig{Name: "Example", URL: server.URL})
+
+	if _, err := source.FetchJobs(context.Background()); err == nil {
+		t.Error("FetchJobs succeeded on a 404, want an error")
+	}
+}
@@ -0,0 +1,32 @@
+package sources
+
+import (
+	"context"
+	"testing"
+
+	"job-scraper-go/internal/models"
+)
+
+func TestMappersSetJobHash(t *testing.T) {
+	remoteOKJobs, err := newTestRemoteOK(t, remoteOKFeed).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("RemoteOK FetchJobs: %v", err)
+	}
+	remotiveJobs, err := newTestRemotive(t, `{"jobs": [
+		{"url": "https://remotive.com/1", "title": "Senior Go Developer", "company_name": "Acme", "candidate_required_location": "Worldwide"}
+	]}`).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("Remotive FetchJobs: %v", err)
+	}
+
+	for _, job := range append(remoteOKJobs, remotiveJobs...) {
+		if want := models.JobHash(job); job.Hash != want {
+			t.Errorf("%s from %s hash = %q, want %q", job.Title, job.Source, job.Hash, want)
+		}
+	}
+
+	// The same posting hashes equally on both boards
+	if remoteOKJobs[0].Hash != remotiveJobs[0].Hash {
+		t.Errorf("RemoteOK hash %s differs from Remotive hash %s for the same posting", remoteOKJobs[0].Hash, remotiveJobs[0].Hash)
+	}
+}
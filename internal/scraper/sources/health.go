@@ -0,0 +1,54 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"job-scraper-go/pkg/httpclient"
+	"net/http"
+	"time"
+)
+
+// healthCheckTimeout bounds a single source health check
+const healthCheckTimeout = 10 * time.Second
+
+// checkHealth verifies that a source's base URL is reachable with a HEAD request,
+// falling back to a GET for servers that do not support HEAD. The header, if
+// any, carries the source's credentials.
+func checkHealth(ctx context.Context, client *httpclient.HttpClient, url string, header http.Header) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	status, err := requestStatus(ctx, client, http.MethodHead, url, header)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestStatus(ctx, client, http.MethodGet, url, header)
+	}
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+
+	if status >= http.StatusBadRequest {
+		return fmt.Errorf("%s returned status %d", url, status)
+	}
+	return nil
+}
+
+// requestStatus performs a request and returns its status code, discarding the body
+func requestStatus(ctx context.Context, client *httpclient.HttpClient, method, url string, header http.Header) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
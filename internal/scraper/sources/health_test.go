@@ -0,0 +1,95 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper-go/pkg/httpclient"
+)
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		wantErr     bool
+		wantMethods string
+	}{
+		{
+			name:        "healthy",
+			handler:     func(w http.ResponseWriter, r *http.Request) {},
+			wantMethods: "HEAD",
+		},
+		{
+			name: "HEAD not allowed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+				}
+			},
+			wantMethods: "HEAD GET",
+		},
+		{
+			name:        "server error",
+			handler:     func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+			wantErr:     true,
+			wantMethods: "HEAD",
+		},
+		{
+			name:        "forbidden",
+			handler:     func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusForbidden) },
+			wantErr:     true,
+			wantMethods: "HEAD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var methods []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			source := NewFeedSource(httpclient.NewHttpClient(5*time.Second), FeedConfig{Name: "Example", URL: server.URL})
+			err := source.HealthCheck(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HealthCheck error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := strings.Join(methods, " "); got != tt.wantMethods {
+				t.Errorf("requests %q, want %q", got, tt.wantMethods)
+			}
+		})
+	}
+}
+
+func TestHealthCheckUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	source := NewFeedSource(httpclient.NewHttpClient(5*time.Second), FeedConfig{Name: "Example", URL: url})
+	if err := source.HealthCheck(context.Background()); err == nil {
+		t.Error("HealthCheck of a closed server succeeded, want an error")
+	}
+}
+
+func TestHealthCheckSendsCredentials(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Api-Key")
+	}))
+	defer server.Close()
+
+	err := checkHealth(context.Background(), httpclient.NewHttpClient(5*time.Second), server.URL, authHeader("X-Api-Key", "secret"))
+	if err != nil {
+		t.Fatalf("checkHealth: %v", err)
+	}
+	if got != "secret" {
+		t.Errorf("X-Api-Key = %q, want the configured credentials", got)
+	}
+}
@@ -0,0 +1,229 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// IndeedSource implements JobSource for Indeed's per-query RSS feeds
+type IndeedSource struct {
+	client      *httpclient.HttpClient
+	baseURL     string
+	searchTerms []string
+	searchMode  string
+	locations   []string
+	delay       time.Duration // pause between the feeds of different locations
+	auth        http.Header   // credentials sent with every request
+}
+
+func init() {
+	RegisterFactory("indeed", func(client *httpclient.HttpClient) JobSource {
+		return NewIndeedSource(client)
+	})
+}
+
+// NewIndeedSource creates a new Indeed source
+func NewIndeedSource(client *httpclient.HttpClient) *IndeedSource {
+	return &IndeedSource{
+		client:  client,
+		baseURL: "https://rss.indeed.com/rss",
+	}
+}
+
+// SetSearchTerms sets the query of the Indeed feeds. With mode "all" every
+// term must match, otherwise any term may match.
+func (i *IndeedSource) SetSearchTerms(terms []string, mode string) {
+	i.searchTerms = terms
+	i.searchMode = mode
+}
+
+// SetLocations sets the locations searched, fetching one feed per location
+func (i *IndeedSource) SetLocations(locations []string) {
+	i.locations = locations
+}
+
+// SetRequestDelay sets the pause between the feeds of successive locations
+func (i *IndeedSource) SetRequestDelay(delay time.Duration) {
+	i.delay = delay
+}
+
+// SetAuth sets a header, such as "Authorization" or "X-Api-Key", sent with
+// every request to the source
+func (i *IndeedSource) SetAuth(name, value string) {
+	i.auth = authHeader(name, value)
+}
+
+func (i *IndeedSource) GetName() string {
+	return "Indeed"
+}
+
+func (i *IndeedSource) GetRateLimit() int {
+	return 10 // 10 requests per minute
+}
+
+func (i *IndeedSource) SupportsSearch() bool {
+	return true
+}
+
+func (i *IndeedSource) GetBaseURL() string {
+	return i.baseURL
+}
+
+// HealthCheck verifies that the Indeed RSS endpoint is reachable
+func (i *IndeedSource) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, i.client, i.baseURL, i.auth)
+}
+
+// FetchJobs fetches the feed for each configured location, dropping jobs
+// listed under more than one location
+func (i *IndeedSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	locations := i.locations
+	if len(locations) == 0 {
+		locations = []string{""}
+	}
+
+	seen := make(map[string]bool)
+	var jobs []models.Job
+	for index, location := range locations {
+		if index > 0 {
+			if err := SleepContext(ctx, i.delay); err != nil {
+				return nil, err
+			}
+		}
+
+		feedJobs, err := i.fetchFeed(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, job := range feedJobs {
+			if seen[job.URL] {
+				continue
+			}
+			seen[job.URL] = true
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
+
+// feedURL builds the RSS URL for the search terms and a location
+func (i *IndeedSource) feedURL(location string) string {
+	separator := " OR "
+	if i.searchMode == SearchModeAll {
+		separator = " "
+	}
+
+	query := url.Values{}
+	query.Set("q", strings.Join(i.searchTerms, separator))
+	query.Set("l", location)
+	query.Set("sort", "date")
+	return i.baseURL + "?" + query.Encode()
+}
+
+// fetchFeed fetches and maps the feed for a single location
+func (i *IndeedSource) fetchFeed(ctx context.Context, location string) ([]models.Job, error) {
+	resp, err := i.client.GetWithHeaders(ctx, i.feedURL(location), i.auth)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil // feed unchanged since the last fetch
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from Indeed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Source: "Indeed", StatusCode: resp.StatusCode}
+	}
+
+	items, err := parseFeed(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Indeed feed: %w", err)
+	}
+
+	var jobs []models.Job
+	for _, item := range items {
+		job := i.toJob(item, location)
+		if job.Title == "" || job.URL == "" {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// toJob maps an Indeed feed item onto a job. Item titles have the form
+// "Title - Company - Location", and the company is also in <source>.
+func (i *IndeedSource) toJob(item feedItem, searchLocation string) models.Job {
+	title, company, location := splitIndeedTitle(item.first([]string{"title"}))
+	if source := item.first([]string{"source"}); source != "" {
+		company = source
+	}
+	if location == "" {
+		location = searchLocation
+	}
+
+	job := models.Job{
+		Title:       title,
+		Company:     company,
+		Location:    location,
+		URL:         item.first([]string{"link", "guid"}),
+		Description: indeedDescription(item.first([]string{"description"})),
+		PostedDate:  parseFeedDate(item.first([]string{"pubDate"})),
+		Source:      i.GetName(),
+		JobCategory: ClassifyCategory(title, nil, ""),
+		WorkMode:    indeedWorkMode(location),
+	}
+	job.ExperienceLevel = inferExperienceLevel(job.Title)
+	job.Hash = models.JobHash(job)
+
+	return job
+}
+
+// splitIndeedTitle splits "Title - Company - Location" from the right, since
+// job titles may themselves contain " - "
+func splitIndeedTitle(raw string) (title, company, location string) {
+	parts := strings.Split(raw, " - ")
+	if len(parts) < 3 {
+		return strings.TrimSpace(raw), "", ""
+	}
+
+	n := len(parts)
+	title = strings.TrimSpace(strings.Join(parts[:n-2], " - "))
+	return title, strings.TrimSpace(parts[n-2]), strings.TrimSpace(parts[n-1])
+}
+
+// indeedDescription cleans the description snippet. Indeed only publishes the
+// first few lines of a posting in RSS, followed by a "From Indeed" link, so the
+// snippet is marked as truncated.
+func indeedDescription(raw string) string {
+	description := cleanDescription(raw)
+	if index := strings.LastIndex(description, "From Indeed"); index >= 0 {
+		description = strings.TrimSpace(description[:index])
+	}
+	if description == "" {
+		return ""
+	}
+
+	description = strings.TrimRight(description, ". …")
+	return description + "…"
+}
+
+// indeedWorkMode classifies a location on a board that is not remote-only,
+// where jobs are onsite unless the location says otherwise
+func indeedWorkMode(location string) string {
+	mode := classifyWorkMode(location, nil)
+	if mode == models.WorkModeRemote && !strings.Contains(strings.ToLower(location), "remote") {
+		return models.WorkModeOnsite
+	}
+	return mode
+}
@@ -0,0 +1,208 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// indeedServer serves the Indeed RSS fixture and records the location and
+// time of each request
+type indeedServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	locations []string
+	times     []time.Time
+}
+
+// newIndeedServer starts a server answering every request with the fixture
+func newIndeedServer(t *testing.T) *indeedServer {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "indeed.rss"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &indeedServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.locations = append(server.locations, r.URL.Query().Get("l"))
+		server.times = append(server.times, time.Now())
+		server.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestIndeed returns an Indeed source reading from server
+func newTestIndeed(server *indeedServer) *IndeedSource {
+	source := NewIndeedSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+	return source
+}
+
+func TestIndeedParsesFeed(t *testing.T) {
+	jobs, err := newTestIndeed(newIndeedServer(t)).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("got %d jobs, want 3", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.Title != "Senior Go Developer" || first.Company != "Acme Corp" || first.Location != "Remote" {
+		t.Errorf("first job = %q at %q in %q, want Senior Go Developer at Acme Corp in Remote", first.Title, first.Company, first.Location)
+	}
+	if first.URL != "https://www.indeed.com/viewjob?jk=3f1c2a9b8d7e6f50" || first.Source != "Indeed" {
+		t.Errorf("first job URL %q and source %q, want the view link and Indeed", first.URL, first.Source)
+	}
+	if want := time.Date(2024, 5, 6, 14, 22, 31, 0, time.UTC); first.PostedDate == nil || !first.PostedDate.Equal(want) {
+		t.Errorf("first job posted %v, want %v", first.PostedDate, want)
+	}
+	// RSS only has the start of the description, so it is marked as truncated
+	if want := "Build and operate Go services for our payments platform. You will own APIs end to end…"; first.Description != want {
+		t.Errorf("first job description = %q, want %q", first.Description, want)
+	}
+	if first.WorkMode != models.WorkModeRemote || first.Hash != models.JobHash(first) {
+		t.Errorf("first job work mode %q and hash %q, want remote and its job hash", first.WorkMode, first.Hash)
+	}
+
+	// Titles may contain " - " themselves
+	second := jobs[1]
+	if second.Title != "Backend Engineer - Payments" || second.Company != "Globex" || second.WorkMode != models.WorkModeHybrid {
+		t.Errorf("second job = %q at %q (%s), want Backend Engineer - Payments at Globex (hybrid)", second.Title, second.Company, second.WorkMode)
+	}
+
+	third := jobs[2]
+	if third.Location != "Austin, TX" || third.WorkMode != models.WorkModeOnsite || third.Description != "" {
+		t.Errorf("third job in %q (%s) with description %q, want Austin, TX (onsite) without one", third.Location, third.WorkMode, third.Description)
+	}
+}
+
+func TestIndeedFetchesEachLocationOnce(t *testing.T) {
+	server := newIndeedServer(t)
+	source := newTestIndeed(server)
+	source.SetLocations([]string{"remote", "Berlin"})
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	// Both feeds list the same jobs, which are kept once
+	if len(jobs) != 3 {
+		t.Errorf("got %d jobs, want 3 without duplicates", len(jobs))
+	}
+	if want := []string{"remote", "Berlin"}; !reflect.DeepEqual(server.locations, want) {
+		t.Errorf("fetched locations %q, want %q", server.locations, want)
+	}
+}
+
+func TestIndeedFeedURL(t *testing.T) {
+	tests := []struct {
+		mode     string
+		location string
+		wantQ    string
+	}{
+		{SearchModeAny, "remote", "golang OR go developer"},
+		{SearchModeAll, "Berlin", "golang go developer"},
+		{SearchModeAny, "", "golang OR go developer"},
+	}
+
+	for _, tt := range tests {
+		source := NewIndeedSource(nil)
+		source.SetSearchTerms([]string{"golang", "go developer"}, tt.mode)
+
+		parsed, err := url.Parse(source.feedURL(tt.location))
+		if err != nil {
+			t.Fatal(err)
+		}
+		query := parsed.Query()
+		if query.Get("q") != tt.wantQ || query.Get("l") != tt.location || query.Get("sort") != "date" {
+			t.Errorf("feedURL(%q) in mode %s has query %v, want q=%q l=%q sort=date", tt.location, tt.mode, query, tt.wantQ, tt.location)
+		}
+	}
+}
+
+func TestSplitIndeedTitle(t *testing.T) {
+	tests := []struct {
+		raw                      string
+		title, company, location string
+	}{
+		{"Go Developer - Acme - Remote", "Go Developer", "Acme", "Remote"},
+		{"Engineer - Platform - Acme - Berlin", "Engineer - Platform", "Acme", "Berlin"},
+		{"Go Developer", "Go Developer", "", ""},
+		{"Go Developer - Acme", "Go Developer - Acme", "", ""},
+	}
+
+	for _, tt := range tests {
+		title, company, location := splitIndeedTitle(tt.raw)
+		if title != tt.title || company != tt.company || location != tt.location {
+			t.Errorf("splitIndeedTitle(%q) = %q, %q, %q; want %q, %q, %q", tt.raw, title, company, location, tt.title, tt.company, tt.location)
+		}
+	}
+}
+
+func TestIndeedWaitsBetweenLocations(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+	}{
+		{"no delay", 0},
+		{"delay", 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newIndeedServer(t)
+			source := newTestIndeed(server)
+			source.SetLocations([]string{"Berlin", "Remote"})
+			source.SetRequestDelay(tt.delay)
+
+			if _, err := source.FetchJobs(context.Background()); err != nil {
+				t.Fatalf("FetchJobs: %v", err)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.times) != 2 {
+				t.Fatalf("got %d requests, want 2", len(server.times))
+			}
+			if gap := server.times[1].Sub(server.times[0]); gap < tt.delay {
+				t.Errorf("second request %v after the first, want at least %v", gap, tt.delay)
+			}
+		})
+	}
+}
+
+func TestIndeedDelayStopsWhenCancelled(t *testing.T) {
+	server := newIndeedServer(t)
+	source := newTestIndeed(server)
+	source.SetLocations([]string{"Berlin", "Remote"})
+	source.SetRequestDelay(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := source.FetchJobs(ctx); err == nil {
+		t.Error("FetchJobs succeeded, want the cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("FetchJobs returned after %v, want it to stop waiting when cancelled", elapsed)
+	}
+}
@@ -0,0 +1,66 @@
+package sources
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrNonJSONResponse is returned when a JSON API answers with something else,
+// such as an HTML error page or a bot challenge served with status 200
+var ErrNonJSONResponse = errors.New("source returned non-JSON response (possibly blocked)")
+
+// ErrMalformedJSON is returned when a JSON response cannot be parsed, such as
+// a body truncated by a dropped connection. Fetching it again often succeeds.
+var ErrMalformedJSON = errors.New("malformed JSON response")
+
+// bodySnippetLength is how much of an unexpected response body errors quote
+const bodySnippetLength = 200
+
+// decodeJSON unmarshals the body of a JSON API response into v, reporting
+// ErrNonJSONResponse with the start of the body for HTML or XML pages and
+// ErrMalformedJSON for bodies that are not valid JSON
+func decodeJSON(resp *http.Response, body []byte, v interface{}) error {
+	contentType := resp.Header.Get("Content-Type")
+	if !isJSONBody(contentType, body) {
+		return fmt.Errorf("%w: Content-Type %q, body starts with %q",
+			ErrNonJSONResponse, contentType, bodySnippet(body))
+	}
+
+	err := json.Unmarshal(body, v)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %v", ErrMalformedJSON, err)
+	}
+	return err
+}
+
+// isJSONBody reports whether a response body may be JSON. APIs label JSON
+// inconsistently, so only markup content types and bodies are ruled out.
+func isJSONBody(contentType string, body []byte) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "text/html", "application/xhtml+xml", "text/xml", "application/xml":
+			return false
+		}
+	}
+
+	trimmed := bytes.TrimLeft(body, " \t\r\n\ufeff")
+	return !bytes.HasPrefix(trimmed, []byte("<"))
+}
+
+// bodySnippet returns the start of a body for error messages, with runs of
+// whitespace collapsed
+func bodySnippet(body []byte) string {
+	if len(body) > bodySnippetLength {
+		body = body[:bodySnippetLength]
+	}
+	// Drop a character cut in half by the truncation
+	snippet := strings.ToValidUTF8(string(body), "")
+	return strings.Join(strings.Fields(snippet), " ")
+}
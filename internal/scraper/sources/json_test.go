@@ -0,0 +1,107 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"job-scraper-go/pkg/httpclient"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	challenge := "<!DOCTYPE html>\n<html><head><title>Just a moment...</title></head>\n<body>Checking your browser</body></html>"
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantErr     error
+		wantQuoted  string
+	}{
+		{name: "json", contentType: "application/json", body: `{"jobs": []}`},
+		{name: "html fragment", contentType: "text/html", body: `<p>`, wantErr: ErrNonJSONResponse, wantQuoted: "<p>"},
+		{name: "html page", contentType: "text/html; charset=utf-8", body: challenge, wantErr: ErrNonJSONResponse, wantQuoted: "<!DOCTYPE html> <html><head><title>Just a moment...</title>"},
+		{name: "html labeled as json", contentType: "application/json", body: "\n  " + challenge, wantErr: ErrNonJSONResponse, wantQuoted: "<!DOCTYPE html>"},
+		{name: "xml", contentType: "application/xml", body: `<?xml version="1.0"?><error/>`, wantErr: ErrNonJSONResponse},
+		{name: "truncated json", contentType: "application/json", body: `{"jobs": [{"title": "Go`, wantErr: ErrMalformedJSON},
+		{name: "unlabeled json", contentType: "", body: `{"jobs": []}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{"Content-Type": {tt.contentType}}}
+			var v struct {
+				Jobs []interface{} `json:"jobs"`
+			}
+
+			err := decodeJSON(resp, []byte(tt.body), &v)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("decodeJSON: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantQuoted) {
+				t.Errorf("error = %q, want it to quote %q", err, tt.wantQuoted)
+			}
+		})
+	}
+}
+
+func TestBodySnippetIsTruncated(t *testing.T) {
+	body := []byte(strings.Repeat("é", bodySnippetLength))
+
+	snippet := bodySnippet(body)
+	if len(snippet) > bodySnippetLength {
+		t.Errorf("snippet is %d bytes, want at most %d", len(snippet), bodySnippetLength)
+	}
+	if !strings.HasPrefix(strings.Repeat("é", bodySnippetLength), snippet) || strings.ContainsRune(snippet, '�') {
+		t.Errorf("snippet = %q, want whole characters only", snippet)
+	}
+}
+
+func TestRemoteOKReportsBlockedResponse(t *testing.T) {
+	server := serveBody(t, "text/html", "<html><body>Attention Required! | Cloudflare</body></html>")
+	source := NewRemoteOKSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+
+	_, err := source.FetchJobs(context.Background())
+	if !errors.Is(err, ErrNonJSONResponse) {
+		t.Fatalf("FetchJobs error = %v, want ErrNonJSONResponse", err)
+	}
+	if !strings.Contains(err.Error(), "Cloudflare") {
+		t.Errorf("error = %q, want it to quote the page", err)
+	}
+}
+
+func TestRemoteOKTruncatedThenValidJSON(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if requests.Add(1) == 1 {
+			w.Write([]byte(remoteOKFeed[:len(remoteOKFeed)/2]))
+			return
+		}
+		w.Write([]byte(remoteOKFeed))
+	}))
+	defer server.Close()
+
+	source := NewRemoteOKSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+
+	if _, err := source.FetchJobs(context.Background()); !errors.Is(err, ErrMalformedJSON) {
+		t.Fatalf("FetchJobs of a truncated body: error = %v, want ErrMalformedJSON", err)
+	}
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil || len(jobs) != 3 {
+		t.Errorf("FetchJobs again = %d jobs, %v; want 3", len(jobs), err)
+	}
+}
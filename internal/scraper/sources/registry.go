@@ -0,0 +1,139 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Factory creates a job source that uses the given HTTP client
+type Factory func(client *httpclient.HttpClient) JobSource
+
+// SearchConfigurable is implemented by sources that can filter jobs by search terms
+type SearchConfigurable interface {
+	SetSearchTerms(terms []string, mode string)
+}
+
+// LocationConfigurable is implemented by sources that search specific locations
+type LocationConfigurable interface {
+	SetLocations(locations []string)
+}
+
+// HTMLConfigurable is implemented by sources that can keep raw HTML descriptions
+type HTMLConfigurable interface {
+	SetPreserveHTML(preserve bool)
+}
+
+// DelayConfigurable is implemented by sources that make several requests in a
+// single fetch and can pause between them
+type DelayConfigurable interface {
+	SetRequestDelay(delay time.Duration)
+}
+
+// AuthConfigurable is implemented by sources that can send credentials, such
+// as an API key or bearer token, with their requests
+type AuthConfigurable interface {
+	SetAuth(name, value string)
+}
+
+// authHeader builds the header a source sends its credentials in, or nil
+// when no header name is given
+func authHeader(name, value string) http.Header {
+	if name == "" {
+		return nil
+	}
+	header := make(http.Header)
+	header.Set(name, value)
+	return header
+}
+
+// ErrUnknownCategory is returned by FetchJobsByCategory for categories the source does not have
+var ErrUnknownCategory = errors.New("unknown category")
+
+// CategorySource is implemented by sources that can fetch jobs of a single category
+type CategorySource interface {
+	FetchJobsByCategory(ctx context.Context, category string) ([]models.Job, error)
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+	aliases     = make(map[string]string)
+)
+
+// RegisterFactory registers a source factory under a name such as "remoteok".
+// Names are case-insensitive and match the keys of the sources config.
+// It panics if the name is already registered or the factory is nil.
+func RegisterFactory(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	name = strings.ToLower(name)
+	if factory == nil {
+		panic("sources: RegisterFactory factory is nil for " + name)
+	}
+	if _, exists := factories[name]; exists {
+		panic("sources: RegisterFactory called twice for " + name)
+	}
+	factories[name] = factory
+}
+
+// RegisterAlias registers a shorter name for a registered source, such as
+// "wework" for "wework_remotely". Aliases are accepted by BuildSource but not
+// listed by FactoryNames.
+func RegisterAlias(alias, name string) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	alias = strings.ToLower(alias)
+	if _, exists := factories[alias]; exists {
+		panic("sources: RegisterAlias alias is a source name: " + alias)
+	}
+	aliases[alias] = strings.ToLower(name)
+}
+
+// CanonicalName resolves an alias to its source name, returning other names lowercased
+func CanonicalName(name string) string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	name = strings.ToLower(name)
+	if canonical, ok := aliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// BuildSource creates the registered source with the given name or alias
+func BuildSource(name string, client *httpclient.HttpClient) (JobSource, error) {
+	canonical := CanonicalName(name)
+
+	factoriesMu.RLock()
+	factory, exists := factories[canonical]
+	factoriesMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown source: %s (available sources: %s)", name, strings.Join(FactoryNames(), ", "))
+	}
+	return factory(client), nil
+}
+
+// FactoryNames returns the names of all registered sources in sorted order
+func FactoryNames() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper-go/pkg/httpclient"
+)
+
+func TestBuildSourceBuiltins(t *testing.T) {
+	client := httpclient.NewHttpClient(time.Second)
+
+	tests := []struct {
+		name     string
+		wantName string
+	}{
+		{"remoteok", "RemoteOK"},
+		{"RemoteOK", "RemoteOK"},
+		{"remotive", "Remotive"},
+		{"wework_remotely", "WeWorkRemotely"},
+		{"wework", "WeWorkRemotely"},
+		{"indeed", "Indeed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := BuildSource(tt.name, client)
+			if err != nil {
+				t.Fatalf("BuildSource: %v", err)
+			}
+			if got := source.GetName(); got != tt.wantName {
+				t.Errorf("BuildSource(%q) built %q, want %q", tt.name, got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestBuildSourceUnknownListsAvailableSources(t *testing.T) {
+	_, err := BuildSource("monster", httpclient.NewHttpClient(time.Second))
+	if err == nil || !strings.Contains(err.Error(), "unknown source: monster") || !strings.Contains(err.Error(), "remotive") {
+		t.Errorf("BuildSource(monster) error = %v, want unknown source listing the available ones", err)
+	}
+}
+
+func TestFactoryNamesListsSourcesNotAliases(t *testing.T) {
+	names := strings.Join(FactoryNames(), ",")
+	for _, want := range []string{"indeed", "remoteok", "remotive", "wework_remotely"} {
+		if !strings.Contains(names, want) {
+			t.Errorf("FactoryNames() = %s, missing %s", names, want)
+		}
+	}
+	if strings.Contains(names, "wework,") || strings.HasSuffix(names, ",wework") {
+		t.Errorf("FactoryNames() = %s, want aliases left out", names)
+	}
+}
+
+func TestCanonicalName(t *testing.T) {
+	tests := map[string]string{
+		"wework":          "wework_remotely",
+		"WeWork":          "wework_remotely",
+		"RemoteOK":        "remoteok",
+		"unknown_board":   "unknown_board",
+		"wework_remotely": "wework_remotely",
+	}
+	for name, want := range tests {
+		if got := CanonicalName(name); got != want {
+			t.Errorf("CanonicalName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRegisterFactoryRejectsDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering remoteok twice did not panic")
+		}
+	}()
+
+	RegisterFactory("RemoteOK", func(client *httpclient.HttpClient) JobSource {
+		return NewRemoteOKSource(client)
+	})
+}
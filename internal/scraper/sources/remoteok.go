@@ -1,7 +1,8 @@
 package sources
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"job-scraper-go/internal/models"
@@ -13,8 +14,17 @@ import (
 
 // RemoteOKSource implements JobSource for RemoteOK API
 type RemoteOKSource struct {
-	client  *httpclient.HttpClient
-	baseURL string
+	client      *httpclient.HttpClient
+	baseURL     string
+	searchTerms []string
+	searchMode  string
+	auth        http.Header // credentials sent with every request
+}
+
+func init() {
+	RegisterFactory("remoteok", func(client *httpclient.HttpClient) JobSource {
+		return NewRemoteOKSource(client)
+	})
 }
 
 // NewRemoteOKSource creates a new RemoteOK source
@@ -25,6 +35,19 @@ func NewRemoteOKSource(client *httpclient.HttpClient) *RemoteOKSource {
 	}
 }
 
+// SetSearchTerms restricts FetchJobs to jobs matching the given terms.
+// The mode is SearchModeAny or SearchModeAll.
+func (r *RemoteOKSource) SetSearchTerms(terms []string, mode string) {
+	r.searchTerms = terms
+	r.searchMode = mode
+}
+
+// SetAuth sets a header, such as "Authorization" or "X-Api-Key", sent with
+// every request to the source
+func (r *RemoteOKSource) SetAuth(name, value string) {
+	r.auth = authHeader(name, value)
+}
+
 func (r *RemoteOKSource) GetName() string {
 	return "RemoteOK"
 }
@@ -41,6 +64,11 @@ func (r *RemoteOKSource) GetBaseURL() string {
 	return r.baseURL
 }
 
+// HealthCheck verifies that the RemoteOK base URL is reachable
+func (r *RemoteOKSource) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, r.client, r.baseURL, r.auth)
+}
+
 // RemoteOKJob represents a job from RemoteOK API
 type RemoteOKJob struct {
 	ID          string    `json:"id"`
@@ -55,17 +83,42 @@ type RemoteOKJob struct {
 	URL         string    `json:"url"`
 	ApplyURL    string    `json:"apply_url"`
 	Date        time.Time `json:"date"`
+	Legal       string    `json:"legal"` // only set on the leading legal-notice element
+}
+
+func (r *RemoteOKSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	jobs, err := r.fetchAllJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterJobsBySearch(jobs, r.searchTerms, r.searchMode), nil
+}
+
+// FetchJobsBySearch fetches jobs matching the given search terms, since the
+// RemoteOK API has no query parameter the full feed is filtered locally
+func (r *RemoteOKSource) FetchJobsBySearch(ctx context.Context, terms []string, mode string) ([]models.Job, error) {
+	jobs, err := r.fetchAllJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterJobsBySearch(jobs, terms, mode), nil
 }
 
-func (r *RemoteOKSource) FetchJobs() ([]models.Job, error) {
-	resp, err := r.client.Get(r.baseURL)
+// fetchAllJobs fetches and maps the full RemoteOK feed
+func (r *RemoteOKSource) fetchAllJobs(ctx context.Context) ([]models.Job, error) {
+	resp, err := r.client.GetWithHeaders(ctx, r.baseURL, r.auth)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil // feed unchanged since the last fetch
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from RemoteOK: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("RemoteOK API returned status %d", resp.StatusCode)
+		return nil, &StatusError{Source: "RemoteOK API", StatusCode: resp.StatusCode}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -74,36 +127,46 @@ func (r *RemoteOKSource) FetchJobs() ([]models.Job, error) {
 	}
 
 	var remoteOKJobs []RemoteOKJob
-	if err := json.Unmarshal(body, &remoteOKJobs); err != nil {
+	if err := decodeJSON(resp, body, &remoteOKJobs); err != nil {
 		return nil, fmt.Errorf("failed to parse RemoteOK response: %w", err)
 	}
 
 	var jobs []models.Job
 	for _, remoteJob := range remoteOKJobs {
-		// Skip the first element which is metadata
-		if remoteJob.ID == "" {
+		// Skip the legal-notice metadata element and anything else that isn't a job
+		if remoteJob.Legal != "" || remoteJob.ID == "" {
 			continue
 		}
 
 		// Extract job type from tags
 		jobType := r.getJobType(remoteJob.Tags)
 
+		// Fall back to the scrape time when the date is missing, like Remotive
+		postedDate := remoteJob.Date
+		if postedDate.IsZero() {
+			postedDate = time.Now()
+		}
+
 		job := models.Job{
-			Title:       remoteJob.Position,
-			Company:     remoteJob.Company,
-			Location:    remoteJob.Location,
-			URL:         remoteJob.URL,
-			Description: remoteJob.Description,
-			Salary:      "", // RemoteOK doesn't provide salary information
-			PostedDate:  &remoteJob.Date,
-			Source:      r.GetName(),
-			JobCategory: r.getJobCategory(remoteJob.Tags),
-			JobType:     jobType,
+			Title:           remoteJob.Position,
+			Company:         remoteJob.Company,
+			Location:        remoteJob.Location,
+			URL:             remoteJob.URL,
+			Description:     remoteJob.Description,
+			Salary:          "", // RemoteOK doesn't provide salary information
+			PostedDate:      &postedDate,
+			Source:          r.GetName(),
+			JobCategory:     ClassifyCategory(remoteJob.Position, remoteJob.Tags, ""),
+			JobType:         jobType,
+			WorkMode:        classifyWorkMode(remoteJob.Location, remoteJob.Tags),
+			ExperienceLevel: inferExperienceLevel(remoteJob.Position),
+			Tags:            normalizeTags(remoteJob.Tags),
 		}
 
 		if job.URL == "" {
 			job.URL = fmt.Sprintf("https://remoteok.com/remote-jobs/%s", remoteJob.Slug)
 		}
+		job.Hash = models.JobHash(job)
 
 		jobs = append(jobs, job)
 	}
@@ -111,52 +174,32 @@ func (r *RemoteOKSource) FetchJobs() ([]models.Job, error) {
 	return jobs, nil
 }
 
-// getJobCategory extracts job category from tags - using a conservative approach since tags are mixed
-func (r *RemoteOKSource) getJobCategory(tags []string) string {
-	// Priority mapping - more specific tags first
-	categoryMap := map[string]string{
-		"backend":    "Backend Development",
-		"frontend":   "Frontend Development",
-		"fullstack":  "Full Stack Development",
-		"full-stack": "Full Stack Development",
-		"devops":     "DevOps",
-		"data":       "Data Science",
-		"ml":         "Machine Learning",
-		"ai":         "Artificial Intelligence",
-		"mobile":     "Mobile Development",
-		"ios":        "Mobile Development",
-		"android":    "Mobile Development",
-		"design":     "Design",
-		"marketing":  "Marketing",
-		"sales":      "Sales",
+// FetchJobsByCategory fetches jobs of a category. The RemoteOK API has no
+// category parameter, so the full feed is filtered by the classified
+// category. The category may be a name such as "Backend Development" or a
+// keyword such as "backend" or "devops".
+//
+// The feed is fetched without cache validators: a 304 for the feed only says
+// it is unchanged since the last fetch, such as that of another category,
+// and nothing about the jobs of this category.
+func (r *RemoteOKSource) FetchJobsByCategory(ctx context.Context, category string) ([]models.Job, error) {
+	target, ok := ResolveCategory(category)
+	if !ok {
+		return nil, fmt.Errorf("%w %q for RemoteOK", ErrUnknownCategory, category)
 	}
 
-	// First pass - look for explicit category tags
-	for _, tag := range tags {
-		if category, exists := categoryMap[strings.ToLower(tag)]; exists {
-			return category
-		}
-	}
-
-	// Second pass - infer from technology tags
-	techMap := map[string]string{
-		"golang":     "Backend Development",
-		"go":         "Backend Development",
-		"python":     "Backend Development",
-		"java":       "Backend Development",
-		"javascript": "Frontend Development",
-		"react":      "Frontend Development",
-		"vue":        "Frontend Development",
-		"angular":    "Frontend Development",
+	jobs, err := r.fetchAllJobs(httpclient.Unconditional(ctx))
+	if err != nil {
+		return nil, err
 	}
 
-	for _, tag := range tags {
-		if category, exists := techMap[strings.ToLower(tag)]; exists {
-			return category
+	var matching []models.Job
+	for _, job := range jobs {
+		if job.JobCategory == target {
+			matching = append(matching, job)
 		}
 	}
-
-	return "Technology" // Safe default
+	return matching, nil
 }
 
 // getJobType extracts job type from tags
@@ -170,7 +213,7 @@ func (r *RemoteOKSource) getJobType(tags []string) string {
 		case "contract", "contractor", "freelance":
 			return models.JobTypeContract
 		case "internship", "intern":
-			return "internship"
+			return models.JobTypeInternship
 		}
 	}
 	return models.JobTypeFullTime // Default assumption
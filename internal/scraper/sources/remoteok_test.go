@@ -0,0 +1,238 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// remoteOKFeed is a RemoteOK API response: a legal notice followed by jobs
+const remoteOKFeed = `[
+	{"legal": "API Terms of Service"},
+	{"id": "1", "slug": "go-developer-acme", "company": "Acme", "position": "Senior Go Developer",
+	 "tags": ["Golang", " backend ", "golang", "full-time", ""], "description": "Build APIs in Go",
+	 "location": "Worldwide", "url": "https://remoteok.com/remote-jobs/1", "date": "2024-05-01T10:00:00Z"},
+	{"id": "2", "slug": "designer-globex", "company": "Globex", "position": "Product Designer",
+	 "tags": ["design", "figma"], "description": "Design the product", "location": "Europe",
+	 "url": "https://remoteok.com/remote-jobs/2", "date": "2024-05-02T10:00:00Z"},
+	{"id": "3", "slug": "intern-initech", "company": "Initech", "position": "Data Intern",
+	 "tags": ["internship", "python"], "description": "Learn data engineering", "location": "Remote",
+	 "date": "2024-05-03T10:00:00Z"}
+]`
+
+// newTestRemoteOK returns a RemoteOK source reading body from a test server
+func newTestRemoteOK(t *testing.T, body string) *RemoteOKSource {
+	t.Helper()
+
+	server := serveBody(t, "application/json", body)
+	source := NewRemoteOKSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+	return source
+}
+
+// jobTitles returns the titles of jobs in order
+func jobTitles(jobs []models.Job) []string {
+	var titles []string
+	for _, job := range jobs {
+		titles = append(titles, job.Title)
+	}
+	return titles
+}
+
+func TestRemoteOKKeepsNormalizedTags(t *testing.T) {
+	jobs, err := newTestRemoteOK(t, remoteOKFeed).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("got %d jobs, want 3 without the legal notice", len(jobs))
+	}
+
+	want := []string{"golang", "backend", "full-time"}
+	if !reflect.DeepEqual(jobs[0].Tags, want) {
+		t.Errorf("tags = %q, want %q", jobs[0].Tags, want)
+	}
+
+	// Tags are stored as a JSON array
+	data, err := json.Marshal(jobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored models.Job
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored.Tags, want) {
+		t.Errorf("tags after a JSON round trip = %q, want %q", stored.Tags, want)
+	}
+}
+
+func TestRemoteOKJobTypeFromTags(t *testing.T) {
+	source := NewRemoteOKSource(nil)
+
+	tests := []struct {
+		tags []string
+		want string
+	}{
+		{[]string{"golang", "Internship"}, models.JobTypeInternship},
+		{[]string{"intern"}, models.JobTypeInternship},
+		{[]string{"part-time"}, models.JobTypePartTime},
+		{[]string{"contractor"}, models.JobTypeContract},
+		{[]string{"golang"}, models.JobTypeFullTime},
+	}
+	for _, tt := range tests {
+		got := source.getJobType(tt.tags)
+		if got != tt.want {
+			t.Errorf("getJobType(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+		if !models.ValidJobType(got) {
+			t.Errorf("getJobType(%q) = %q, which is not a standardized job type", tt.tags, got)
+		}
+	}
+}
+
+func TestRemoteOKSkipsMetadataElements(t *testing.T) {
+	// The legal notice is skipped even when it carries an id
+	jobs, err := newTestRemoteOK(t, `[
+		{"id": "0", "legal": "API Terms of Service", "position": "Not a job"},
+		{"last_updated": 1714557600},
+		{"id": "1", "company": "Acme", "position": "Go Developer", "url": "https://remoteok.com/remote-jobs/1", "date": "2024-05-01T10:00:00Z"}
+	]`).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Title != "Go Developer" {
+		t.Errorf("got jobs %q, want only Go Developer", jobTitles(jobs))
+	}
+}
+
+func TestRemoteOKPostedDate(t *testing.T) {
+	before := time.Now()
+	jobs, err := newTestRemoteOK(t, `[
+		{"legal": "API Terms of Service"},
+		{"id": "1", "company": "Acme", "position": "Dated", "url": "https://remoteok.com/remote-jobs/1", "date": "2024-05-01T10:00:00Z"},
+		{"id": "2", "company": "Acme", "position": "Undated", "slug": "undated-acme"}
+	]`).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	if want := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC); jobs[0].PostedDate == nil || !jobs[0].PostedDate.Equal(want) {
+		t.Errorf("dated job posted %v, want %v", jobs[0].PostedDate, want)
+	}
+	if posted := jobs[1].PostedDate; posted == nil || posted.Before(before) || posted.After(time.Now()) {
+		t.Errorf("undated job posted %v, want the scrape time", posted)
+	}
+	if jobs[1].URL != "https://remoteok.com/remote-jobs/undated-acme" {
+		t.Errorf("undated job URL = %q, want one built from the slug", jobs[1].URL)
+	}
+}
+
+func TestRemoteOKUnchangedFeedHasNoJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"feed-v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"feed-v1"`)
+		w.Write([]byte(remoteOKFeed))
+	}))
+	defer server.Close()
+
+	client := httpclient.NewHttpClient(5 * time.Second)
+	client.SetConditionalRequests(true)
+	source := NewRemoteOKSource(client)
+	source.baseURL = server.URL
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil || len(jobs) != 3 {
+		t.Fatalf("first FetchJobs = %d jobs, %v; want 3", len(jobs), err)
+	}
+	jobs, err = source.FetchJobs(context.Background())
+	if err != nil || len(jobs) != 0 {
+		t.Errorf("FetchJobs of an unchanged feed = %d jobs, %v; want none and no error", len(jobs), err)
+	}
+}
+
+func TestRemoteOKFetchJobsByCategory(t *testing.T) {
+	tests := []struct {
+		category string
+		want     []string
+	}{
+		{"backend", []string{"Senior Go Developer"}},
+		{"Backend Development", []string{"Senior Go Developer"}},
+		{"design", []string{"Product Designer"}},
+		{"data", []string{"Data Intern"}},
+		{"devops", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			jobs, err := newTestRemoteOK(t, remoteOKFeed).FetchJobsByCategory(context.Background(), tt.category)
+			if err != nil {
+				t.Fatalf("FetchJobsByCategory: %v", err)
+			}
+			if got := jobTitles(jobs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FetchJobsByCategory(%q) = %q, want %q", tt.category, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoteOKFetchJobsByCategoryWithConditionalRequests(t *testing.T) {
+	var conditional []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match") != "")
+		if r.Header.Get("If-None-Match") == `"feed-v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"feed-v1"`)
+		w.Write([]byte(remoteOKFeed))
+	}))
+	defer server.Close()
+
+	client := httpclient.NewHttpClient(5 * time.Second)
+	client.SetConditionalRequests(true)
+	source := NewRemoteOKSource(client)
+	source.baseURL = server.URL
+
+	// Each category of a run is filtered from the full feed
+	for _, tt := range []struct {
+		category string
+		want     []string
+	}{
+		{"backend", []string{"Senior Go Developer"}},
+		{"design", []string{"Product Designer"}},
+		{"backend", []string{"Senior Go Developer"}},
+	} {
+		jobs, err := source.FetchJobsByCategory(context.Background(), tt.category)
+		if err != nil {
+			t.Fatalf("FetchJobsByCategory(%q): %v", tt.category, err)
+		}
+		if got := jobTitles(jobs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FetchJobsByCategory(%q) = %q, want %q", tt.category, got, tt.want)
+		}
+	}
+	if want := []bool{false, false, false}; !reflect.DeepEqual(conditional, want) {
+		t.Errorf("conditional requests = %v, want %v", conditional, want)
+	}
+}
+
+func TestRemoteOKFetchJobsByUnknownCategory(t *testing.T) {
+	_, err := newTestRemoteOK(t, remoteOKFeed).FetchJobsByCategory(context.Background(), "astronomy")
+	if !errors.Is(err, ErrUnknownCategory) {
+		t.Errorf("FetchJobsByCategory(astronomy) = %v, want ErrUnknownCategory", err)
+	}
+}
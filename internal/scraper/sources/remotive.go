@@ -1,7 +1,8 @@
 package sources
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"job-scraper-go/internal/models"
@@ -13,8 +14,16 @@ import (
 
 // RemotiveSource implements JobSource for Remotive API
 type RemotiveSource struct {
-	client  *httpclient.HttpClient
-	baseURL string
+	client       *httpclient.HttpClient
+	baseURL      string
+	preserveHTML bool
+	auth         http.Header // credentials sent with every request
+}
+
+func init() {
+	RegisterFactory("remotive", func(client *httpclient.HttpClient) JobSource {
+		return NewRemotiveSource(client)
+	})
 }
 
 // NewRemotiveSource creates a new Remotive source
@@ -25,6 +34,17 @@ func NewRemotiveSource(client *httpclient.HttpClient) *RemotiveSource {
 	}
 }
 
+// SetPreserveHTML keeps job descriptions as raw HTML instead of plain text
+func (r *RemotiveSource) SetPreserveHTML(preserve bool) {
+	r.preserveHTML = preserve
+}
+
+// SetAuth sets a header, such as "Authorization" or "X-Api-Key", sent with
+// every request to the source
+func (r *RemotiveSource) SetAuth(name, value string) {
+	r.auth = authHeader(name, value)
+}
+
 func (r *RemotiveSource) GetName() string {
 	return "Remotive"
 }
@@ -41,6 +61,11 @@ func (r *RemotiveSource) GetBaseURL() string {
 	return r.baseURL
 }
 
+// HealthCheck verifies that the Remotive base URL is reachable
+func (r *RemotiveSource) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, r.client, r.baseURL, r.auth)
+}
+
 // RemotiveResponse represents the API response from Remotive
 type RemotiveResponse struct {
 	Jobs []RemotiveJob `json:"jobs"`
@@ -61,223 +86,119 @@ type RemotiveJob struct {
 	Description               string `json:"description"`
 }
 
-func (r *RemotiveSource) FetchJobs() ([]models.Job, error) {
-	resp, err := r.client.Get(r.baseURL)
+func (r *RemotiveSource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	resp, err := r.client.GetWithHeaders(ctx, r.baseURL, r.auth)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil // no changes since the last fetch
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from Remotive: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Remotive API returned status %d", resp.StatusCode)
+		return nil, &StatusError{Source: "Remotive API", StatusCode: resp.StatusCode}
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var response RemotiveResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse Remotive response: %w", err)
-	}
-
-	var jobs []models.Job
-	for _, remotiveJob := range response.Jobs {
-		location := remotiveJob.CandidateRequiredLocation
-		if location == "" {
-			location = "Remote"
-		}
-
-		// Parse posted date with better error handling
-		var postedDate *time.Time
-		if remotiveJob.PublicationDate != "" {
-			// Try multiple date formats that Remotive might use
-			formats := []string{
-				"2006-01-02",
-				"2006-01-02T15:04:05Z",
-				"2006-01-02T15:04:05-07:00",
-				"2006-01-02 15:04:05",
-			}
-
-			for _, format := range formats {
-				if parsed, err := time.Parse(format, remotiveJob.PublicationDate); err == nil {
-					postedDate = &parsed
-					break
-				}
-			}
-
-			// If all parsing attempts fail, set to current time as fallback
-			if postedDate == nil {
-				now := time.Now()
-				postedDate = &now
-			}
-		}
-
-		// Use job_type directly from Remotive API
-		jobType := r.getJobType(remotiveJob.JobType)
-
-		// Ensure all fields have values, even if empty, to maintain consistent JSON structure
-		description := remotiveJob.Description
-		if description == "" {
-			description = " " // Single space instead of empty to avoid omitempty
-		}
-		salary := remotiveJob.Salary
-		if salary == "" {
-			salary = " " // Single space instead of empty to avoid omitempty
-		}
-		jobCategory := r.getJobCategory(remotiveJob.Category, remotiveJob.Title)
-		if jobCategory == "" {
-			jobCategory = " " // Single space instead of empty
-		}
-
-		job := models.Job{
-			Title:       remotiveJob.Title,
-			Company:     remotiveJob.CompanyName,
-			Location:    location,
-			URL:         remotiveJob.URL,
-			Description: description,
-			Salary:      salary,
-			PostedDate:  postedDate,
-			Source:      r.GetName(),
-			JobCategory: jobCategory,
-			JobType:     jobType,
-		}
-
-		jobs = append(jobs, job)
-	}
-
-	return jobs, nil
+	return r.parseJobs(resp)
 }
 
 // FetchJobsByCategory fetches jobs from specific category
-func (r *RemotiveSource) FetchJobsByCategory(category string) ([]models.Job, error) {
+func (r *RemotiveSource) FetchJobsByCategory(ctx context.Context, category string) ([]models.Job, error) {
 	url := fmt.Sprintf("%s?category=%s", r.baseURL, strings.ToLower(category))
 
-	resp, err := r.client.Get(url)
+	resp, err := r.client.GetWithHeaders(ctx, url, r.auth)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil // no changes since the last fetch
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from Remotive with category %s: %w", category, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Remotive API returned status %d for category %s", resp.StatusCode, category)
+		return nil, fmt.Errorf("%w for category %s", &StatusError{Source: "Remotive API", StatusCode: resp.StatusCode}, category)
 	}
 
+	return r.parseJobs(resp)
+}
+
+// parseJobs reads a Remotive API response and maps its jobs
+func (r *RemotiveSource) parseJobs(resp *http.Response) ([]models.Job, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	var response RemotiveResponse
-	if err := json.Unmarshal(body, &response); err != nil {
+	if err := decodeJSON(resp, body, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse Remotive response: %w", err)
 	}
 
-	var jobs []models.Job
+	jobs := make([]models.Job, 0, len(response.Jobs))
 	for _, remotiveJob := range response.Jobs {
-		location := remotiveJob.CandidateRequiredLocation
-		if location == "" {
-			location = "Remote"
-		}
+		jobs = append(jobs, r.toJob(remotiveJob))
+	}
+	return jobs, nil
+}
 
-		// Parse posted date with better error handling
-		var postedDate *time.Time
-		if remotiveJob.PublicationDate != "" {
-			// Try multiple date formats that Remotive might use
-			formats := []string{
-				"2006-01-02",
-				"2006-01-02T15:04:05Z",
-				"2006-01-02T15:04:05-07:00",
-				"2006-01-02 15:04:05",
-			}
+// remotiveDateFormats are the publication date formats Remotive uses
+var remotiveDateFormats = []string{
+	"2006-01-02",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05-07:00",
+	"2006-01-02 15:04:05",
+}
 
-			for _, format := range formats {
-				if parsed, err := time.Parse(format, remotiveJob.PublicationDate); err == nil {
-					postedDate = &parsed
-					break
-				}
-			}
+// toJob maps a Remotive API job onto a job
+func (r *RemotiveSource) toJob(remotiveJob RemotiveJob) models.Job {
+	location := remotiveJob.CandidateRequiredLocation
+	if location == "" {
+		location = "Remote"
+	}
 
-			// If all parsing attempts fail, set to current time as fallback
-			if postedDate == nil {
-				now := time.Now()
-				postedDate = &now
+	var postedDate *time.Time
+	if remotiveJob.PublicationDate != "" {
+		for _, format := range remotiveDateFormats {
+			if parsed, err := time.Parse(format, remotiveJob.PublicationDate); err == nil {
+				postedDate = &parsed
+				break
 			}
 		}
 
-		// Ensure all fields have values for consistent JSON structure
-		description := remotiveJob.Description
-		if description == "" {
-			description = " "
+		// If all parsing attempts fail, set to current time as fallback
+		if postedDate == nil {
+			now := time.Now()
+			postedDate = &now
 		}
-		salary := remotiveJob.Salary
-		if salary == "" {
-			salary = " "
-		}
-		jobCategory := r.getJobCategory(remotiveJob.Category, remotiveJob.Title)
-		if jobCategory == "" {
-			jobCategory = " "
-		}
-
-		job := models.Job{
-			Title:       remotiveJob.Title,
-			Company:     remotiveJob.CompanyName,
-			Location:    location,
-			URL:         remotiveJob.URL,
-			Description: description,
-			Salary:      salary,
-			PostedDate:  postedDate,
-			Source:      r.GetName(),
-			JobCategory: jobCategory,
-			JobType:     r.getJobType(remotiveJob.JobType),
-		}
-
-		jobs = append(jobs, job)
 	}
 
-	return jobs, nil
+	job := models.Job{
+		Title:           remotiveJob.Title,
+		Company:         remotiveJob.CompanyName,
+		Location:        location,
+		URL:             remotiveJob.URL,
+		Description:     r.description(remotiveJob.Description),
+		Salary:          strings.TrimSpace(remotiveJob.Salary),
+		PostedDate:      postedDate,
+		Source:          r.GetName(),
+		JobCategory:     ClassifyCategory(remotiveJob.Title, nil, remotiveJob.Category),
+		JobType:         r.getJobType(remotiveJob.JobType),
+		WorkMode:        classifyWorkMode(remotiveJob.CandidateRequiredLocation, nil),
+		ExperienceLevel: inferExperienceLevel(remotiveJob.Title),
+	}
+	applySalary(&job, remotiveJob.Salary)
+	job.Hash = models.JobHash(job)
+
+	return job
 }
 
-// getJobCategory determines job category from Remotive category and title
-func (r *RemotiveSource) getJobCategory(category, title string) string {
-	// Use category if available
-	if category != "" {
-		// Replace hyphens with spaces and capitalize first letter of each word
-		formatted := strings.ReplaceAll(category, "-", " ")
-		words := strings.Fields(formatted)
-		for i, word := range words {
-			if len(word) > 0 {
-				words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
-			}
-		}
-		return strings.Join(words, " ")
-	}
-
-	// Otherwise infer from title
-	title = strings.ToLower(title)
-	if strings.Contains(title, "frontend") || strings.Contains(title, "react") || strings.Contains(title, "vue") || strings.Contains(title, "angular") {
-		return "Frontend Development"
-	}
-	if strings.Contains(title, "backend") || strings.Contains(title, "golang") || strings.Contains(title, "go") || strings.Contains(title, "python") {
-		return "Backend Development"
-	}
-	if strings.Contains(title, "fullstack") || strings.Contains(title, "full-stack") || strings.Contains(title, "full stack") {
-		return "Full Stack Development"
-	}
-	if strings.Contains(title, "devops") || strings.Contains(title, "sre") {
-		return "DevOps"
-	}
-	if strings.Contains(title, "data") || strings.Contains(title, "analyst") {
-		return "Data Science"
-	}
-	if strings.Contains(title, "mobile") || strings.Contains(title, "ios") || strings.Contains(title, "android") {
-		return "Mobile Development"
-	}
-	if strings.Contains(title, "design") || strings.Contains(title, "ux") || strings.Contains(title, "ui") {
-		return "Design"
+// description returns the job description, stripped of HTML unless configured otherwise
+func (r *RemotiveSource) description(raw string) string {
+	if r.preserveHTML {
+		return raw
 	}
-	return "Technology"
+	return cleanDescription(raw)
 }
 
 // getJobType maps Remotive job types to our standardized job types
@@ -0,0 +1,150 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"job-scraper-go/pkg/httpclient"
+)
+
+// serveBody starts a server answering every request with body as contentType
+func serveBody(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestRemotive returns a Remotive source reading from a test server
+func newTestRemotive(t *testing.T, body string) *RemotiveSource {
+	t.Helper()
+
+	server := serveBody(t, "application/json", body)
+	source := NewRemotiveSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+	return source
+}
+
+func TestRemotiveParsesStructuredSalary(t *testing.T) {
+	source := newTestRemotive(t, `{"jobs": [
+		{"url": "https://remotive.com/1", "title": "Go Developer", "company_name": "Acme", "salary": "$90k - $120k"},
+		{"url": "https://remotive.com/2", "title": "Designer", "company_name": "Globex", "salary": "€50 - €70 per hour"},
+		{"url": "https://remotive.com/3", "title": "Writer", "company_name": "Initech", "salary": "Competitive"}
+	]}`)
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("got %d jobs, want 3", len(jobs))
+	}
+
+	tests := []struct {
+		min, max         int
+		currency, period string
+	}{
+		{90000, 120000, "USD", "year"},
+		{50, 70, "EUR", "hour"},
+		{0, 0, "", ""},
+	}
+	for i, want := range tests {
+		job := jobs[i]
+		if job.SalaryMin != want.min || job.SalaryMax != want.max || job.SalaryCurrency != want.currency || job.SalaryPeriod != want.period {
+			t.Errorf("salary %q parsed to %d-%d %s per %s, want %d-%d %s per %s", job.Salary,
+				job.SalaryMin, job.SalaryMax, job.SalaryCurrency, job.SalaryPeriod,
+				want.min, want.max, want.currency, want.period)
+		}
+	}
+	if jobs[2].Salary != "Competitive" {
+		t.Errorf("raw salary = %q, want it kept", jobs[2].Salary)
+	}
+}
+
+func TestRemotiveSalaryStrings(t *testing.T) {
+	tests := []struct {
+		raw              string
+		wantRaw          string
+		min, max         int
+		currency, period string
+	}{
+		{raw: "$50,000 - $80,000", wantRaw: "$50,000 - $80,000", min: 50000, max: 80000, currency: "USD", period: "year"},
+		{raw: "€40k-€60k", wantRaw: "€40k-€60k", min: 40000, max: 60000, currency: "EUR", period: "year"},
+		{raw: "£45,000", wantRaw: "£45,000", min: 45000, max: 45000, currency: "GBP", period: "year"},
+		{raw: "", wantRaw: ""},
+		{raw: "  ", wantRaw: ""},
+		{raw: "DOE", wantRaw: "DOE"},
+	}
+
+	source := NewRemotiveSource(httpclient.NewHttpClient(5 * time.Second))
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			job := source.toJob(RemotiveJob{URL: "https://remotive.com/1", Title: "Go Developer", CompanyName: "Acme", Salary: tt.raw})
+
+			if job.Salary != tt.wantRaw {
+				t.Errorf("Salary = %q, want %q", job.Salary, tt.wantRaw)
+			}
+			if job.SalaryMin != tt.min || job.SalaryMax != tt.max || job.SalaryCurrency != tt.currency || job.SalaryPeriod != tt.period {
+				t.Errorf("parsed to %d-%d %s per %s, want %d-%d %s per %s",
+					job.SalaryMin, job.SalaryMax, job.SalaryCurrency, job.SalaryPeriod,
+					tt.min, tt.max, tt.currency, tt.period)
+			}
+		})
+	}
+}
+
+func TestRemotiveKeepsEmptyFieldsEmpty(t *testing.T) {
+	source := newTestRemotive(t, `{"jobs": [
+		{"url": "https://remotive.com/1", "title": "Go Developer", "company_name": "Acme", "description": "", "salary": "", "category": ""}
+	]}`)
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("got %d jobs, want 1", len(jobs))
+	}
+	if jobs[0].Description != "" || jobs[0].Salary != "" {
+		t.Errorf("Description = %q, Salary = %q, want both empty", jobs[0].Description, jobs[0].Salary)
+	}
+	if jobs[0].JobCategory == " " {
+		t.Error("JobCategory is padded with a space")
+	}
+}
+
+func TestRemotiveSendsAuthHeader(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jobs": []}`))
+	}))
+	defer server.Close()
+
+	source := NewRemotiveSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+	source.SetAuth("Authorization", "Bearer token-456")
+
+	if _, err := source.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if auth := got.Get("Authorization"); auth != "Bearer token-456" {
+		t.Errorf("Authorization = %q, want the bearer token", auth)
+	}
+
+	source.SetAuth("", "")
+	if _, err := source.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if auth := got.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization = %q after clearing the credentials, want none", auth)
+	}
+}
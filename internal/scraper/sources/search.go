@@ -0,0 +1,72 @@
+package sources
+
+import (
+	"job-scraper-go/internal/models"
+	"strings"
+	"unicode"
+)
+
+// Search modes for matching multiple search terms
+const (
+	SearchModeAny = "any" // a job matches if any term matches
+	SearchModeAll = "all" // a job matches only if every term matches
+)
+
+// FilterJobsBySearch returns the jobs whose title, tags, or description match the
+// search terms. Matching is case-insensitive and on whole words; an empty mode
+// defaults to SearchModeAny. All jobs are returned when no terms are given.
+func FilterJobsBySearch(jobs []models.Job, terms []string, mode string) []models.Job {
+	var normalized []string
+	for _, term := range terms {
+		if term = strings.Join(searchWords(term), " "); term != "" {
+			normalized = append(normalized, term)
+		}
+	}
+	if len(normalized) == 0 {
+		return jobs
+	}
+
+	var matched []models.Job
+	for _, job := range jobs {
+		if jobMatchesSearch(job, normalized, mode) {
+			matched = append(matched, job)
+		}
+	}
+	return matched
+}
+
+// jobMatchesSearch checks a job against normalized search terms
+func jobMatchesSearch(job models.Job, terms []string, mode string) bool {
+	text := " " + strings.Join(searchWords(job.Title+" "+job.Description), " ") + " "
+
+	tags := make(map[string]bool)
+	for _, tag := range job.Tags {
+		tags[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	for _, term := range terms {
+		matches := tags[term] || strings.Contains(text, " "+term+" ")
+
+		if mode == SearchModeAll && !matches {
+			return false
+		}
+		if mode != SearchModeAll && matches {
+			return true
+		}
+	}
+
+	return mode == SearchModeAll
+}
+
+// searchWords lowercases text and splits it into words, keeping characters
+// common in technology names such as "c++", "c#" and "node.js"
+func searchWords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' && r != '.'
+	})
+
+	for i, word := range words {
+		words[i] = strings.Trim(word, ".")
+	}
+	return words
+}
@@ -0,0 +1,71 @@
+package sources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"job-scraper-go/internal/models"
+)
+
+func TestFilterJobsBySearch(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "Senior Go Developer", Description: "Build APIs with PostgreSQL", Tags: []string{"golang", "backend"}},
+		{Title: "Product Designer", Description: "Design in Figma", Tags: []string{"design"}},
+		{Title: "Data Engineer", Description: "Pipelines in Python and Go", Tags: []string{"python"}},
+		{Title: "C++ Engineer", Description: "Low-latency systems"},
+		{Title: "Frontend Developer", Description: "React and Node.js", Tags: []string{"javascript"}},
+	}
+
+	tests := []struct {
+		name  string
+		terms []string
+		mode  string
+		want  []string
+	}{
+		{"no terms", nil, "", []string{"Senior Go Developer", "Product Designer", "Data Engineer", "C++ Engineer", "Frontend Developer"}},
+		{"tag", []string{"golang"}, SearchModeAny, []string{"Senior Go Developer"}},
+		{"case-insensitive", []string{"FIGMA"}, SearchModeAny, []string{"Product Designer"}},
+		{"whole words", []string{"go"}, SearchModeAny, []string{"Senior Go Developer", "Data Engineer"}},
+		{"any", []string{"python", "design"}, SearchModeAny, []string{"Product Designer", "Data Engineer"}},
+		{"default mode is any", []string{"python", "design"}, "", []string{"Product Designer", "Data Engineer"}},
+		{"all", []string{"go", "python"}, SearchModeAll, []string{"Data Engineer"}},
+		{"all without a match", []string{"go", "figma"}, SearchModeAll, nil},
+		{"symbols", []string{"c++", "node.js"}, SearchModeAny, []string{"C++ Engineer", "Frontend Developer"}},
+		{"phrase", []string{"Product Designer"}, SearchModeAny, []string{"Product Designer"}},
+		{"blank terms", []string{" ", ""}, SearchModeAll, []string{"Senior Go Developer", "Product Designer", "Data Engineer", "C++ Engineer", "Frontend Developer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, job := range FilterJobsBySearch(jobs, tt.terms, tt.mode) {
+				got = append(got, job.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterJobsBySearch(%q, %q) = %q, want %q", tt.terms, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoteOKFiltersBySearchTerms(t *testing.T) {
+	source := newTestRemoteOK(t, remoteOKFeed)
+	source.SetSearchTerms([]string{"python", "figma"}, SearchModeAny)
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Title != "Product Designer" || jobs[1].Title != "Data Intern" {
+		t.Errorf("FetchJobs returned %d jobs, want Product Designer and Data Intern", len(jobs))
+	}
+
+	jobs, err = source.FetchJobsBySearch(context.Background(), []string{"golang", "backend"}, SearchModeAll)
+	if err != nil {
+		t.Fatalf("FetchJobsBySearch: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Title != "Senior Go Developer" {
+		t.Errorf("FetchJobsBySearch returned %d jobs, want Senior Go Developer", len(jobs))
+	}
+}
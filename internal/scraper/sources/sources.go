@@ -1,32 +1,45 @@
 package sources
 
 import (
+	"context"
+	"fmt"
 	"job-scraper-go/internal/models"
+	"strings"
+	"sync"
+	"time"
 )
 
 // JobSource represents a job board source
 type JobSource interface {
 	GetName() string
-	FetchJobs() ([]models.Job, error)
+	FetchJobs(ctx context.Context) ([]models.Job, error)
 	GetRateLimit() int // requests per minute
 	SupportsSearch() bool
 	GetBaseURL() string
+	HealthCheck(ctx context.Context) error // lightweight connectivity check
 }
 
 // JobSourceConfig holds configuration for job sources
 type JobSourceConfig struct {
-	Enabled     bool                   `json:"enabled"`
-	RateLimit   int                    `json:"rate_limit"`
-	SearchTerms []string               `json:"search_terms"`
-	Locations   []string               `json:"locations"`
-	JobTypes    []string               `json:"job_types"`
-	Custom      map[string]interface{} `json:"custom"`
+	Enabled          bool                   `json:"enabled"`
+	RateLimit        int                    `json:"rate_limit"`
+	RateWindow       time.Duration          `json:"rate_window"`         // window for RateLimit, defaults to a minute
+	Burst            int                    `json:"burst"`               // requests allowed at once after idling, defaults to 1
+	Timeout          time.Duration          `json:"timeout"`             // limit for each fetch, defaults to the request timeout
+	RequestDelay     time.Duration          `json:"request_delay"`       // pause between the requests of a single fetch
+	MinExpectedJobs  int                    `json:"min_expected_jobs"`   // fewer jobs in a fetch flag a suspicious run, 0 disables the check
+	FailBelowMinJobs bool                   `json:"fail_below_min_jobs"` // treat a suspicious run as an error
+	SearchTerms      []string               `json:"search_terms"`
+	Locations        []string               `json:"locations"`
+	JobTypes         []string               `json:"job_types"`
+	Custom           map[string]interface{} `json:"custom"`
 }
 
-// SourceManager manages all job sources
+// SourceManager manages all job sources. It is safe for concurrent use.
 type SourceManager struct {
 	sources map[string]JobSource
 	configs map[string]JobSourceConfig
+	mu      sync.RWMutex
 }
 
 // NewSourceManager creates a new source manager
@@ -37,19 +50,50 @@ func NewSourceManager() *SourceManager {
 	}
 }
 
+// SleepContext pauses for delay, returning early with the context's error
+// when ctx is done
+func SleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // RegisterSource registers a new job source
 func (sm *SourceManager) RegisterSource(source JobSource, config JobSourceConfig) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
 	sm.sources[source.GetName()] = source
 	sm.configs[source.GetName()] = config
 }
 
-// GetSources returns all registered sources
+// GetSources returns a snapshot of all registered sources
 func (sm *SourceManager) GetSources() map[string]JobSource {
-	return sm.sources
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	sources := make(map[string]JobSource, len(sm.sources))
+	for name, source := range sm.sources {
+		sources[name] = source
+	}
+	return sources
 }
 
 // GetEnabledSources returns only enabled sources
 func (sm *SourceManager) GetEnabledSources() map[string]JobSource {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
 	enabled := make(map[string]JobSource)
 	for name, source := range sm.sources {
 		if config, exists := sm.configs[name]; exists && config.Enabled {
@@ -61,6 +105,64 @@ func (sm *SourceManager) GetEnabledSources() map[string]JobSource {
 
 // GetSourceConfig returns configuration for a source
 func (sm *SourceManager) GetSourceConfig(name string) (JobSourceConfig, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
 	config, exists := sm.configs[name]
 	return config, exists
 }
+
+// SetEnabled enables or disables a registered source at runtime
+func (sm *SourceManager) SetEnabled(name string, enabled bool) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	config, exists := sm.configs[name]
+	if !exists {
+		return fmt.Errorf("unknown source: %s", name)
+	}
+	config.Enabled = enabled
+	sm.configs[name] = config
+	return nil
+}
+
+// IsEnabled reports whether a registered source is enabled
+func (sm *SourceManager) IsEnabled(name string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	config, exists := sm.configs[name]
+	return exists && config.Enabled
+}
+
+// applySalary fills the structured salary fields of a job from a raw salary
+// string. Amounts with neither a currency nor a period, as in "Up to 4 weeks
+// PTO", are not taken for a salary.
+func applySalary(job *models.Job, raw string) {
+	info, ok := models.ParseSalary(raw)
+	if !ok || (info.Currency == "" && info.Period == "") {
+		return
+	}
+
+	job.SalaryMin = info.Min
+	job.SalaryMax = info.Max
+	job.SalaryCurrency = info.Currency
+	job.SalaryPeriod = info.Period
+}
+
+// normalizeTags lowercases and trims tags, dropping empty and repeated ones
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
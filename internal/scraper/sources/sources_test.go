@@ -0,0 +1,139 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"job-scraper-go/internal/models"
+)
+
+// stubSource is a JobSource with a name and no jobs
+type stubSource struct {
+	name string
+}
+
+func (s stubSource) GetName() string                                     { return s.name }
+func (s stubSource) FetchJobs(ctx context.Context) ([]models.Job, error) { return nil, nil }
+func (s stubSource) GetRateLimit() int                                   { return 60 }
+func (s stubSource) SupportsSearch() bool                                { return false }
+func (s stubSource) GetBaseURL() string                                  { return "https://example.com" }
+func (s stubSource) HealthCheck(ctx context.Context) error               { return nil }
+
+func TestSourceManagerSetEnabled(t *testing.T) {
+	sm := NewSourceManager()
+	sm.RegisterSource(stubSource{"RemoteOK"}, JobSourceConfig{Enabled: true, RateLimit: 30})
+	sm.RegisterSource(stubSource{"Remotive"}, JobSourceConfig{Enabled: true})
+
+	if err := sm.SetEnabled("RemoteOK", false); err != nil {
+		t.Fatalf("SetEnabled: %v", err)
+	}
+	if sm.IsEnabled("RemoteOK") {
+		t.Error("RemoteOK is enabled after disabling it")
+	}
+	if _, ok := sm.GetEnabledSources()["RemoteOK"]; ok {
+		t.Error("GetEnabledSources includes the disabled RemoteOK")
+	}
+	if config, _ := sm.GetSourceConfig("RemoteOK"); config.RateLimit != 30 {
+		t.Errorf("rate limit = %d after disabling, want the rest of the config kept", config.RateLimit)
+	}
+
+	if err := sm.SetEnabled("RemoteOK", true); err != nil {
+		t.Fatalf("SetEnabled: %v", err)
+	}
+	if !sm.IsEnabled("RemoteOK") || len(sm.GetEnabledSources()) != 2 {
+		t.Error("RemoteOK is not enabled again")
+	}
+
+	if err := sm.SetEnabled("Monster", true); err == nil {
+		t.Error("SetEnabled of an unregistered source succeeded, want an error")
+	}
+	if sm.IsEnabled("Monster") {
+		t.Error("IsEnabled reports an unregistered source as enabled")
+	}
+}
+
+func TestSourceManagerToggleWhileReading(t *testing.T) {
+	sm := NewSourceManager()
+	sm.RegisterSource(stubSource{"RemoteOK"}, JobSourceConfig{Enabled: true})
+	sm.RegisterSource(stubSource{"Remotive"}, JobSourceConfig{Enabled: true})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			sm.SetEnabled("RemoteOK", i%2 == 0)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if _, ok := sm.GetEnabledSources()["Remotive"]; !ok {
+				t.Error("Remotive disappeared while RemoteOK was toggled")
+				return
+			}
+			sm.IsEnabled("RemoteOK")
+		}
+	}()
+	wg.Wait()
+
+	// The last toggle disabled RemoteOK
+	if sm.IsEnabled("RemoteOK") {
+		t.Error("RemoteOK is enabled after the final toggle disabled it")
+	}
+}
+
+// Run with -race to check the manager's locking
+func TestSourceManagerRegisterWhileReading(t *testing.T) {
+	sm := NewSourceManager()
+
+	var wg sync.WaitGroup
+	for writer := 0; writer < 4; writer++ {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				sm.RegisterSource(stubSource{fmt.Sprintf("Source%d-%d", writer, i)}, JobSourceConfig{Enabled: i%2 == 0})
+			}
+		}(writer)
+	}
+	for reader := 0; reader < 4; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				for name := range sm.GetSources() {
+					sm.GetSourceConfig(name)
+				}
+				sm.GetEnabledSources()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(sm.GetSources()); got != 400 {
+		t.Errorf("registered %d sources, want 400", got)
+	}
+	if got := len(sm.GetEnabledSources()); got != 200 {
+		t.Errorf("%d enabled sources, want 200", got)
+	}
+}
+
+func TestSourceManagerSnapshotsAreCopies(t *testing.T) {
+	sm := NewSourceManager()
+	sm.RegisterSource(stubSource{"RemoteOK"}, JobSourceConfig{Enabled: true})
+
+	snapshot := sm.GetSources()
+	delete(snapshot, "RemoteOK")
+	enabled := sm.GetEnabledSources()
+	enabled["Injected"] = stubSource{"Injected"}
+
+	if _, ok := sm.GetSources()["RemoteOK"]; !ok {
+		t.Error("deleting from a GetSources snapshot removed the source")
+	}
+	if _, ok := sm.GetSources()["Injected"]; ok {
+		t.Error("adding to a GetEnabledSources snapshot registered a source")
+	}
+}
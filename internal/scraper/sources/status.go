@@ -0,0 +1,34 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// StatusError is returned when a source answers with an unexpected HTTP status
+type StatusError struct {
+	Source     string // what answered, e.g. "RemoteOK API"
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.Source, e.StatusCode)
+}
+
+// IsRetryableError reports whether a failed fetch may succeed when retried:
+// network failures, timeouts, rate limiting and server errors. Other 4xx
+// statuses, such as 401 or 404, are permanent.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		status := statusErr.StatusCode
+		return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"job-scraper-go/pkg/httpclient"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"network", errors.New("connection reset"), true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"canceled", context.Canceled, false},
+		{"400", &StatusError{Source: "API", StatusCode: http.StatusBadRequest}, false},
+		{"401", &StatusError{Source: "API", StatusCode: http.StatusUnauthorized}, false},
+		{"404", &StatusError{Source: "API", StatusCode: http.StatusNotFound}, false},
+		{"408", &StatusError{Source: "API", StatusCode: http.StatusRequestTimeout}, true},
+		{"429", &StatusError{Source: "API", StatusCode: http.StatusTooManyRequests}, true},
+		{"500", &StatusError{Source: "API", StatusCode: http.StatusInternalServerError}, true},
+		{"503", &StatusError{Source: "API", StatusCode: http.StatusServiceUnavailable}, true},
+		{"wrapped 404", fmt.Errorf("fetching: %w", &StatusError{Source: "API", StatusCode: http.StatusNotFound}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoteOKReportsStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	source := NewRemoteOKSource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+
+	_, err := source.FetchJobs(context.Background())
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("FetchJobs error = %v, want a 404 StatusError", err)
+	}
+	if IsRetryableError(err) {
+		t.Errorf("IsRetryableError(%v) = true, want false", err)
+	}
+}
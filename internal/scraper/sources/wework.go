@@ -0,0 +1,190 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// weWorkRemotelyCategories maps category slugs to WeWorkRemotely category feed names
+var weWorkRemotelyCategories = map[string]string{
+	"programming":            "remote-programming-jobs",
+	"full-stack":             "remote-full-stack-programming-jobs",
+	"back-end":               "remote-back-end-programming-jobs",
+	"front-end":              "remote-front-end-programming-jobs",
+	"devops-sysadmin":        "remote-devops-sysadmin-jobs",
+	"design":                 "remote-design-jobs",
+	"customer-support":       "remote-customer-support-jobs",
+	"sales-and-marketing":    "remote-sales-and-marketing-jobs",
+	"management-and-finance": "remote-management-and-finance-jobs",
+	"product":                "remote-product-jobs",
+	"other":                  "all-other-remote-jobs",
+}
+
+// WeWorkRemotelySource implements JobSource for the WeWorkRemotely RSS feeds
+type WeWorkRemotelySource struct {
+	client      *httpclient.HttpClient
+	baseURL     string
+	searchTerms []string
+	searchMode  string
+	auth        http.Header // credentials sent with every request
+}
+
+func init() {
+	RegisterFactory("wework_remotely", func(client *httpclient.HttpClient) JobSource {
+		return NewWeWorkRemotelySource(client)
+	})
+	RegisterAlias("wework", "wework_remotely")
+}
+
+// NewWeWorkRemotelySource creates a new WeWorkRemotely source
+func NewWeWorkRemotelySource(client *httpclient.HttpClient) *WeWorkRemotelySource {
+	return &WeWorkRemotelySource{
+		client:  client,
+		baseURL: "https://weworkremotely.com",
+	}
+}
+
+// SetSearchTerms restricts FetchJobs to jobs matching the given terms.
+// With mode "all" every term must match, otherwise any term may match.
+func (w *WeWorkRemotelySource) SetSearchTerms(terms []string, mode string) {
+	w.searchTerms = terms
+	w.searchMode = mode
+}
+
+// SetAuth sets a header, such as "Authorization" or "X-Api-Key", sent with
+// every request to the source
+func (w *WeWorkRemotelySource) SetAuth(name, value string) {
+	w.auth = authHeader(name, value)
+}
+
+func (w *WeWorkRemotelySource) GetName() string {
+	return "WeWorkRemotely"
+}
+
+func (w *WeWorkRemotelySource) GetRateLimit() int {
+	return 30 // 30 requests per minute
+}
+
+func (w *WeWorkRemotelySource) SupportsSearch() bool {
+	return true
+}
+
+func (w *WeWorkRemotelySource) GetBaseURL() string {
+	return w.baseURL
+}
+
+// HealthCheck verifies that the WeWorkRemotely base URL is reachable
+func (w *WeWorkRemotelySource) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, w.client, w.baseURL, w.auth)
+}
+
+func (w *WeWorkRemotelySource) FetchJobs(ctx context.Context) ([]models.Job, error) {
+	jobs, err := w.fetchFeed(ctx, w.baseURL+"/remote-jobs.rss")
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterJobsBySearch(jobs, w.searchTerms, w.searchMode), nil
+}
+
+// FetchJobsByCategory fetches jobs from the feed of a category slug such as
+// "programming" or "devops-sysadmin"
+func (w *WeWorkRemotelySource) FetchJobsByCategory(ctx context.Context, category string) ([]models.Job, error) {
+	feed, ok := weWorkRemotelyCategories[strings.ToLower(category)]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for WeWorkRemotely (available categories: %s)",
+			ErrUnknownCategory, category, strings.Join(WeWorkRemotelyCategories(), ", "))
+	}
+
+	return w.fetchFeed(ctx, fmt.Sprintf("%s/categories/%s.rss", w.baseURL, feed))
+}
+
+// WeWorkRemotelyCategories returns the supported category slugs in sorted order
+func WeWorkRemotelyCategories() []string {
+	slugs := make([]string, 0, len(weWorkRemotelyCategories))
+	for slug := range weWorkRemotelyCategories {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// fetchFeed fetches and maps a WeWorkRemotely RSS feed
+func (w *WeWorkRemotelySource) fetchFeed(ctx context.Context, url string) ([]models.Job, error) {
+	resp, err := w.client.GetWithHeaders(ctx, url, w.auth)
+	if errors.Is(err, httpclient.ErrNotModified) {
+		return nil, nil // feed unchanged since the last fetch
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from WeWorkRemotely: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Source: "WeWorkRemotely", StatusCode: resp.StatusCode}
+	}
+
+	items, err := parseFeed(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse WeWorkRemotely feed: %w", err)
+	}
+
+	var jobs []models.Job
+	for _, item := range items {
+		job := w.toJob(item)
+		if job.Title == "" || job.URL == "" {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// toJob maps a WeWorkRemotely feed item onto a job. Item titles have the
+// form "Company: Title".
+func (w *WeWorkRemotelySource) toJob(item feedItem) models.Job {
+	title := item.first([]string{"title"})
+	company := ""
+	if index := strings.Index(title, ": "); index >= 0 {
+		company = strings.TrimSpace(title[:index])
+		title = strings.TrimSpace(title[index+2:])
+	}
+
+	location := item.first([]string{"region"})
+	if location == "" {
+		location = "Remote"
+	}
+
+	job := models.Job{
+		Title:       title,
+		Company:     company,
+		Location:    location,
+		URL:         item.first([]string{"link", "guid"}),
+		Description: cleanDescription(item.first([]string{"description"})),
+		PostedDate:  parseFeedDate(item.first([]string{"pubDate"})),
+		Source:      w.GetName(),
+		JobCategory: ClassifyCategory(title, nil, item.first([]string{"category"})),
+		JobType:     w.getJobType(item.first([]string{"type"})),
+		WorkMode:    classifyWorkMode(location, nil),
+	}
+	job.ExperienceLevel = inferExperienceLevel(job.Title)
+	job.Hash = models.JobHash(job)
+
+	return job
+}
+
+// getJobType maps WeWorkRemotely job types such as "Full-Time" to our standardized job types
+func (w *WeWorkRemotelySource) getJobType(jobType string) string {
+	normalized := strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(jobType)))
+	if models.ValidJobType(normalized) {
+		return normalized
+	}
+	return ""
+}
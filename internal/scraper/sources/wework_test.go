@@ -0,0 +1,131 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/models"
+	"job-scraper-go/pkg/httpclient"
+)
+
+// newTestWeWorkRemotely returns a WeWorkRemotely source reading the RSS
+// fixture from a test server, and a function returning the requested paths
+func newTestWeWorkRemotely(t *testing.T) (*WeWorkRemotelySource, func() []string) {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "wework.rss"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	source := NewWeWorkRemotelySource(httpclient.NewHttpClient(5 * time.Second))
+	source.baseURL = server.URL
+	return source, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func TestWeWorkRemotelyParsesFeed(t *testing.T) {
+	source, _ := newTestWeWorkRemotely(t)
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.Title != "Senior Go Developer" || first.Company != "Acme" || first.Location != "Anywhere in the World" {
+		t.Errorf("first job = %q at %q in %q, want Senior Go Developer at Acme in Anywhere in the World", first.Title, first.Company, first.Location)
+	}
+	if first.JobType != models.JobTypeFullTime || first.Description != "Build APIs in Go." || first.Source != "WeWorkRemotely" {
+		t.Errorf("first job type %q, description %q and source %q, want full-time, the text and WeWorkRemotely", first.JobType, first.Description, first.Source)
+	}
+	if jobs[1].JobType != models.JobTypeContract {
+		t.Errorf("second job type = %q, want contract", jobs[1].JobType)
+	}
+}
+
+func TestWeWorkRemotelyFetchJobsByCategory(t *testing.T) {
+	tests := []struct {
+		category string
+		wantPath string
+	}{
+		{"programming", "/categories/remote-programming-jobs.rss"},
+		{"devops-sysadmin", "/categories/remote-devops-sysadmin-jobs.rss"},
+		{"Design", "/categories/remote-design-jobs.rss"},
+		{"other", "/categories/all-other-remote-jobs.rss"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			source, paths := newTestWeWorkRemotely(t)
+
+			jobs, err := source.FetchJobsByCategory(context.Background(), tt.category)
+			if err != nil {
+				t.Fatalf("FetchJobsByCategory: %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Errorf("got %d jobs, want 2", len(jobs))
+			}
+			if got := paths(); len(got) != 1 || got[0] != tt.wantPath {
+				t.Errorf("requested %q, want %s", got, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestWeWorkRemotelyUnknownCategory(t *testing.T) {
+	source, paths := newTestWeWorkRemotely(t)
+
+	_, err := source.FetchJobsByCategory(context.Background(), "astronomy")
+	if !errors.Is(err, ErrUnknownCategory) {
+		t.Fatalf("FetchJobsByCategory = %v, want ErrUnknownCategory", err)
+	}
+	// The error lists the categories to choose from
+	if !strings.Contains(err.Error(), `"astronomy"`) || !strings.Contains(err.Error(), "devops-sysadmin, front-end") {
+		t.Errorf("error %q does not name the category and the available ones", err)
+	}
+	if got := paths(); len(got) != 0 {
+		t.Errorf("requested %q for an unknown category, want no requests", got)
+	}
+}
+
+func TestWeWorkRemotelyCategoriesSorted(t *testing.T) {
+	slugs := WeWorkRemotelyCategories()
+	if len(slugs) != len(weWorkRemotelyCategories) {
+		t.Fatalf("got %d categories, want %d", len(slugs), len(weWorkRemotelyCategories))
+	}
+	for i := 1; i < len(slugs); i++ {
+		if slugs[i-1] >= slugs[i] {
+			t.Errorf("categories %q are not sorted", slugs)
+			break
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package sources
+
+import (
+	"job-scraper-go/internal/models"
+	"strings"
+)
+
+// hybridHints and onsiteHints are location or tag phrases marking non-remote work
+var (
+	hybridHints = []string{"hybrid", "partially remote", "partly remote"}
+	onsiteHints = []string{"on-site", "onsite", "on site", "in-office", "in office", "office based", "office-based", "relocation required"}
+)
+
+// classifyWorkMode infers the work mode from location and tag hints. Jobs on
+// remote boards are remote unless the hints say otherwise; regional limits
+// such as "US only" still describe remote work.
+func classifyWorkMode(location string, tags []string) string {
+	text := strings.ToLower(location + " " + strings.Join(tags, " "))
+
+	for _, hint := range hybridHints {
+		if strings.Contains(text, hint) {
+			return models.WorkModeHybrid
+		}
+	}
+	for _, hint := range onsiteHints {
+		if strings.Contains(text, hint) {
+			return models.WorkModeOnsite
+		}
+	}
+	return models.WorkModeRemote
+}
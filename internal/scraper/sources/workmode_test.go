@@ -0,0 +1,95 @@
+package sources
+
+import (
+	"context"
+	"testing"
+
+	"job-scraper-go/internal/models"
+)
+
+func TestClassifyWorkMode(t *testing.T) {
+	tests := []struct {
+		location string
+		tags     []string
+		want     string
+	}{
+		{"Worldwide", nil, models.WorkModeRemote},
+		{"", nil, models.WorkModeRemote},
+		// Regional limits still describe remote work
+		{"US only", nil, models.WorkModeRemote},
+		{"Hybrid - Berlin", nil, models.WorkModeHybrid},
+		{"London (partially remote)", nil, models.WorkModeHybrid},
+		{"On-site in Paris", nil, models.WorkModeOnsite},
+		{"New York, office based", nil, models.WorkModeOnsite},
+		{"Europe", []string{"golang", "hybrid"}, models.WorkModeHybrid},
+		{"Remote", []string{"in-office"}, models.WorkModeOnsite},
+	}
+
+	for _, tt := range tests {
+		if got := classifyWorkMode(tt.location, tt.tags); got != tt.want {
+			t.Errorf("classifyWorkMode(%q, %q) = %q, want %q", tt.location, tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestIndeedWorkMode(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+	}{
+		// Indeed is not remote-only, so a plain location is on-site
+		{"Berlin", models.WorkModeOnsite},
+		{"Remote in Germany", models.WorkModeRemote},
+		{"Hybrid remote in Berlin", models.WorkModeHybrid},
+	}
+
+	for _, tt := range tests {
+		if got := indeedWorkMode(tt.location); got != tt.want {
+			t.Errorf("indeedWorkMode(%q) = %q, want %q", tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestRemotiveWorkMode(t *testing.T) {
+	source := newTestRemotive(t, `{"jobs": [
+		{"url": "https://remotive.com/1", "title": "Go Developer", "company_name": "Acme", "candidate_required_location": "USA Only"},
+		{"url": "https://remotive.com/2", "title": "Designer", "company_name": "Globex", "candidate_required_location": "Hybrid - Berlin"},
+		{"url": "https://remotive.com/3", "title": "Engineer", "company_name": "Initech", "candidate_required_location": "On-site, Paris"}
+	]}`)
+
+	jobs, err := source.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	want := []string{models.WorkModeRemote, models.WorkModeHybrid, models.WorkModeOnsite}
+	if len(jobs) != len(want) {
+		t.Fatalf("got %d jobs, want %d", len(jobs), len(want))
+	}
+	for i, job := range jobs {
+		if job.WorkMode != want[i] {
+			t.Errorf("%s work mode = %q, want %q", job.Title, job.WorkMode, want[i])
+		}
+	}
+}
+
+func TestRemoteOKWorkMode(t *testing.T) {
+	jobs, err := newTestRemoteOK(t, `[
+		{"legal": "API Terms of Service"},
+		{"id": "1", "company": "Acme", "position": "Go Developer", "location": "Worldwide",
+		 "url": "https://remoteok.com/remote-jobs/1", "date": "2024-05-01T10:00:00Z"},
+		{"id": "2", "company": "Globex", "position": "Designer", "tags": ["design", "hybrid"], "location": "Amsterdam",
+		 "url": "https://remoteok.com/remote-jobs/2", "date": "2024-05-02T10:00:00Z"}
+	]`).FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	want := []string{models.WorkModeRemote, models.WorkModeHybrid}
+	if len(jobs) != len(want) {
+		t.Fatalf("got %d jobs, want %d", len(jobs), len(want))
+	}
+	for i, job := range jobs {
+		if job.WorkMode != want[i] {
+			t.Errorf("%s work mode = %q, want %q", job.Title, job.WorkMode, want[i])
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ScrapeState persists the last successful scrape time of each source so
+// that later runs only process jobs posted since then
+type ScrapeState struct {
+	path       string
+	lastScrape map[string]time.Time
+	mu         sync.RWMutex
+}
+
+// LoadScrapeState loads the scrape state from a JSON file. A missing file
+// yields an empty state that is created on the first Save.
+func LoadScrapeState(path string) (*ScrapeState, error) {
+	state := &ScrapeState{
+		path:       path,
+		lastScrape: make(map[string]time.Time),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scrape state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state.lastScrape); err != nil {
+		return nil, fmt.Errorf("failed to parse scrape state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// LastScrapeTime returns the last successful scrape time of a source
+func (s *ScrapeState) LastScrapeTime(source string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lastScrape, ok := s.lastScrape[source]
+	return lastScrape, ok
+}
+
+// SetLastScrapeTime records the last successful scrape time of a source
+func (s *ScrapeState) SetLastScrapeTime(source string, t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastScrape[source] = t
+}
+
+// Save writes the scrape state to its file, replacing it atomically
+func (s *ScrapeState) Save() error {
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s.lastScrape, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode scrape state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write scrape state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write scrape state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write scrape state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write scrape state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package scraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/models"
+	"job-scraper-go/internal/storage"
+)
+
+func TestScrapeStateRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadScrapeState(path)
+	if err != nil {
+		t.Fatalf("LoadScrapeState of a missing file: %v", err)
+	}
+	if _, ok := state.LastScrapeTime("RemoteOK"); ok {
+		t.Error("a new state has a last scrape time")
+	}
+
+	want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	state.SetLastScrapeTime("RemoteOK", want)
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadScrapeState(path)
+	if err != nil {
+		t.Fatalf("LoadScrapeState: %v", err)
+	}
+	if got, ok := loaded.LastScrapeTime("RemoteOK"); !ok || !got.Equal(want) {
+		t.Errorf("LastScrapeTime = %v, %v; want %v", got, ok, want)
+	}
+}
+
+func TestLoadScrapeStateRejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScrapeState(path); err == nil {
+		t.Error("LoadScrapeState of an invalid file succeeded, want an error")
+	}
+}
+
+// datedJob returns a job from source posted at posted
+func datedJob(source, title string, posted time.Time) models.Job {
+	job := testJob(source, title, "Acme")
+	job.PostedDate = &posted
+	return job
+}
+
+// scrapeWithState runs a scrape of source by a new scraper using the scrape
+// state in path, and returns the titles of the jobs it processed
+func scrapeWithState(t *testing.T, path string, source *fakeSource) []string {
+	t.Helper()
+
+	state, err := LoadScrapeState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := newTestScraper(t, storage.NewMemoryStore(), source)
+	ps.SetScrapeState(state)
+
+	var processed []string
+	ps.OnJobsScraped(func(source string, jobs []models.Job) {
+		processed = append(processed, titles(jobs)...)
+	})
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+	return processed
+}
+
+func TestIncrementalScrapeProcessesOnlyNewJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{
+		datedJob("RemoteOK", "Go Developer", time.Now().Add(-time.Hour)),
+		testJob("RemoteOK", "Undated Role", "Globex"),
+	}}
+
+	if got, want := scrapeWithState(t, path, source), []string{"Go Developer", "Undated Role"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("first run processed %q, want %q", got, want)
+	}
+
+	// A job posted after the first run joins the feed
+	source.jobs = append(source.jobs, datedJob("RemoteOK", "Rust Developer", time.Now().Add(time.Minute)))
+
+	// Undated jobs are kept since their age is unknown
+	if got, want := scrapeWithState(t, path, source), []string{"Undated Role", "Rust Developer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second run processed %q, want %q", got, want)
+	}
+}
+
+func TestFailedSaveHoldsBackWatermark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	posted := time.Now().Add(-time.Hour)
+	source := &fakeSource{name: "RemoteOK", jobs: []models.Job{datedJob("RemoteOK", "Designer", posted)}}
+
+	state, err := LoadScrapeState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &rejectingStore{MemoryStore: storage.NewMemoryStore(), reject: map[string]bool{"Designer": true}}
+	ps := newTestScraper(t, store, source)
+	ps.SetScrapeState(state)
+	if _, err := ps.ScrapeAllSources(context.Background()); err != nil {
+		t.Fatalf("ScrapeAllSources: %v", err)
+	}
+
+	// The next run must fetch the unsaved job again
+	lastScrape, ok := state.LastScrapeTime("RemoteOK")
+	if !ok || !lastScrape.Before(posted) {
+		t.Errorf("last scrape time = %v, %v; want before the unsaved job posted at %v", lastScrape, ok, posted)
+	}
+}
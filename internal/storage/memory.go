@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"job-scraper-go/internal/models"
+)
+
+// MemoryStore keeps jobs in memory. It is useful for dry runs, tests, and
+// embedding the scraper without a database. Its operations do not block, so
+// the context is only checked before writes.
+type MemoryStore struct {
+	jobs   []models.Job
+	nextID int
+	mu     sync.RWMutex
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nextID: 1}
+}
+
+func (m *MemoryStore) SaveJob(ctx context.Context, job *models.Job) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.insert(job, time.Now())
+	return nil
+}
+
+func (m *MemoryStore) SaveJobs(ctx context.Context, jobs []models.Job) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for i := range jobs {
+		m.insert(&jobs[i], now)
+	}
+	return nil
+}
+
+func (m *MemoryStore) GetJobs(ctx context.Context) ([]models.Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]models.Job, len(m.jobs))
+	copy(jobs, m.jobs)
+	return jobs, nil
+}
+
+func (m *MemoryStore) GetJobsFiltered(ctx context.Context, filter JobFilter) ([]models.Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matched []models.Job
+	for _, job := range m.jobs {
+		if filter.Matches(job) {
+			matched = append(matched, job)
+		}
+	}
+
+	return paginate(matched, filter.Limit, filter.Offset), nil
+}
+
+func (m *MemoryStore) Count(ctx context.Context, filter JobFilter) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var count int64
+	for _, job := range m.jobs {
+		if filter.Matches(job) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MemoryStore) GetJobByURL(ctx context.Context, url string) (*models.Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, job := range m.jobs {
+		if job.URL == url {
+			found := job
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MemoryStore) StoredURLs(ctx context.Context, urls []string) (map[string]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		wanted[url] = true
+	}
+
+	stored := make(map[string]bool)
+	for _, job := range m.jobs {
+		if wanted[job.URL] {
+			stored[job.URL] = true
+		}
+	}
+	return stored, nil
+}
+
+func (m *MemoryStore) GetJobByID(ctx context.Context, id int) (*models.Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, job := range m.jobs {
+		if job.ID == id {
+			found := job
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MemoryStore) DeleteJobsOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.jobs[:0]
+	var deleted int64
+	for _, job := range m.jobs {
+		if jobAge(job).Before(cutoff) {
+			deleted++
+			continue
+		}
+		kept = append(kept, job)
+	}
+	m.jobs = kept
+	return deleted, nil
+}
+
+// SearchJobs returns jobs whose title or description contain every word of the
+// query, ignoring case. Jobs with more title matches rank first, then newer jobs.
+func (m *MemoryStore) SearchJobs(ctx context.Context, query string, limit int) ([]models.Job, error) {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	type match struct {
+		job   models.Job
+		score int
+	}
+	var matches []match
+	for _, job := range m.jobs {
+		title := strings.ToLower(job.Title)
+		description := strings.ToLower(job.Description)
+
+		score := 0
+		for _, word := range words {
+			inTitle := strings.Contains(title, word)
+			if !inTitle && !strings.Contains(description, word) {
+				score = -1
+				break
+			}
+			if inTitle {
+				score += 2
+			} else {
+				score++
+			}
+		}
+		if score >= 0 {
+			matches = append(matches, match{job: job, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return jobAge(matches[i].job).After(jobAge(matches[j].job))
+	})
+
+	jobs := make([]models.Job, len(matches))
+	for i, match := range matches {
+		jobs[i] = match.job
+	}
+	return paginate(jobs, limit, 0), nil
+}
+
+// CountSearch counts the jobs SearchJobs matches
+func (m *MemoryStore) CountSearch(ctx context.Context, query string) (int64, error) {
+	jobs, err := m.SearchJobs(ctx, query, 0)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(jobs)), nil
+}
+
+// insert assigns an ID and scraped_at timestamp and stores a copy of the job.
+// Callers must hold the write lock.
+func (m *MemoryStore) insert(job *models.Job, now time.Time) {
+	if job.ScrapedAt.IsZero() {
+		job.ScrapedAt = now
+	}
+	job.ID = m.nextID
+	m.nextID++
+	m.jobs = append(m.jobs, *job)
+}
+
+// paginate returns the page of jobs starting at offset, with at most limit jobs
+// when limit is positive
+func paginate(jobs []models.Job, limit, offset int) []models.Job {
+	if offset >= len(jobs) {
+		return nil
+	}
+	if offset > 0 {
+		jobs = jobs[offset:]
+	}
+	if limit > 0 && limit < len(jobs) {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"job-scraper-go/internal/models"
+)
+
+// newTestMemoryStore returns a memory store holding jobs
+func newTestMemoryStore(t *testing.T, jobs ...models.Job) *MemoryStore {
+	t.Helper()
+
+	store := NewMemoryStore()
+	if err := store.SaveJobs(context.Background(), jobs); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// sampleJobs returns jobs of two sources, categories and job types
+func sampleJobs() []models.Job {
+	return []models.Job{
+		{Title: "Go Developer", URL: "https://example.com/1", Source: "RemoteOK", JobCategory: "Backend Development", JobType: models.JobTypeFullTime},
+		{Title: "Designer", URL: "https://example.com/2", Source: "Remotive", JobCategory: "Design", JobType: models.JobTypeContract},
+		{Title: "Rust Developer", URL: "https://example.com/3", Source: "RemoteOK", JobCategory: "Backend Development", JobType: models.JobTypeContract},
+		{Title: "Data Intern", URL: "https://example.com/4", Source: "Remotive", JobCategory: "Data Science", JobType: models.JobTypeInternship},
+	}
+}
+
+func TestMemoryStoreCount(t *testing.T) {
+	store := newTestMemoryStore(t, sampleJobs()...)
+
+	tests := []struct {
+		name   string
+		filter JobFilter
+		want   int64
+	}{
+		{"all", JobFilter{}, 4},
+		{"source", JobFilter{Source: "RemoteOK"}, 2},
+		{"category", JobFilter{Category: "Design"}, 1},
+		{"job type", JobFilter{JobType: models.JobTypeContract}, 2},
+		{"combined", JobFilter{Source: "RemoteOK", JobType: models.JobTypeContract}, 1},
+		{"no match", JobFilter{Source: "Indeed"}, 0},
+		// Paging does not limit the count
+		{"ignores paging", JobFilter{Source: "RemoteOK", Limit: 1, Offset: 1}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Count(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Count(%+v) = %d, want %d", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreCountEmpty(t *testing.T) {
+	if got, err := NewMemoryStore().Count(context.Background(), JobFilter{}); err != nil || got != 0 {
+		t.Errorf("Count of an empty store = %d, %v; want 0", got, err)
+	}
+}
+
+func TestMemoryStoreGetJobByURL(t *testing.T) {
+	store := newTestMemoryStore(t, sampleJobs()...)
+
+	job, err := store.GetJobByURL(context.Background(), "https://example.com/2")
+	if err != nil {
+		t.Fatalf("GetJobByURL: %v", err)
+	}
+	if job == nil || job.Title != "Designer" {
+		t.Errorf("GetJobByURL = %+v, want the Designer job", job)
+	}
+
+	job, err = store.GetJobByURL(context.Background(), "https://example.com/missing")
+	if job != nil || err != nil {
+		t.Errorf("GetJobByURL of a missing URL = %+v, %v; want nil, nil", job, err)
+	}
+}
+
+func TestMemoryStoreGetJobByID(t *testing.T) {
+	store := newTestMemoryStore(t, sampleJobs()...)
+
+	job, err := store.GetJobByID(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetJobByID: %v", err)
+	}
+	if job == nil || job.ID != 2 || job.Title != "Designer" {
+		t.Errorf("GetJobByID = %+v, want the Designer job", job)
+	}
+
+	job, err = store.GetJobByID(context.Background(), 99)
+	if job != nil || err != nil {
+		t.Errorf("GetJobByID of a missing ID = %+v, %v; want nil, nil", job, err)
+	}
+}
+
+func TestMemoryStoreStoredURLs(t *testing.T) {
+	store := newTestMemoryStore(t, sampleJobs()...)
+
+	got, err := store.StoredURLs(context.Background(), []string{"https://example.com/1", "https://example.com/missing", "https://example.com/4"})
+	if err != nil {
+		t.Fatalf("StoredURLs: %v", err)
+	}
+	want := map[string]bool{"https://example.com/1": true, "https://example.com/4": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StoredURLs = %v, want %v", got, want)
+	}
+}
+
+func TestMemoryStoreDeleteJobsOlderThan(t *testing.T) {
+	now := time.Now()
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	old := now.Add(-60 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+
+	store := newTestMemoryStore(t,
+		models.Job{Title: "Old posting", PostedDate: &old, ScrapedAt: now},
+		models.Job{Title: "Recent posting", PostedDate: &recent, ScrapedAt: old},
+		models.Job{Title: "Old undated", ScrapedAt: old},
+		models.Job{Title: "Recent undated", ScrapedAt: recent},
+	)
+
+	deleted, err := store.DeleteJobsOlderThan(context.Background(), cutoff)
+	if err != nil {
+		t.Fatalf("DeleteJobsOlderThan: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted %d jobs, want 2", deleted)
+	}
+
+	jobs, err := store.GetJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, job := range jobs {
+		got = append(got, job.Title)
+	}
+	sort.Strings(got)
+	// The posted date decides, even when the job was scraped long ago
+	if want := []string{"Recent posting", "Recent undated"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("kept %q, want %q", got, want)
+	}
+}
+
+func TestMemoryStoreSearchJobs(t *testing.T) {
+	now := time.Now()
+	older := now.Add(-48 * time.Hour)
+	store := newTestMemoryStore(t,
+		models.Job{Title: "Platform Engineer", Description: "Run Kubernetes clusters on AWS", PostedDate: &now},
+		models.Job{Title: "Kubernetes Engineer", Description: "Operate clusters", PostedDate: &older},
+		models.Job{Title: "Go Developer", Description: "Build APIs, deploy to kubernetes", PostedDate: &older},
+		models.Job{Title: "Designer", Description: "Design in Figma", PostedDate: &now},
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		// Title matches rank first, then newer jobs
+		{"single word", "kubernetes", 0, []string{"Kubernetes Engineer", "Platform Engineer", "Go Developer"}},
+		{"ignores case", "KUBERNETES", 0, []string{"Kubernetes Engineer", "Platform Engineer", "Go Developer"}},
+		// Every word must match the title or description
+		{"multiple words", "kubernetes clusters", 0, []string{"Kubernetes Engineer", "Platform Engineer"}},
+		{"words across fields", "engineer aws", 0, []string{"Platform Engineer"}},
+		{"limit", "kubernetes", 2, []string{"Kubernetes Engineer", "Platform Engineer"}},
+		{"no match", "rust", 0, nil},
+		{"empty query", "  ", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := store.SearchJobs(context.Background(), tt.query, tt.limit)
+			if err != nil {
+				t.Fatalf("SearchJobs: %v", err)
+			}
+			var got []string
+			for _, job := range jobs {
+				got = append(got, job.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchJobs(%q, %d) = %q, want %q", tt.query, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSaveCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	store := NewMemoryStore()
+	job := models.Job{Title: "Go Developer"}
+	if err := store.SaveJob(ctx, &job); err != context.Canceled {
+		t.Errorf("SaveJob = %v, want context.Canceled", err)
+	}
+	if err := store.SaveJobs(ctx, []models.Job{job}); err != context.Canceled {
+		t.Errorf("SaveJobs = %v, want context.Canceled", err)
+	}
+	if count, _ := store.Count(context.Background(), JobFilter{}); count != 0 {
+		t.Errorf("stored %d jobs, want none", count)
+	}
+}
@@ -1,9 +1,57 @@
 package storage
 
-import "job-scraper-go/internal/models"
+import (
+	"context"
+	"time"
 
+	"job-scraper-go/internal/models"
+)
+
+// Store persists scraped jobs. Every method takes a context so that slow
+// database calls are cancelled along with the scrape or request that made them.
 type Store interface {
-	SaveJob(job *models.Job) error
-	SaveJobs(jobs []models.Job) error // Batch save for better performance
-	GetJobs() ([]models.Job, error)
+	SaveJob(ctx context.Context, job *models.Job) error
+	SaveJobs(ctx context.Context, jobs []models.Job) error // Batch save for better performance
+	GetJobs(ctx context.Context) ([]models.Job, error)
+	GetJobsFiltered(ctx context.Context, filter JobFilter) ([]models.Job, error)
+	Count(ctx context.Context, filter JobFilter) (int64, error)                    // Limit and Offset are ignored
+	GetJobByURL(ctx context.Context, url string) (*models.Job, error)              // returns nil, nil when not found
+	StoredURLs(ctx context.Context, urls []string) (map[string]bool, error)        // the given URLs that are already stored
+	GetJobByID(ctx context.Context, id int) (*models.Job, error)                   // returns nil, nil when not found
+	DeleteJobsOlderThan(ctx context.Context, cutoff time.Time) (int64, error)      // by posted date, or scraped_at when undated
+	SearchJobs(ctx context.Context, query string, limit int) ([]models.Job, error) // full-text over title and description, best match first
+	CountSearch(ctx context.Context, query string) (int64, error)                  // number of jobs SearchJobs matches
+}
+
+// JobFilter restricts which stored jobs are returned. Empty fields match all jobs
+// and a zero Limit returns every matching job.
+type JobFilter struct {
+	Source   string
+	Category string
+	JobType  string
+	Limit    int
+	Offset   int
+}
+
+// jobAge returns the time used to decide whether a job is stale: its posted
+// date, or the time it was scraped when the source gave no date
+func jobAge(job models.Job) time.Time {
+	if job.PostedDate != nil {
+		return *job.PostedDate
+	}
+	return job.ScrapedAt
+}
+
+// Matches reports whether a job satisfies the filter's field conditions
+func (f JobFilter) Matches(job models.Job) bool {
+	if f.Source != "" && job.Source != f.Source {
+		return false
+	}
+	if f.Category != "" && job.JobCategory != f.Category {
+		return false
+	}
+	if f.JobType != "" && job.JobType != f.JobType {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	postgrest "github.com/nedpals/supabase-go/postgrest/pkg"
+)
+
+// condition is a PostgREST column filter, e.g. {"source", "eq", "RemoteOK"}.
+// The value of an "in" filter is a list built with inList.
+type condition struct {
+	column   string
+	operator string
+	value    string
+}
+
+// selectQuery describes the rows to read from a table
+type selectQuery struct {
+	table      string
+	columns    string // comma-separated columns to return, all when empty
+	conditions []condition
+	orderBy    string // column sorted in descending order, if set
+	limit      int    // 0 returns every row
+	offset     int    // only applied together with limit
+}
+
+// database is the part of the Supabase SDK that SupabaseStore uses. It keeps
+// the store's query logic independent of the SDK's request builders, so it
+// can run against a fake.
+type database interface {
+	Insert(ctx context.Context, table string, rows interface{}) error
+	Select(ctx context.Context, query selectQuery, result interface{}) error
+	Count(ctx context.Context, table string, conditions []condition) (int64, error)
+	Delete(ctx context.Context, table string, conditions []condition) error
+	Rpc(ctx context.Context, name string, params map[string]interface{}, result interface{}) error
+}
+
+// postgrestDB implements database with the SDK's PostgREST client
+type postgrestDB struct {
+	client *postgrest.Client
+}
+
+func (db postgrestDB) Insert(ctx context.Context, table string, rows interface{}) error {
+	return db.client.From(table).Insert(rows).ExecuteWithContext(ctx, nil)
+}
+
+func (db postgrestDB) Select(ctx context.Context, query selectQuery, result interface{}) error {
+	columns := query.columns
+	if columns == "" {
+		columns = "*"
+	}
+	request := db.client.From(query.table).Select(columns)
+	applyConditions(&request.FilterRequestBuilder, query.conditions)
+
+	if query.orderBy != "" {
+		request.OrderBy(query.orderBy, "desc")
+	}
+	if query.limit > 0 {
+		request.LimitWithOffset(query.limit, query.offset)
+	}
+	return request.ExecuteWithContext(ctx, result)
+}
+
+// Count uses a COUNT(*) request, without loading any rows
+func (db postgrestDB) Count(ctx context.Context, table string, conditions []condition) (int64, error) {
+	request := db.client.From(table).Select("*").Count()
+	applyConditions(&request.FilterRequestBuilder, conditions)
+
+	var count int64
+	if err := request.ExecuteWithContext(ctx, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (db postgrestDB) Delete(ctx context.Context, table string, conditions []condition) error {
+	request := db.client.From(table).Delete()
+	applyConditions(request, conditions)
+	return request.ExecuteWithContext(ctx, nil)
+}
+
+func (db postgrestDB) Rpc(ctx context.Context, name string, params map[string]interface{}, result interface{}) error {
+	return db.client.Rpc(name, params).ExecuteWithContext(ctx, result)
+}
+
+// applyConditions adds column filters to a request
+func applyConditions(request *postgrest.FilterRequestBuilder, conditions []condition) {
+	for _, c := range conditions {
+		value := c.value
+		if c.operator != "in" {
+			value = quoteFilterValue(value)
+		}
+		request.Filter(c.column, c.operator, encodeFilterValue(value))
+	}
+}
+
+// quoteFilterValue double-quotes a filter value containing characters that
+// PostgREST reserves, such as the ":" and "." of a URL
+func quoteFilterValue(value string) string {
+	if !strings.ContainsAny(value, `,.:()"\`) {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// inList builds the value of an "in" filter matching any of the values
+func inList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quoteFilterValue(value)
+	}
+	return "(" + strings.Join(quoted, ",") + ")"
+}
+
+// encodeFilterValue percent-encodes a filter value. The SDK unescapes the
+// query string it builds, so without this "&", "#" or "+" in a value, as in
+// many job URLs, would break up or change the query.
+func encodeFilterValue(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
@@ -0,0 +1,49 @@
+package storage
+
+import "testing"
+
+func TestQuoteFilterValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"RemoteOK", "RemoteOK"},
+		{"Backend Development", "Backend Development"},
+		{"https://example.com/jobs/1", `"https://example.com/jobs/1"`},
+		{"Acme, Inc.", `"Acme, Inc."`},
+		{"(remote)", `"(remote)"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\path`, `"C:\\path"`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteFilterValue(tt.value); got != tt.want {
+			t.Errorf("quoteFilterValue(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestInList(t *testing.T) {
+	got := inList([]string{"RemoteOK", "https://example.com/a,b"})
+	if want := `(RemoteOK,"https://example.com/a,b")`; got != want {
+		t.Errorf("inList = %s, want %s", got, want)
+	}
+}
+
+func TestEncodeFilterValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"RemoteOK", "RemoteOK"},
+		{"Backend Development", "Backend%20Development"},
+		{`"https://example.com/?a=1&b=2"`, "%22https%3A%2F%2Fexample.com%2F%3Fa%3D1%26b%3D2%22"},
+		{"C++ & Go", "C%2B%2B%20%26%20Go"},
+	}
+
+	for _, tt := range tests {
+		if got := encodeFilterValue(tt.value); got != tt.want {
+			t.Errorf("encodeFilterValue(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -1,23 +1,37 @@
 package storage
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	supabase "github.com/nedpals/supabase-go"
+	postgrest "github.com/nedpals/supabase-go/postgrest/pkg"
 
 	"job-scraper-go/internal/models"
 )
 
+// DefaultTable is the table jobs are stored in unless configured otherwise
+const DefaultTable = "jobs"
+
+// ErrSearchUnsupported is returned by SearchJobs when the store cannot search
+// its table
+var ErrSearchUnsupported = errors.New("search is not supported for this table")
+
 // SupabaseStore uses the nedpals/supabase-go SDK to persist jobs.
 type SupabaseStore struct {
-	client *supabase.Client
+	db    database
+	table string
 }
 
-// NewSupabaseStore creates a SupabaseStore. It reads SUPABASE_URL and SUPABASE_KEY
+// NewSupabaseStore creates a SupabaseStore that keeps jobs in the given table,
+// or DefaultTable when it is empty. It reads SUPABASE_URL and SUPABASE_KEY
 // from environment variables if empty values are provided.
-func NewSupabaseStore(supabaseURL, supabaseKey string) (*SupabaseStore, error) {
+func NewSupabaseStore(supabaseURL, supabaseKey, table string) (*SupabaseStore, error) {
 	if supabaseURL == "" {
 		supabaseURL = os.Getenv("SUPABASE_URL")
 	}
@@ -30,33 +44,229 @@ func NewSupabaseStore(supabaseURL, supabaseKey string) (*SupabaseStore, error) {
 
 	// CreateClient returns *supabase.Client (no error)
 	client := supabase.CreateClient(supabaseURL, supabaseKey)
-	return &SupabaseStore{client: client}, nil
+	if table == "" {
+		table = DefaultTable
+	}
+	return &SupabaseStore{db: postgrestDB{client: client.DB}, table: table}, nil
 }
 
-func (s *SupabaseStore) SaveJob(job *models.Job) error {
+func (s *SupabaseStore) SaveJob(ctx context.Context, job *models.Job) error {
 	// Set scraped_at timestamp if not already set
 	if job.ScrapedAt.IsZero() {
 		job.ScrapedAt = time.Now()
 	}
 
-	// Use the SDK's DB wrapper: client.DB.From(...).Insert(...).Execute(&results)
-	var results []models.Job
 	// Insert expects a value (not pointer) in examples
-	err := s.client.DB.From("jobs").Insert(*job).Execute(&results)
-	return err
+	return s.db.Insert(ctx, s.table, *job)
+}
+
+func (s *SupabaseStore) GetJobs(ctx context.Context) ([]models.Job, error) {
+	var res []models.Job
+	if err := s.db.Select(ctx, selectQuery{table: s.table}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetJobsFiltered returns stored jobs matching the filter, newest first
+func (s *SupabaseStore) GetJobsFiltered(ctx context.Context, filter JobFilter) ([]models.Job, error) {
+	query := selectQuery{
+		table:      s.table,
+		conditions: filterConditions(filter),
+		orderBy:    "scraped_at",
+		limit:      filter.Limit,
+		offset:     filter.Offset,
+	}
+
+	var res []models.Job
+	if err := s.db.Select(ctx, query, &res); err != nil {
+		return nil, err
+	}
+
+	// Without a limit the Range header cannot express an offset, so skip rows locally
+	if filter.Limit <= 0 {
+		res = paginate(res, 0, filter.Offset)
+	}
+	return res, nil
+}
+
+// Count returns the number of stored jobs matching the filter using a
+// COUNT(*) request, without loading any rows
+func (s *SupabaseStore) Count(ctx context.Context, filter JobFilter) (int64, error) {
+	return s.db.Count(ctx, s.table, filterConditions(filter))
+}
+
+// GetJobByURL returns the stored job with the given URL, or nil when there is none
+func (s *SupabaseStore) GetJobByURL(ctx context.Context, url string) (*models.Job, error) {
+	query := selectQuery{
+		table:      s.table,
+		conditions: []condition{{"url", "eq", url}},
+		limit:      1,
+	}
+
+	var res []models.Job
+	if err := s.db.Select(ctx, query, &res); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return &res[0], nil
+}
+
+// StoredURLs returns which of the URLs belong to stored jobs, reading only
+// the url column of the matching rows
+func (s *SupabaseStore) StoredURLs(ctx context.Context, urls []string) (map[string]bool, error) {
+	stored := make(map[string]bool)
+	if len(urls) == 0 {
+		return stored, nil
+	}
+
+	query := selectQuery{
+		table:      s.table,
+		columns:    "url",
+		conditions: []condition{{"url", "in", inList(urls)}},
+	}
+
+	var res []struct {
+		URL string `json:"url"`
+	}
+	if err := s.db.Select(ctx, query, &res); err != nil {
+		return nil, err
+	}
+	for _, row := range res {
+		stored[row.URL] = true
+	}
+	return stored, nil
+}
+
+func (s *SupabaseStore) GetJobByID(ctx context.Context, id int) (*models.Job, error) {
+	query := selectQuery{
+		table:      s.table,
+		conditions: []condition{{"id", "eq", strconv.Itoa(id)}},
+		limit:      1,
+	}
+
+	var res []models.Job
+	if err := s.db.Select(ctx, query, &res); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return &res[0], nil
 }
 
-func (s *SupabaseStore) GetJobs() ([]models.Job, error) {
+// SearchJobs returns jobs whose title or description match every word of the
+// query, best match first, using the search_jobs function from schema.sql. It
+// ranks with Postgres full-text search (to_tsvector/plainto_tsquery), weighting
+// title matches above description matches. A zero limit returns every match.
+// The function only reads the jobs table, so stores configured with another
+// table return ErrSearchUnsupported.
+func (s *SupabaseStore) SearchJobs(ctx context.Context, query string, limit int) ([]models.Job, error) {
+	if s.table != DefaultTable {
+		return nil, fmt.Errorf("%w: search_jobs reads the %s table, not %s", ErrSearchUnsupported, DefaultTable, s.table)
+	}
+
 	var res []models.Job
-	err := s.client.DB.From("jobs").Select("*").Execute(&res)
+	err := s.db.Rpc(ctx, "search_jobs", map[string]interface{}{
+		"search_query": query,
+		"max_results":  limit,
+	}, &res)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// CountSearch counts the jobs matching a SearchJobs query with the
+// count_search_jobs function from schema.sql, without loading them
+func (s *SupabaseStore) CountSearch(ctx context.Context, query string) (int64, error) {
+	if s.table != DefaultTable {
+		return 0, fmt.Errorf("%w: count_search_jobs reads the %s table, not %s", ErrSearchUnsupported, DefaultTable, s.table)
+	}
+
+	var count int64
+	err := s.db.Rpc(ctx, "count_search_jobs", map[string]interface{}{
+		"search_query": query,
+	}, &count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteJobsOlderThan deletes jobs posted before the cutoff, using scraped_at
+// for jobs without a posted date, and returns how many were deleted. Stale rows
+// are counted first since deletes do not report the affected rows.
+func (s *SupabaseStore) DeleteJobsOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	value := cutoff.UTC().Format(time.RFC3339)
+	var deleted int64
+
+	// Dated jobs posted before the cutoff
+	dated, err := s.countAndDelete(ctx, []condition{{"posted_date", "lt", value}})
+	if err != nil {
+		return 0, err
+	}
+	deleted += dated
+
+	// Undated jobs scraped before the cutoff
+	undated, err := s.countAndDelete(ctx, []condition{{"posted_date", "is", "null"}, {"scraped_at", "lt", value}})
+	if err != nil {
+		return deleted, err
+	}
+	return deleted + undated, nil
+}
+
+// countAndDelete counts the rows matching the conditions, then deletes them
+func (s *SupabaseStore) countAndDelete(ctx context.Context, conditions []condition) (int64, error) {
+	count, err := s.db.Count(ctx, s.table, conditions)
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	if err := s.db.Delete(ctx, s.table, conditions); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// IsTransientError reports whether a failed storage call may succeed when
+// retried: network failures, timeouts, rate limiting and server errors.
+// Rejected requests such as constraint violations are permanent.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var requestErr *postgrest.RequestError
+	if errors.As(err, &requestErr) {
+		status := requestErr.HTTPStatusCode
+		return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+	}
+	return true
+}
+
+// filterConditions converts the filter's field conditions to column filters
+func filterConditions(filter JobFilter) []condition {
+	var conditions []condition
+	if filter.Source != "" {
+		conditions = append(conditions, condition{"source", "eq", filter.Source})
+	}
+	if filter.Category != "" {
+		conditions = append(conditions, condition{"job_category", "eq", filter.Category})
+	}
+	if filter.JobType != "" {
+		conditions = append(conditions, condition{"job_type", "eq", filter.JobType})
+	}
+	return conditions
+}
+
 // SaveJobs saves multiple jobs in a single batch operation for better performance
-func (s *SupabaseStore) SaveJobs(jobs []models.Job) error {
+func (s *SupabaseStore) SaveJobs(ctx context.Context, jobs []models.Job) error {
 	if len(jobs) == 0 {
 		return nil
 	}
@@ -70,7 +280,5 @@ func (s *SupabaseStore) SaveJobs(jobs []models.Job) error {
 	}
 
 	// Use batch insert
-	var results []models.Job
-	err := s.client.DB.From("jobs").Insert(jobs).Execute(&results)
-	return err
+	return s.db.Insert(ctx, s.table, jobs)
 }
@@ -0,0 +1,470 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	postgrest "github.com/nedpals/supabase-go/postgrest/pkg"
+
+	"job-scraper-go/internal/models"
+)
+
+// rpcCall is a database function call made through fakeDB
+type rpcCall struct {
+	name   string
+	params map[string]interface{}
+}
+
+// fakeDB is a database returning canned results and recording the requests it gets
+type fakeDB struct {
+	rows      []models.Job // returned by Select
+	count     int64        // returned by Count
+	rpcResult interface{}  // returned by Rpc
+	err       error
+
+	tables  []string // of every request but Rpc
+	inserts []interface{}
+	selects []selectQuery
+	counts  [][]condition
+	deletes [][]condition
+	rpcs    []rpcCall
+}
+
+func (db *fakeDB) Insert(ctx context.Context, table string, rows interface{}) error {
+	db.tables = append(db.tables, table)
+	db.inserts = append(db.inserts, rows)
+	return db.err
+}
+
+func (db *fakeDB) Select(ctx context.Context, query selectQuery, result interface{}) error {
+	db.tables = append(db.tables, query.table)
+	db.selects = append(db.selects, query)
+	if db.err != nil {
+		return db.err
+	}
+	return decodeInto(db.rows, result)
+}
+
+func (db *fakeDB) Count(ctx context.Context, table string, conditions []condition) (int64, error) {
+	db.tables = append(db.tables, table)
+	db.counts = append(db.counts, conditions)
+	return db.count, db.err
+}
+
+func (db *fakeDB) Delete(ctx context.Context, table string, conditions []condition) error {
+	db.tables = append(db.tables, table)
+	db.deletes = append(db.deletes, conditions)
+	return db.err
+}
+
+func (db *fakeDB) Rpc(ctx context.Context, name string, params map[string]interface{}, result interface{}) error {
+	db.rpcs = append(db.rpcs, rpcCall{name: name, params: params})
+	if db.err != nil {
+		return db.err
+	}
+	return decodeInto(db.rpcResult, result)
+}
+
+// decodeInto copies value into result through JSON, as the SDK decodes responses
+func decodeInto(value, result interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// newFakeStore returns a SupabaseStore over db using the given table
+func newFakeStore(db *fakeDB, table string) *SupabaseStore {
+	return &SupabaseStore{db: db, table: table}
+}
+
+func TestSupabaseStoreCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter JobFilter
+		want   []condition
+	}{
+		{"all", JobFilter{}, nil},
+		{"source", JobFilter{Source: "RemoteOK"}, []condition{{"source", "eq", "RemoteOK"}}},
+		{"every field", JobFilter{Source: "RemoteOK", Category: "Design", JobType: "contract", Limit: 5},
+			[]condition{{"source", "eq", "RemoteOK"}, {"job_category", "eq", "Design"}, {"job_type", "eq", "contract"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{count: 42}
+			got, err := newFakeStore(db, DefaultTable).Count(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			if got != 42 {
+				t.Errorf("Count = %d, want the database's count 42", got)
+			}
+			if len(db.counts) != 1 || !reflect.DeepEqual(db.counts[0], tt.want) {
+				t.Errorf("counted with conditions %v, want %v", db.counts, tt.want)
+			}
+			if len(db.selects) != 0 {
+				t.Error("Count loaded rows, want a COUNT request only")
+			}
+		})
+	}
+}
+
+func TestSupabaseStoreGetJobByURL(t *testing.T) {
+	const url = "https://example.com/jobs/1"
+
+	db := &fakeDB{rows: []models.Job{{Title: "Go Developer", URL: url}}}
+	job, err := newFakeStore(db, DefaultTable).GetJobByURL(context.Background(), url)
+	if err != nil {
+		t.Fatalf("GetJobByURL: %v", err)
+	}
+	if job == nil || job.Title != "Go Developer" {
+		t.Errorf("GetJobByURL = %+v, want the Go Developer job", job)
+	}
+	want := selectQuery{table: DefaultTable, conditions: []condition{{"url", "eq", url}}, limit: 1}
+	if len(db.selects) != 1 || !reflect.DeepEqual(db.selects[0], want) {
+		t.Errorf("selected %+v, want %+v", db.selects, want)
+	}
+
+	job, err = newFakeStore(&fakeDB{}, DefaultTable).GetJobByURL(context.Background(), url)
+	if job != nil || err != nil {
+		t.Errorf("GetJobByURL without rows = %+v, %v; want nil, nil", job, err)
+	}
+}
+
+func TestSupabaseStoreGetJobByID(t *testing.T) {
+	db := &fakeDB{rows: []models.Job{{ID: 7, Title: "Go Developer"}}}
+	job, err := newFakeStore(db, DefaultTable).GetJobByID(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetJobByID: %v", err)
+	}
+	if job == nil || job.ID != 7 {
+		t.Errorf("GetJobByID = %+v, want job 7", job)
+	}
+	want := selectQuery{table: DefaultTable, conditions: []condition{{"id", "eq", "7"}}, limit: 1}
+	if len(db.selects) != 1 || !reflect.DeepEqual(db.selects[0], want) {
+		t.Errorf("selected %+v, want %+v", db.selects, want)
+	}
+
+	job, err = newFakeStore(&fakeDB{}, DefaultTable).GetJobByID(context.Background(), 7)
+	if job != nil || err != nil {
+		t.Errorf("GetJobByID without rows = %+v, %v; want nil, nil", job, err)
+	}
+}
+
+func TestSupabaseStoreStoredURLs(t *testing.T) {
+	urls := []string{"https://example.com/1", "https://example.com/2"}
+
+	db := &fakeDB{rows: []models.Job{{URL: "https://example.com/2"}}}
+	got, err := newFakeStore(db, "jobs_archive").StoredURLs(context.Background(), urls)
+	if err != nil {
+		t.Fatalf("StoredURLs: %v", err)
+	}
+	if want := map[string]bool{"https://example.com/2": true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StoredURLs = %v, want %v", got, want)
+	}
+	want := selectQuery{
+		table:      "jobs_archive",
+		columns:    "url",
+		conditions: []condition{{"url", "in", `("https://example.com/1","https://example.com/2")`}},
+	}
+	if len(db.selects) != 1 || !reflect.DeepEqual(db.selects[0], want) {
+		t.Errorf("selected %+v, want %+v", db.selects, want)
+	}
+}
+
+func TestSupabaseStoreStoredURLsWithoutURLs(t *testing.T) {
+	db := &fakeDB{}
+	got, err := newFakeStore(db, DefaultTable).StoredURLs(context.Background(), nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("StoredURLs(nil) = %v, %v; want an empty map", got, err)
+	}
+	if len(db.selects) != 0 {
+		t.Errorf("StoredURLs(nil) made %d requests, want none", len(db.selects))
+	}
+}
+
+func TestSupabaseStoreDeleteJobsOlderThan(t *testing.T) {
+	cutoff := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	db := &fakeDB{count: 3}
+
+	deleted, err := newFakeStore(db, DefaultTable).DeleteJobsOlderThan(context.Background(), cutoff)
+	if err != nil {
+		t.Fatalf("DeleteJobsOlderThan: %v", err)
+	}
+	// Both the dated and the undated delete report the fake's count
+	if deleted != 6 {
+		t.Errorf("deleted %d jobs, want 6", deleted)
+	}
+
+	want := [][]condition{
+		{{"posted_date", "lt", "2024-03-01T11:00:00Z"}},
+		{{"posted_date", "is", "null"}, {"scraped_at", "lt", "2024-03-01T11:00:00Z"}},
+	}
+	if !reflect.DeepEqual(db.counts, want) {
+		t.Errorf("counted %v, want %v", db.counts, want)
+	}
+	if !reflect.DeepEqual(db.deletes, want) {
+		t.Errorf("deleted %v, want %v", db.deletes, want)
+	}
+}
+
+func TestSupabaseStoreDeleteJobsOlderThanSkipsEmptyDeletes(t *testing.T) {
+	db := &fakeDB{}
+	deleted, err := newFakeStore(db, DefaultTable).DeleteJobsOlderThan(context.Background(), time.Now())
+	if err != nil || deleted != 0 {
+		t.Errorf("DeleteJobsOlderThan = %d, %v; want 0, nil", deleted, err)
+	}
+	if len(db.deletes) != 0 {
+		t.Errorf("sent %d deletes with nothing to delete, want none", len(db.deletes))
+	}
+}
+
+func TestSupabaseStoreSearchJobs(t *testing.T) {
+	db := &fakeDB{rpcResult: []models.Job{{Title: "Kubernetes Engineer"}, {Title: "Platform Engineer"}}}
+
+	jobs, err := newFakeStore(db, DefaultTable).SearchJobs(context.Background(), "kubernetes clusters", 10)
+	if err != nil {
+		t.Fatalf("SearchJobs: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Title != "Kubernetes Engineer" {
+		t.Errorf("SearchJobs = %+v, want the ranked results of search_jobs", jobs)
+	}
+	want := []rpcCall{{"search_jobs", map[string]interface{}{"search_query": "kubernetes clusters", "max_results": 10}}}
+	if !reflect.DeepEqual(db.rpcs, want) {
+		t.Errorf("called %+v, want %+v", db.rpcs, want)
+	}
+}
+
+func TestSupabaseStoreSaveJobsCancelled(t *testing.T) {
+	// The server never answers, so only the context ends the request. The
+	// body is read first, so that the server notices the client hanging up.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	store, err := NewSupabaseStore(server.URL, "key", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = store.SaveJobs(ctx, []models.Job{{Title: "Go Developer", URL: "https://example.com/1"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SaveJobs = %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("SaveJobs returned after %v, want promptly after the context ended", elapsed)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"cancelled", context.Canceled, false},
+		{"network", errors.New("connection refused"), true},
+		{"timeout", &postgrest.RequestError{HTTPStatusCode: http.StatusRequestTimeout}, true},
+		{"rate limited", &postgrest.RequestError{HTTPStatusCode: http.StatusTooManyRequests}, true},
+		{"server error", fmt.Errorf("insert: %w", &postgrest.RequestError{HTTPStatusCode: http.StatusBadGateway}), true},
+		{"conflict", &postgrest.RequestError{HTTPStatusCode: http.StatusConflict}, false},
+		{"bad request", &postgrest.RequestError{HTTPStatusCode: http.StatusBadRequest}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTransientError(tt.err); got != tt.want {
+			t.Errorf("IsTransientError(%s) = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSupabaseStoreUsesConfiguredTable(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeDB{count: 1}
+	store := newFakeStore(db, "jobs_staging")
+
+	job := models.Job{Title: "Go Developer", URL: "https://example.com/1"}
+	calls := []func() error{
+		func() error { return store.SaveJob(ctx, &job) },
+		func() error { return store.SaveJobs(ctx, []models.Job{job}) },
+		func() error { _, err := store.GetJobs(ctx); return err },
+		func() error { _, err := store.GetJobsFiltered(ctx, JobFilter{Source: "RemoteOK"}); return err },
+		func() error { _, err := store.Count(ctx, JobFilter{}); return err },
+		func() error { _, err := store.GetJobByURL(ctx, job.URL); return err },
+		func() error { _, err := store.GetJobByID(ctx, 1); return err },
+		func() error { _, err := store.StoredURLs(ctx, []string{job.URL}); return err },
+		func() error { _, err := store.DeleteJobsOlderThan(ctx, time.Now()); return err },
+	}
+	for i, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+
+	if len(db.tables) == 0 {
+		t.Fatal("no requests were made")
+	}
+	for _, table := range db.tables {
+		if table != "jobs_staging" {
+			t.Errorf("requests went to tables %q, want only jobs_staging", db.tables)
+			break
+		}
+	}
+}
+
+func TestSupabaseStoreQueriesConfiguredTable(t *testing.T) {
+	tests := []struct {
+		table    string
+		wantPath string
+	}{
+		{"", "/rest/v1/" + DefaultTable},
+		{"jobs_staging", "/rest/v1/jobs_staging"},
+	}
+
+	for _, tt := range tests {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("[]"))
+		}))
+
+		store, err := NewSupabaseStore(server.URL, "key", tt.table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.GetJobs(context.Background()); err != nil {
+			t.Errorf("GetJobs: %v", err)
+		}
+		server.Close()
+
+		if path != tt.wantPath {
+			t.Errorf("table %q queried %s, want %s", tt.table, path, tt.wantPath)
+		}
+	}
+}
+
+func TestSupabaseStoreSearchUnsupportedForOtherTables(t *testing.T) {
+	db := &fakeDB{}
+	store := newFakeStore(db, "jobs_staging")
+
+	if _, err := store.SearchJobs(context.Background(), "golang", 10); !errors.Is(err, ErrSearchUnsupported) {
+		t.Errorf("SearchJobs = %v, want ErrSearchUnsupported", err)
+	}
+	if _, err := store.CountSearch(context.Background(), "golang"); !errors.Is(err, ErrSearchUnsupported) {
+		t.Errorf("CountSearch = %v, want ErrSearchUnsupported", err)
+	}
+	if len(db.rpcs) != 0 {
+		t.Errorf("called %+v, want no database functions", db.rpcs)
+	}
+}
+
+func TestSupabaseStoreSaveJobs(t *testing.T) {
+	db := &fakeDB{}
+	scraped := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Title: "Go Developer", URL: "https://example.com/1"},
+		{Title: "Designer", URL: "https://example.com/2", ScrapedAt: scraped},
+	}
+
+	if err := newFakeStore(db, DefaultTable).SaveJobs(context.Background(), jobs); err != nil {
+		t.Fatalf("SaveJobs: %v", err)
+	}
+	// The jobs are inserted in a single batch
+	if len(db.inserts) != 1 {
+		t.Fatalf("made %d inserts, want 1", len(db.inserts))
+	}
+	inserted, ok := db.inserts[0].([]models.Job)
+	if !ok || len(inserted) != 2 {
+		t.Fatalf("inserted %#v, want both jobs", db.inserts[0])
+	}
+	if inserted[0].ScrapedAt.IsZero() || !inserted[1].ScrapedAt.Equal(scraped) {
+		t.Errorf("scraped at %v and %v, want now and the existing time %v", inserted[0].ScrapedAt, inserted[1].ScrapedAt, scraped)
+	}
+}
+
+func TestSupabaseStoreSaveJobsWithoutJobs(t *testing.T) {
+	db := &fakeDB{}
+	if err := newFakeStore(db, DefaultTable).SaveJobs(context.Background(), nil); err != nil {
+		t.Fatalf("SaveJobs: %v", err)
+	}
+	if len(db.inserts) != 0 {
+		t.Errorf("made %d inserts, want none", len(db.inserts))
+	}
+}
+
+func TestSupabaseStoreGetJobs(t *testing.T) {
+	db := &fakeDB{rows: []models.Job{{ID: 1, Title: "Go Developer"}, {ID: 2, Title: "Designer"}}}
+
+	jobs, err := newFakeStore(db, DefaultTable).GetJobs(context.Background())
+	if err != nil {
+		t.Fatalf("GetJobs: %v", err)
+	}
+	if !reflect.DeepEqual(jobs, db.rows) {
+		t.Errorf("GetJobs = %+v, want %+v", jobs, db.rows)
+	}
+	if want := []selectQuery{{table: DefaultTable}}; !reflect.DeepEqual(db.selects, want) {
+		t.Errorf("selected %+v, want %+v", db.selects, want)
+	}
+}
+
+func TestSupabaseStoreGetJobsFiltered(t *testing.T) {
+	db := &fakeDB{}
+	filter := JobFilter{Source: "RemoteOK", Limit: 20, Offset: 40}
+
+	if _, err := newFakeStore(db, DefaultTable).GetJobsFiltered(context.Background(), filter); err != nil {
+		t.Fatalf("GetJobsFiltered: %v", err)
+	}
+	want := selectQuery{
+		table:      DefaultTable,
+		conditions: []condition{{"source", "eq", "RemoteOK"}},
+		orderBy:    "scraped_at",
+		limit:      20,
+		offset:     40,
+	}
+	if len(db.selects) != 1 || !reflect.DeepEqual(db.selects[0], want) {
+		t.Errorf("selected %+v, want %+v", db.selects, want)
+	}
+}
+
+func TestSupabaseStorePropagatesErrors(t *testing.T) {
+	errDown := errors.New("database down")
+	store := newFakeStore(&fakeDB{err: errDown}, DefaultTable)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"SaveJob", func() error { return store.SaveJob(ctx, &models.Job{Title: "Go Developer"}) }},
+		{"SaveJobs", func() error { return store.SaveJobs(ctx, []models.Job{{Title: "Go Developer"}}) }},
+		{"GetJobs", func() error { _, err := store.GetJobs(ctx); return err }},
+		{"GetJobsFiltered", func() error { _, err := store.GetJobsFiltered(ctx, JobFilter{}); return err }},
+		{"Count", func() error { _, err := store.Count(ctx, JobFilter{}); return err }},
+		{"GetJobByURL", func() error { _, err := store.GetJobByURL(ctx, "https://example.com/1"); return err }},
+		{"SearchJobs", func() error { _, err := store.SearchJobs(ctx, "golang", 0); return err }},
+		{"DeleteJobsOlderThan", func() error { _, err := store.DeleteJobsOlderThan(ctx, time.Now()); return err }},
+	}
+
+	for _, tt := range tests {
+		if err := tt.call(); !errors.Is(err, errDown) {
+			t.Errorf("%s = %v, want the database error", tt.name, err)
+		}
+	}
+}
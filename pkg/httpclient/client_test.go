@@ -0,0 +1,220 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveBytes starts a server answering every request with size bytes,
+// gzip-compressed when gzipped is set
+func serveBytes(t *testing.T, size int, gzipped bool) *httptest.Server {
+	t.Helper()
+
+	body := []byte(strings.Repeat("x", size))
+	if gzipped {
+		body = compress(t, "gzip", string(body))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if gzipped {
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		gzipped bool
+		limit   int64
+		wantErr bool
+	}{
+		{"below limit", 512, false, 1024, false},
+		{"at limit", 1024, false, 1024, false},
+		{"over limit", 1025, false, 1024, true},
+		{"far over limit", 1 << 20, false, 1024, true},
+		{"unlimited", 1 << 20, false, 0, false},
+		// The limit applies to the decompressed size
+		{"compressed over limit", 64 * 1024, true, 1024, true},
+		{"compressed below limit", 512, true, 1024, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := serveBytes(t, tt.size, tt.gzipped)
+			client := NewHttpClient(5 * time.Second)
+			client.SetMaxResponseBytes(tt.limit)
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if tt.wantErr {
+				if !errors.Is(err, ErrResponseTooLarge) {
+					t.Errorf("reading %d bytes with limit %d: error = %v, want ErrResponseTooLarge", tt.size, tt.limit, err)
+				}
+				if int64(len(body)) > tt.limit {
+					t.Errorf("read %d bytes, want at most the limit %d", len(body), tt.limit)
+				}
+				return
+			}
+			if err != nil || len(body) != tt.size {
+				t.Errorf("read %d bytes (%v), want all %d", len(body), err, tt.size)
+			}
+		})
+	}
+}
+
+func TestMaxResponseBytesAppliesToPost(t *testing.T) {
+	server := serveBytes(t, 2048, false)
+	client := NewHttpClient(5 * time.Second)
+	client.SetMaxResponseBytes(1024)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("reading an oversized POST response: error = %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestSetTransportOptions(t *testing.T) {
+	defaults := http.DefaultTransport.(*http.Transport)
+
+	tests := []struct {
+		name               string
+		opts               TransportOptions
+		wantMaxIdle        int
+		wantMaxIdlePerHost int
+		wantIdleTimeout    time.Duration
+		wantNoKeepAlives   bool
+	}{
+		{"zero keeps defaults", TransportOptions{}, defaults.MaxIdleConns, defaults.MaxIdleConnsPerHost, defaults.IdleConnTimeout, false},
+		{"tuned", TransportOptions{MaxIdleConns: 20, MaxIdleConnsPerHost: 5, IdleConnTimeout: 30 * time.Second}, 20, 5, 30 * time.Second, false},
+		{"keep-alives disabled", TransportOptions{DisableKeepAlives: true}, defaults.MaxIdleConns, defaults.MaxIdleConnsPerHost, defaults.IdleConnTimeout, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHttpClient(time.Second)
+			client.SetTransportOptions(tt.opts)
+
+			transport := client.transport.base
+			if transport.MaxIdleConns != tt.wantMaxIdle || transport.MaxIdleConnsPerHost != tt.wantMaxIdlePerHost || transport.IdleConnTimeout != tt.wantIdleTimeout {
+				t.Errorf("transport pool = %d, %d per host, %v idle; want %d, %d per host, %v idle",
+					transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout,
+					tt.wantMaxIdle, tt.wantMaxIdlePerHost, tt.wantIdleTimeout)
+			}
+			if transport.DisableKeepAlives != tt.wantNoKeepAlives {
+				t.Errorf("DisableKeepAlives = %t, want %t", transport.DisableKeepAlives, tt.wantNoKeepAlives)
+			}
+		})
+	}
+
+	if defaults.MaxIdleConns == 20 {
+		t.Error("SetTransportOptions changed http.DefaultTransport")
+	}
+}
+
+func TestDisableKeepAlivesClosesConnections(t *testing.T) {
+	var connectionHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connectionHeaders = append(connectionHeaders, r.Header.Get("Connection"))
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(5 * time.Second)
+	client.SetTransportOptions(TransportOptions{DisableKeepAlives: true})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(connectionHeaders) != 1 || connectionHeaders[0] != "close" {
+		t.Errorf("Connection headers = %q, want close", connectionHeaders)
+	}
+}
+
+// serveStalledBody starts a server that sends the headers and part of the
+// body, then stalls until the client gives up
+func serveStalledBody(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jobs": [`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+	return server
+}
+
+func TestStalledBodyReadTimesOut(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientTimeout time.Duration
+		ctxTimeout    time.Duration
+	}{
+		{"context deadline", 5 * time.Second, 100 * time.Millisecond},
+		{"client timeout", 100 * time.Millisecond, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := serveStalledBody(t)
+			client := NewHttpClient(tt.clientTimeout)
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if !errors.Is(err, ErrReadTimeout) {
+				t.Errorf("read error = %v, want ErrReadTimeout", err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("read was cut off after %v, want about 100ms", elapsed)
+			}
+			if string(body) != `{"jobs": [` {
+				t.Errorf("read %q before the stall, want the partial body", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrNotModified is returned by Get and Do when conditional requests are enabled
+// and the server reports that a resource has not changed since the last fetch
+var ErrNotModified = errors.New("resource not modified")
+
+// unconditionalKey marks a context whose requests skip the conditional cache
+type unconditionalKey struct{}
+
+// Unconditional returns a context whose requests are sent without cache
+// validators, so that resources are fetched in full even when unchanged, e.g.
+// to fetch again a response whose body turned out to be broken
+func Unconditional(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unconditionalKey{}, true)
+}
+
+// validators holds the cache validators from the last successful response for a URL
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+// conditionalCache remembers ETag and Last-Modified headers per URL
+type conditionalCache struct {
+	mu      sync.Mutex
+	entries map[string]validators
+}
+
+func newConditionalCache() *conditionalCache {
+	return &conditionalCache{
+		entries: make(map[string]validators),
+	}
+}
+
+// prepare adds If-None-Match/If-Modified-Since headers to a GET request for a
+// URL fetched before, reporting whether the request is conditional
+func (c *conditionalCache) prepare(req *http.Request) (*http.Request, bool) {
+	if req.Method != http.MethodGet || req.Context().Value(unconditionalKey{}) != nil ||
+		req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return req, false
+	}
+
+	c.mu.Lock()
+	entry, exists := c.entries[req.URL.String()]
+	c.mu.Unlock()
+
+	if !exists {
+		return req, false
+	}
+
+	req = req.Clone(req.Context())
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+	return req, true
+}
+
+// storeOnEOF wraps the body of a successful GET response so that its
+// validators are stored once the body has been read to the end without
+// error. A body cut off by a timeout, the size limit or a dropped connection
+// leaves the cache as it was, so the next request fetches it in full again
+// rather than being told it has not changed.
+func (c *conditionalCache) storeOnEOF(req *http.Request, resp *http.Response) {
+	if req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	resp.Body = &validatedBody{
+		body:  resp.Body,
+		cache: c,
+		url:   req.URL.String(),
+		entry: validators{
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+		},
+	}
+}
+
+// store records the validators of a URL, forgetting it when there are none
+func (c *conditionalCache) store(url string, entry validators) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry.etag == "" && entry.lastModified == "" {
+		delete(c.entries, url)
+		return
+	}
+	c.entries[url] = entry
+}
+
+// validatedBody stores a response's validators when it is read to EOF
+// without an earlier read error
+type validatedBody struct {
+	body   io.ReadCloser
+	cache  *conditionalCache
+	url    string
+	entry  validators
+	failed bool
+	stored bool
+}
+
+func (b *validatedBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	switch {
+	case err == io.EOF:
+		if !b.failed && !b.stored {
+			b.stored = true
+			b.cache.store(b.url, b.entry)
+		}
+	case err != nil:
+		b.failed = true
+	}
+	return n, err
+}
+
+func (b *validatedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,180 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// validatingServer serves body with an ETag and Last-Modified, answering 304
+// to requests that carry a matching validator. It records the conditional
+// headers of each request.
+type validatingServer struct {
+	*httptest.Server
+	etag         string
+	lastModified string
+	requests     []http.Header
+}
+
+func newValidatingServer(t *testing.T, etag, lastModified string) *validatingServer {
+	t.Helper()
+
+	server := &validatingServer{etag: etag, lastModified: lastModified}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests = append(server.requests, r.Header.Clone())
+		if server.etag != "" && r.Header.Get("If-None-Match") == server.etag ||
+			server.lastModified != "" && r.Header.Get("If-Modified-Since") == server.lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if server.etag != "" {
+			w.Header().Set("ETag", server.etag)
+		}
+		if server.lastModified != "" {
+			w.Header().Set("Last-Modified", server.lastModified)
+		}
+		w.Write([]byte("jobs"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// get fetches url and returns the body, or the error
+func get(ctx context.Context, client *HttpClient, url string) (string, error) {
+	resp, err := client.GetWithContext(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestConditionalRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		etag         string
+		lastModified string
+		wantHeader   string
+	}{
+		{"etag", `"v1"`, "", "If-None-Match"},
+		{"last modified", "", "Wed, 01 May 2024 10:00:00 GMT", "If-Modified-Since"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newValidatingServer(t, tt.etag, tt.lastModified)
+			client := NewHttpClient(5 * time.Second)
+			client.SetConditionalRequests(true)
+
+			if body, err := get(context.Background(), client, server.URL); err != nil || body != "jobs" {
+				t.Fatalf("first fetch = %q, %v; want the body", body, err)
+			}
+			if _, err := get(context.Background(), client, server.URL); !errors.Is(err, ErrNotModified) {
+				t.Errorf("second fetch error = %v, want ErrNotModified", err)
+			}
+			if got := server.requests[1].Get(tt.wantHeader); got == "" {
+				t.Errorf("second request has no %s header", tt.wantHeader)
+			}
+
+			// A changed resource is fetched in full and its new validator remembered
+			server.etag, server.lastModified = `"v2"`, ""
+			if body, err := get(context.Background(), client, server.URL); err != nil || body != "jobs" {
+				t.Errorf("fetch after a change = %q, %v; want the body", body, err)
+			}
+			if _, err := get(context.Background(), client, server.URL); !errors.Is(err, ErrNotModified) {
+				t.Errorf("fetch after storing the new ETag error = %v, want ErrNotModified", err)
+			}
+		})
+	}
+}
+
+func TestConditionalRequestsDisabled(t *testing.T) {
+	server := newValidatingServer(t, `"v1"`, "")
+	client := NewHttpClient(5 * time.Second)
+
+	for i := 0; i < 2; i++ {
+		if body, err := get(context.Background(), client, server.URL); err != nil || body != "jobs" {
+			t.Errorf("fetch %d = %q, %v; want the body", i, body, err)
+		}
+	}
+	if server.requests[1].Get("If-None-Match") != "" {
+		t.Error("sent If-None-Match with conditional requests disabled")
+	}
+}
+
+func TestUnconditionalSkipsValidators(t *testing.T) {
+	server := newValidatingServer(t, `"v1"`, "")
+	client := NewHttpClient(5 * time.Second)
+	client.SetConditionalRequests(true)
+
+	if _, err := get(context.Background(), client, server.URL); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	body, err := get(Unconditional(context.Background()), client, server.URL)
+	if err != nil || body != "jobs" {
+		t.Errorf("unconditional fetch = %q, %v; want the full body", body, err)
+	}
+	if server.requests[1].Get("If-None-Match") != "" {
+		t.Error("unconditional request sent If-None-Match")
+	}
+}
+
+func TestConditionalCacheIsPerURL(t *testing.T) {
+	server := newValidatingServer(t, `"v1"`, "")
+	client := NewHttpClient(5 * time.Second)
+	client.SetConditionalRequests(true)
+
+	if _, err := get(context.Background(), client, server.URL+"/remoteok"); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if body, err := get(context.Background(), client, server.URL+"/remotive"); err != nil || body != "jobs" {
+		t.Errorf("fetch of another URL = %q, %v; want the body", body, err)
+	}
+}
+
+func TestConditionalCacheIgnoresUnreadBodies(t *testing.T) {
+	tests := []struct {
+		name string
+		read func(t *testing.T, client *HttpClient, url string)
+	}{
+		{"too large", func(t *testing.T, client *HttpClient, url string) {
+			client.SetMaxResponseBytes(2)
+			defer client.SetMaxResponseBytes(0)
+			if _, err := get(context.Background(), client, url); !errors.Is(err, ErrResponseTooLarge) {
+				t.Fatalf("fetch error = %v, want ErrResponseTooLarge", err)
+			}
+		}},
+		{"closed unread", func(t *testing.T, client *HttpClient, url string) {
+			resp, err := client.GetWithContext(context.Background(), url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newValidatingServer(t, `"v1"`, "")
+			client := NewHttpClient(5 * time.Second)
+			client.SetConditionalRequests(true)
+
+			tt.read(t, client, server.URL)
+			body, err := get(context.Background(), client, server.URL)
+			if err != nil || body != "jobs" {
+				t.Fatalf("fetch after an unread body = %q, %v; want the full body", body, err)
+			}
+			if server.requests[1].Get("If-None-Match") != "" {
+				t.Error("sent If-None-Match after the body was not read")
+			}
+			if _, err := get(context.Background(), client, server.URL); !errors.Is(err, ErrNotModified) {
+				t.Errorf("fetch after reading the body error = %v, want ErrNotModified", err)
+			}
+		})
+	}
+}
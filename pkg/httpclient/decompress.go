@@ -0,0 +1,105 @@
+package httpclient
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decompressingTransport requests gzip/deflate compressed responses and
+// transparently decompresses them, so callers always read plain bytes. It also
+// handles requests that set Accept-Encoding themselves, which Go's transport
+// would otherwise leave compressed.
+type decompressingTransport struct {
+	base *http.Transport
+}
+
+// newDecompressingTransport wraps the default transport with compression disabled,
+// since decompression is handled here instead. Proxies are taken from the
+// HTTP_PROXY/HTTPS_PROXY environment variables unless set explicitly.
+func newDecompressingTransport() *decompressingTransport {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.DisableCompression = true
+	return &decompressingTransport{base: base}
+}
+
+func (t *decompressingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding != "gzip" && encoding != "deflate" {
+		return resp, nil
+	}
+	if req.Method == http.MethodHead || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return resp, nil
+	}
+
+	resp.Body = &decompressingBody{body: resp.Body, encoding: encoding}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// decompressingBody decodes a compressed response body on first read
+type decompressingBody struct {
+	body     io.ReadCloser
+	encoding string
+	reader   io.Reader
+	err      error
+}
+
+func (b *decompressingBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		// Keep the reader nil on error, since a failed gzip.NewReader returns
+		// a nil *gzip.Reader that would panic when closed
+		reader, err := b.newReader()
+		if err != nil {
+			b.err = err
+		} else {
+			b.reader = reader
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// newReader creates the decoder for the body's content encoding
+func (b *decompressingBody) newReader() (io.Reader, error) {
+	if b.encoding == "gzip" {
+		return gzip.NewReader(b.body)
+	}
+
+	// "deflate" should be zlib-wrapped, but some servers send raw deflate data
+	buffered := bufio.NewReader(b.body)
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
+
+func (b *decompressingBody) Close() error {
+	if closer, ok := b.reader.(io.Closer); ok {
+		closer.Close()
+	}
+	return b.body.Close()
+}
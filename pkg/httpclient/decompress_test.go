@@ -0,0 +1,111 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// compress encodes body with the given content encoding
+func compress(t *testing.T, encoding, body string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+	case "raw deflate":
+		writer, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	}
+	if _, err := writer.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompressesResponses(t *testing.T) {
+	const body = `[{"id": "1", "position": "Go Developer"}]`
+
+	tests := []struct {
+		name            string
+		contentEncoding string
+		encoded         []byte
+		requestHeader   string // Accept-Encoding set by the caller, if any
+	}{
+		{"gzip", "gzip", compress(t, "gzip", body), ""},
+		{"zlib deflate", "deflate", compress(t, "deflate", body), ""},
+		{"raw deflate", "deflate", compress(t, "raw deflate", body), ""},
+		{"uppercase encoding", "GZIP", compress(t, "gzip", body), ""},
+		{"caller sets Accept-Encoding", "gzip", compress(t, "gzip", body), "gzip"},
+		{"uncompressed", "", []byte(body), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var acceptEncoding string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				acceptEncoding = r.Header.Get("Accept-Encoding")
+				if tt.contentEncoding != "" {
+					w.Header().Set("Content-Encoding", tt.contentEncoding)
+				}
+				w.Write(tt.encoded)
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			if tt.requestHeader != "" {
+				req.Header.Set("Accept-Encoding", tt.requestHeader)
+			}
+			resp, err := NewHttpClient(5 * time.Second).Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			defer resp.Body.Close()
+
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if string(got) != body {
+				t.Errorf("body = %q, want %q", got, body)
+			}
+			if resp.Header.Get("Content-Encoding") != "" {
+				t.Errorf("Content-Encoding = %q left on the decompressed response", resp.Header.Get("Content-Encoding"))
+			}
+			if tt.requestHeader == "" && !strings.Contains(acceptEncoding, "gzip") {
+				t.Errorf("Accept-Encoding = %q, want compression requested", acceptEncoding)
+			}
+		})
+	}
+}
+
+func TestDecompressionReportsCorruptBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("not gzip data"))
+	}))
+	defer server.Close()
+
+	resp, err := NewHttpClient(5 * time.Second).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.ReadAll(resp.Body); err == nil {
+		t.Error("reading a corrupt gzip body succeeded, want an error")
+	}
+}
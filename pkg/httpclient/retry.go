@@ -0,0 +1,61 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy retries idempotent requests (GET and HEAD) that fail with a
+// network error or a 5xx response, using exponential backoff
+type RetryPolicy struct {
+	MaxAttempts  int           // total attempts including the first, 1 or less disables retries
+	InitialDelay time.Duration // delay before the first retry, defaults to 500ms
+	MaxDelay     time.Duration // upper bound for the backoff delay, 0 for no cap
+}
+
+// SetRetryPolicy enables retries for idempotent requests. Retries are off by
+// default because PowerScraper already retries whole source fetches.
+func (h *HttpClient) SetRetryPolicy(policy RetryPolicy) {
+	h.retryPolicy = policy
+}
+
+// doWithRetry performs a request, retrying it according to the retry policy
+func (h *HttpClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := h.retryPolicy
+	if policy.MaxAttempts <= 1 || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return h.client.Do(req)
+	}
+
+	delay := policy.InitialDelay
+	if delay <= 0 {
+		delay = 500 * time.Millisecond
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := h.client.Do(req)
+		retryable := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= policy.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		// Drain the failed response so the connection can be reused
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+}
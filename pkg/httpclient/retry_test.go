@@ -0,0 +1,125 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with a 503, then succeeds
+func flakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &attempts
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		maxAttempts  int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{"succeeds on the third try", http.MethodGet, 3, http.StatusOK, 3},
+		{"HEAD is retried", http.MethodHead, 5, http.StatusOK, 3},
+		{"gives up after max attempts", http.MethodGet, 2, http.StatusServiceUnavailable, 2},
+		{"off by default", http.MethodGet, 0, http.StatusServiceUnavailable, 1},
+		{"POST is not retried", http.MethodPost, 5, http.StatusServiceUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, attempts := flakyServer(t, 2)
+			client := NewHttpClient(5 * time.Second)
+			client.SetRetryPolicy(RetryPolicy{MaxAttempts: tt.maxAttempts, InitialDelay: time.Millisecond})
+
+			req, _ := http.NewRequest(tt.method, server.URL, strings.NewReader(""))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("made %d attempts, want %d", got, tt.wantAttempts)
+			}
+			if tt.wantStatus == http.StatusOK && tt.method == http.MethodGet {
+				if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+					t.Errorf("body = %q, want the successful response", body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(5 * time.Second)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("made %d attempts for a 404, want 1", got)
+	}
+}
+
+func TestRetryPolicyRetriesNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := NewHttpClient(5 * time.Second)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialDelay: 20 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.Get(url); err == nil {
+		t.Fatal("Get of a closed server succeeded, want an error")
+	}
+	// Two retries wait 20ms and then 40ms
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("gave up after %v, want the backoff delays to have passed", elapsed)
+	}
+}
+
+func TestRetryPolicyStopsWhenCanceled(t *testing.T) {
+	server, attempts := flakyServer(t, 100)
+	client := NewHttpClient(5 * time.Second)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 10, InitialDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetWithContext(ctx, server.URL); err == nil {
+		t.Error("Get succeeded after the context expired, want an error")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("made %d attempts, want 1 before the context expired", got)
+	}
+}
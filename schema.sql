@@ -2,25 +2,69 @@ DROP TABLE IF EXISTS jobs;
 
 CREATE TABLE jobs (
     id SERIAL PRIMARY KEY,
+    hash TEXT,
     title TEXT NOT NULL,
     company TEXT NOT NULL,
     location TEXT,
     url TEXT,
     description TEXT,
     salary TEXT,
+    salary_min INTEGER,
+    salary_max INTEGER,
+    salary_currency TEXT,
+    salary_period TEXT,
     posted_date TIMESTAMP WITH TIME ZONE,
     source TEXT NOT NULL,
     job_category TEXT,
     job_type TEXT,
+    work_mode TEXT,
+    experience_level TEXT,
+    tags JSONB,
     scraped_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 );
 
 CREATE INDEX idx_jobs_source ON jobs(source);
+CREATE INDEX idx_jobs_hash ON jobs(hash);
 CREATE INDEX idx_jobs_category ON jobs(job_category);
 CREATE INDEX idx_jobs_job_type ON jobs(job_type);
+CREATE INDEX idx_jobs_work_mode ON jobs(work_mode);
+CREATE INDEX idx_jobs_experience_level ON jobs(experience_level);
 CREATE INDEX idx_jobs_posted_date ON jobs(posted_date);
 CREATE INDEX idx_jobs_scraped_at ON jobs(scraped_at);
 CREATE INDEX idx_jobs_company ON jobs(company);
 CREATE INDEX idx_jobs_location ON jobs(location);
 
+-- Full-text search over title and description, used by search_jobs
+CREATE INDEX idx_jobs_search ON jobs USING GIN (
+    to_tsvector('english', coalesce(title, '') || ' ' || coalesce(description, ''))
+);
+
+CREATE OR REPLACE FUNCTION search_jobs(search_query TEXT, max_results INTEGER)
+RETURNS SETOF jobs
+LANGUAGE sql STABLE
+AS $$
+    SELECT *
+    FROM jobs
+    WHERE to_tsvector('english', coalesce(title, '') || ' ' || coalesce(description, ''))
+          @@ plainto_tsquery('english', search_query)
+    ORDER BY ts_rank(
+                 setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+                 setweight(to_tsvector('english', coalesce(description, '')), 'B'),
+                 plainto_tsquery('english', search_query)
+             ) DESC,
+             posted_date DESC NULLS LAST
+    LIMIT CASE WHEN max_results > 0 THEN max_results END;
+$$;
+
+-- Number of jobs search_jobs matches, used for the total of paged searches
+CREATE OR REPLACE FUNCTION count_search_jobs(search_query TEXT)
+RETURNS BIGINT
+LANGUAGE sql STABLE
+AS $$
+    SELECT count(*)
+    FROM jobs
+    WHERE to_tsvector('english', coalesce(title, '') || ' ' || coalesce(description, ''))
+          @@ plainto_tsquery('english', search_query);
+$$;
+
 CREATE UNIQUE INDEX idx_jobs_unique ON jobs(title, company, url) WHERE url IS NOT NULL;
\ No newline at end of file